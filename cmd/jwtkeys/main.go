@@ -0,0 +1,142 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/pkg/jwtkeys"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+const usage = `Usage: jwtkeys <command> [flags]
+
+Commands:
+  rotate    generate a new signing key and make it active
+  generate  generate a new key without activating it, publish it before activation when running several instances
+  activate  make an existing key the signing key
+  retire    remove a key, tokens signed with it stop verifying
+  list      list keys
+
+Keys dir and algorithm default to the jwt section of the config file.
+Running servers pick up changes on restart.
+`
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg := loadConfig()
+
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	dir := fs.String("dir", cfg.KeysDir, "keys directory")
+	alg := fs.String("alg", cfg.Algorithm, "signing algorithm, RS256 or ES256")
+	kid := fs.String("kid", "", "key id")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+
+	if *dir == "" {
+		log.Fatal("keys directory is not set, use -dir or jwt.KeysDir in config")
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "rotate":
+		err = generate(*dir, *alg, true)
+	case "generate":
+		err = generate(*dir, *alg, false)
+	case "activate":
+		err = activate(*dir, *kid)
+	case "retire":
+		if *kid == "" {
+			log.Fatal("-kid is required")
+		}
+		err = jwtkeys.Retire(*dir, *kid)
+	case "list":
+		err = list(*dir)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Config file is optional, flags are enough when it is missing
+func loadConfig() config.JWT {
+	cfgFile, err := config.LoadConfig(utils.GetConfigPath(os.Getenv("config")))
+	if err != nil {
+		return config.JWT{Algorithm: jwtkeys.AlgorithmES256}
+	}
+
+	cfg, err := config.ParseConfig(cfgFile)
+	if err != nil {
+		log.Fatalf("ParseConfig: %v", err)
+	}
+
+	if cfg.JWT.Algorithm == "" {
+		cfg.JWT.Algorithm = jwtkeys.AlgorithmES256
+	}
+
+	return cfg.JWT
+}
+
+func generate(dir string, alg string, activateKey bool) error {
+	key, data, err := jwtkeys.Generate(alg)
+	if err != nil {
+		return err
+	}
+
+	if err = jwtkeys.WriteKey(dir, key.ID, data); err != nil {
+		return err
+	}
+	log.Printf("Generated %s key %s", key.Algorithm, key.ID)
+
+	if !activateKey {
+		return nil
+	}
+
+	return activate(dir, key.ID)
+}
+
+func activate(dir string, kid string) error {
+	if kid == "" {
+		return fmt.Errorf("-kid is required")
+	}
+
+	if err := jwtkeys.Activate(dir, kid); err != nil {
+		return err
+	}
+	log.Printf("Activated key %s", kid)
+
+	return nil
+}
+
+func list(dir string) error {
+	keys, err := jwtkeys.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	activeID, _ := jwtkeys.ActiveKeyID(dir)
+	for _, key := range keys {
+		status := "verify"
+		if key.CanSign() {
+			status = "sign/verify"
+		}
+		if key.ID == activeID {
+			status += " (active)"
+		}
+		fmt.Printf("%s\t%s\t%s\n", key.ID, key.Algorithm, status)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/AleksK1NG/api-mc/config"
+	storageRepository "github.com/AleksK1NG/api-mc/internal/storage/repository"
+	storageUseCase "github.com/AleksK1NG/api-mc/internal/storage/usecase"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+	"github.com/AleksK1NG/api-mc/pkg/db/aws"
+	"github.com/AleksK1NG/api-mc/pkg/db/postgres"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+const (
+	defaultBucket      = "avatars"
+	defaultGracePeriod = 24 * time.Hour
+	reconcileTimeout   = 30 * time.Minute
+)
+
+// Remove bucket objects no row references any more, prints json report.
+// Run it periodically, for example from cron.
+func main() {
+	log.SetFlags(0)
+
+	bucket := flag.String("bucket", defaultBucket, "bucket to reconcile")
+	gracePeriod := flag.Duration("grace", defaultGracePeriod, "keep unreferenced objects younger than this")
+	dryRun := flag.Bool("dry-run", false, "report orphaned objects without removing them")
+	flag.Parse()
+
+	cfgFile, err := config.LoadConfig(utils.GetConfigPath(os.Getenv("config")))
+	if err != nil {
+		log.Fatalf("LoadConfig: %v", err)
+	}
+
+	cfg, err := config.ParseConfig(cfgFile)
+	if err != nil {
+		log.Fatalf("ParseConfig: %v", err)
+	}
+
+	appLogger := logger.NewApiLogger(cfg)
+	appLogger.InitLogger()
+
+	psqlDB, err := postgres.NewPsqlDB(cfg)
+	if err != nil {
+		log.Fatalf("Postgresql init: %v", err)
+	}
+	defer psqlDB.Close()
+
+	awsClient, err := aws.NewAWSClient(cfg.AWS.Endpoint, cfg.AWS.MinioAccessKey, cfg.AWS.MinioSecretKey, cfg.AWS.UseSSL)
+	if err != nil {
+		log.Fatalf("AWS Client init: %v", err)
+	}
+
+	blobStore, err := blobstore.NewBlobStore(cfg, awsClient)
+	if err != nil {
+		log.Fatalf("BlobStore init: %v", err)
+	}
+
+	storageUC := storageUseCase.NewStorageUseCase(
+		cfg,
+		storageRepository.NewStorageRepository(psqlDB),
+		storageRepository.NewStorageAWSRepository(blobStore),
+		appLogger,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer cancel()
+
+	report, err := storageUC.Reconcile(ctx, *bucket, *gracePeriod, *dryRun)
+	if err != nil {
+		log.Fatalf("Reconcile: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		log.Fatal(err)
+	}
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
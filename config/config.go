@@ -10,33 +10,47 @@ import (
 
 // App config struct
 type Config struct {
-	Server   ServerConfig
-	Postgres PostgresConfig
-	Redis    RedisConfig
-	MongoDB  MongoDB
-	Cookie   Cookie
-	Store    Store
-	Session  Session
-	Metrics  Metrics
-	Logger   Logger
-	AWS      AWS
-	Jaeger   Jaeger
+	Server         ServerConfig
+	Postgres       PostgresConfig
+	Redis          RedisConfig
+	MongoDB        MongoDB
+	Cookie         Cookie
+	Store          Store
+	Session        Session
+	Metrics        Metrics
+	Logger         Logger
+	AWS            AWS
+	Jaeger         Jaeger
+	Mailer         Mailer
+	Auth           Auth
+	OIDC           OIDC
+	LoginThrottle  LoginThrottle
+	PasswordPolicy PasswordPolicy
+	PasswordHash   PasswordHash
+	GDPR           GDPR
+	JWT            JWT
+	Uploads        Uploads
+	Search         Search
 }
 
 // Server config struct
 type ServerConfig struct {
-	AppVersion        string
-	Port              string
-	PprofPort         string
-	Mode              string
-	JwtSecretKey      string
-	CookieName        string
-	ReadTimeout       time.Duration
-	WriteTimeout      time.Duration
-	SSL               bool
-	CtxDefaultTimeout time.Duration
-	CSRF              bool
-	Debug             bool
+	AppVersion         string
+	Port               string
+	PprofPort          string
+	Mode               string
+	JwtSecretKey       string
+	CookieName         string
+	ReadTimeout        time.Duration
+	WriteTimeout       time.Duration
+	SSL                bool
+	CtxDefaultTimeout  time.Duration
+	CSRF               bool
+	Debug              bool
+	AccessTokenExpire  int
+	RefreshTokenExpire int
+	// CIDRs of reverse proxies allowed to set X-Forwarded-For, client address is used directly when empty
+	TrustedProxies []string
 }
 
 // Logger config
@@ -87,9 +101,10 @@ type Cookie struct {
 
 // Session config
 type Session struct {
-	Prefix string
-	Name   string
-	Expire int
+	Prefix              string
+	Name                string
+	Expire              int
+	ImpersonationExpire int
 }
 
 // Metrics config
@@ -98,9 +113,12 @@ type Metrics struct {
 	ServiceName string
 }
 
-// Store config
+// Store config, backend is minio or filesystem.
+// Filesystem backend keeps objects in ImagesFolder and serves public ones under PublicURL.
 type Store struct {
+	Backend      string
 	ImagesFolder string
+	PublicURL    string
 }
 
 // AWS S3
@@ -119,6 +137,106 @@ type Jaeger struct {
 	LogSpans    bool
 }
 
+// Mailer config
+type Mailer struct {
+	Driver   string
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// Auth config
+type Auth struct {
+	PasswordResetURL         string
+	PasswordResetExpire      int
+	EmailVerificationURL     string
+	EmailVerificationExpire  int
+	RequireEmailVerification bool
+	TOTPIssuer               string
+	TwoFactorChallengeExpire int
+	InviteOnly               bool
+}
+
+// Password policy config
+type PasswordPolicy struct {
+	MinLength      int
+	MaxLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+}
+
+// JWT signing keys config, empty KeysDir keeps HS256 with Server.JwtSecretKey
+type JWT struct {
+	KeysDir   string
+	Algorithm string
+}
+
+// Password hashing config, fields mirror passwords.Params, memory is in KiB
+type PasswordHash struct {
+	Algorithm   string
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+	BcryptCost  int
+}
+
+// Login brute force protection config, durations in seconds
+type LoginThrottle struct {
+	MaxEmailAttempts int
+	MaxIPAttempts    int
+	AttemptsWindow   int
+	LockoutDuration  int
+	DelayAfter       int
+	BaseDelay        int
+	MaxDelay         int
+}
+
+// GDPR data export config, export expire in seconds
+type GDPR struct {
+	ExportBucket string
+	ExportExpire int
+}
+
+// Direct uploads config, ticket expire in seconds, sizes in bytes
+type Uploads struct {
+	AvatarBucket     string
+	NewsImageBucket  string
+	TicketExpire     int
+	MaxAvatarSize    int64
+	MaxNewsImageSize int64
+}
+
+// News full text search config, language is a postgres text search configuration used for stemming
+type Search struct {
+	Language string
+}
+
+// OIDC config
+type OIDC struct {
+	StateExpire int
+	Providers   []OIDCProvider
+}
+
+// OIDC provider config, endpoints are discovered from Issuer when empty
+type OIDCProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	JWKSURL      string
+}
+
 // Load config file from given path
 func LoadConfig(filename string) (*viper.Viper, error) {
 	v := viper.New()
@@ -0,0 +1,10 @@
+package apikeys
+
+import "github.com/labstack/echo/v4"
+
+// API keys HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	GetMyKeys() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+}
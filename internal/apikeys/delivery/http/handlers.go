@@ -0,0 +1,142 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/apikeys"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// API keys handlers
+type apiKeysHandlers struct {
+	cfg       *config.Config
+	apiKeysUC apikeys.UseCase
+	logger    logger.Logger
+}
+
+// NewAPIKeysHandlers API keys handlers constructor
+func NewAPIKeysHandlers(cfg *config.Config, apiKeysUC apikeys.UseCase, log logger.Logger) apikeys.Handlers {
+	return &apiKeysHandlers{cfg: cfg, apiKeysUC: apiKeysUC, logger: log}
+}
+
+// Create godoc
+// @Summary Create API key
+// @Description create personal API key, plain key is returned only once, required auth session cookie
+// @Tags API keys
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.APIKeyWithSecret
+// @Failure 400 {object} httpErrors.RestError
+// @Router /api-keys [post]
+func (h *apiKeysHandlers) Create() echo.HandlerFunc {
+	type CreateAPIKey struct {
+		Name      string     `json:"name" validate:"required,lte=64"`
+		Scopes    []string   `json:"scopes" validate:"omitempty,dive,required"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "apiKeysHandlers.Create")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		request := &CreateAPIKey{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		createdKey, err := h.apiKeysUC.Create(ctx, &models.APIKey{
+			UserID:    user.UserID,
+			Name:      request.Name,
+			Scopes:    request.Scopes,
+			ExpiresAt: request.ExpiresAt,
+		})
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdKey)
+	}
+}
+
+// GetMyKeys godoc
+// @Summary Get API keys
+// @Description get API keys of current user without secrets, required auth session cookie
+// @Tags API keys
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.APIKey
+// @Failure 500 {object} httpErrors.RestError
+// @Router /api-keys [get]
+func (h *apiKeysHandlers) GetMyKeys() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "apiKeysHandlers.GetMyKeys")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		keys, err := h.apiKeysUC.GetByUserID(ctx, user.UserID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, keys)
+	}
+}
+
+// Delete godoc
+// @Summary Revoke API key
+// @Description revoke API key of current user, required auth session cookie
+// @Tags API keys
+// @Accept json
+// @Produce json
+// @Param key_id path string true "key_id"
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /api-keys/{key_id} [delete]
+func (h *apiKeysHandlers) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "apiKeysHandlers.Delete")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		keyID, err := uuid.Parse(c.Param("key_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.apiKeysUC.Delete(ctx, user.UserID, keyID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
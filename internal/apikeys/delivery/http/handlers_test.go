@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/apikeys/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+func TestAPIKeysHandlers_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAPIKeysUC := mock.NewMockUseCase(ctrl)
+	apiKeysHandlers := NewAPIKeysHandlers(cfg, mockAPIKeysUC, apiLogger)
+
+	user := &models.User{UserID: uuid.New()}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/api-keys", strings.NewReader(`{"name":"ci","scopes":["news:write"]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, res)
+	c.Set("user", user)
+
+	createdKey := &models.APIKeyWithSecret{
+		APIKey: &models.APIKey{KeyID: uuid.New(), UserID: user.UserID, Name: "ci", Scopes: models.Scopes{models.ScopeNewsWrite}},
+		Key:    "prefix.secret",
+	}
+	mockAPIKeysUC.EXPECT().Create(gomock.Any(), gomock.Eq(&models.APIKey{
+		UserID: user.UserID,
+		Name:   "ci",
+		Scopes: models.Scopes{models.ScopeNewsWrite},
+	})).Return(createdKey, nil)
+
+	err := apiKeysHandlers.Create()(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, res.Code)
+	require.Contains(t, res.Body.String(), `"key":"prefix.secret"`)
+	require.NotContains(t, res.Body.String(), "key_hash")
+}
@@ -0,0 +1,15 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/apikeys"
+	"github.com/AleksK1NG/api-mc/internal/middleware"
+)
+
+// Map API keys routes, keys are managed only with session, not with another API key
+func MapAPIKeysRoutes(apiKeysGroup *echo.Group, h apikeys.Handlers, mw *middleware.MiddlewareManager) {
+	apiKeysGroup.POST("", h.Create(), mw.AuthSessionMiddleware, mw.CSRF, mw.DenyImpersonation)
+	apiKeysGroup.GET("", h.GetMyKeys(), mw.AuthSessionMiddleware)
+	apiKeysGroup.DELETE("/:key_id", h.Delete(), mw.AuthSessionMiddleware, mw.CSRF)
+}
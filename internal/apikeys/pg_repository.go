@@ -0,0 +1,19 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package apikeys
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// API keys repository interface
+type Repository interface {
+	Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	UpdateLastUsed(ctx context.Context, keyID uuid.UUID) error
+	Delete(ctx context.Context, userID uuid.UUID, keyID uuid.UUID) error
+}
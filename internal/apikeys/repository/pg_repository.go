@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/apikeys"
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// API keys Repository
+type apiKeysRepo struct {
+	db *sqlx.DB
+}
+
+// API keys Repository constructor
+func NewAPIKeysRepository(db *sqlx.DB) apikeys.Repository {
+	return &apiKeysRepo{db: db}
+}
+
+// Create new API key
+func (r *apiKeysRepo) Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "apiKeysRepo.Create")
+	defer span.Finish()
+
+	k := &models.APIKey{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createAPIKeyQuery,
+		key.UserID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		key.ExpiresAt,
+	).StructScan(k); err != nil {
+		return nil, errors.Wrap(err, "apiKeysRepo.Create.StructScan")
+	}
+
+	return k, nil
+}
+
+// Get all API keys of user
+func (r *apiKeysRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "apiKeysRepo.GetByUserID")
+	defer span.Finish()
+
+	keys := make([]*models.APIKey, 0)
+	if err := r.db.SelectContext(ctx, &keys, getAPIKeysByUserIDQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "apiKeysRepo.GetByUserID.SelectContext")
+	}
+
+	return keys, nil
+}
+
+// Find API key by its public prefix
+func (r *apiKeysRepo) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "apiKeysRepo.GetByPrefix")
+	defer span.Finish()
+
+	k := &models.APIKey{}
+	if err := r.db.GetContext(ctx, k, getAPIKeyByPrefixQuery, prefix); err != nil {
+		return nil, errors.Wrap(err, "apiKeysRepo.GetByPrefix.GetContext")
+	}
+
+	return k, nil
+}
+
+// Set API key last usage time
+func (r *apiKeysRepo) UpdateLastUsed(ctx context.Context, keyID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "apiKeysRepo.UpdateLastUsed")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, updateAPIKeyLastUsedQuery, keyID); err != nil {
+		return errors.Wrap(err, "apiKeysRepo.UpdateLastUsed.ExecContext")
+	}
+
+	return nil
+}
+
+// Revoke API key of user
+func (r *apiKeysRepo) Delete(ctx context.Context, userID uuid.UUID, keyID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "apiKeysRepo.Delete")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteAPIKeyQuery, keyID, userID)
+	if err != nil {
+		return errors.Wrap(err, "apiKeysRepo.Delete.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "apiKeysRepo.Delete.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "apiKeysRepo.Delete.rowsAffected")
+	}
+
+	return nil
+}
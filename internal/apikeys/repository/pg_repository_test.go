@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+var apiKeyColumns = []string{
+	"key_id", "user_id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "created_at",
+}
+
+func TestAPIKeysRepo_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	apiKeysRepo := NewAPIKeysRepository(sqlxDB)
+
+	t.Run("Create", func(t *testing.T) {
+		key := &models.APIKey{
+			UserID:  uuid.New(),
+			Name:    "ci",
+			Prefix:  "0a1b2c3d4e5f",
+			KeyHash: "hash",
+			Scopes:  models.Scopes{models.ScopeNewsWrite, models.ScopeCommentsWrite},
+		}
+
+		rows := sqlmock.NewRows(apiKeyColumns).AddRow(
+			uuid.New(), key.UserID, key.Name, key.Prefix, key.KeyHash, "news:write comments:write", nil, nil, time.Now())
+
+		mock.ExpectQuery(createAPIKeyQuery).
+			WithArgs(key.UserID, key.Name, key.Prefix, key.KeyHash, "news:write comments:write", nil).
+			WillReturnRows(rows)
+
+		createdKey, err := apiKeysRepo.Create(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, key.Prefix, createdKey.Prefix)
+		require.Equal(t, key.Scopes, createdKey.Scopes)
+	})
+}
+
+func TestAPIKeysRepo_GetByPrefix(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	apiKeysRepo := NewAPIKeysRepository(sqlxDB)
+
+	t.Run("GetByPrefix", func(t *testing.T) {
+		prefix := "0a1b2c3d4e5f"
+		expiresAt := time.Now().Add(time.Hour)
+
+		rows := sqlmock.NewRows(apiKeyColumns).AddRow(
+			uuid.New(), uuid.New(), "ci", prefix, "hash", "", expiresAt, nil, time.Now())
+
+		mock.ExpectQuery(getAPIKeyByPrefixQuery).WithArgs(prefix).WillReturnRows(rows)
+
+		key, err := apiKeysRepo.GetByPrefix(context.Background(), prefix)
+		require.NoError(t, err)
+		require.Empty(t, key.Scopes)
+		require.NotNil(t, key.ExpiresAt)
+	})
+}
+
+func TestAPIKeysRepo_Delete(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	apiKeysRepo := NewAPIKeysRepository(sqlxDB)
+
+	t.Run("Delete", func(t *testing.T) {
+		userID := uuid.New()
+		keyID := uuid.New()
+
+		mock.ExpectExec(deleteAPIKeyQuery).WithArgs(keyID, userID).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := apiKeysRepo.Delete(context.Background(), userID, keyID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete not owned", func(t *testing.T) {
+		userID := uuid.New()
+		keyID := uuid.New()
+
+		mock.ExpectExec(deleteAPIKeyQuery).WithArgs(keyID, userID).WillReturnResult(sqlmock.NewResult(1, 0))
+
+		err := apiKeysRepo.Delete(context.Background(), userID, keyID)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,23 @@
+package repository
+
+const (
+	createAPIKeyQuery = `INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at, created_at) 
+						VALUES ($1, $2, $3, $4, string_to_array($5, ' '), $6, now()) 
+						RETURNING key_id, user_id, name, prefix, key_hash, array_to_string(scopes, ' ') AS scopes, 
+						expires_at, last_used_at, created_at`
+
+	getAPIKeysByUserIDQuery = `SELECT key_id, user_id, name, prefix, key_hash, array_to_string(scopes, ' ') AS scopes, 
+						expires_at, last_used_at, created_at 
+						FROM api_keys 
+						WHERE user_id = $1 
+						ORDER BY created_at DESC`
+
+	getAPIKeyByPrefixQuery = `SELECT key_id, user_id, name, prefix, key_hash, array_to_string(scopes, ' ') AS scopes, 
+						expires_at, last_used_at, created_at 
+						FROM api_keys 
+						WHERE prefix = $1`
+
+	updateAPIKeyLastUsedQuery = `UPDATE api_keys SET last_used_at = now() WHERE key_id = $1`
+
+	deleteAPIKeyQuery = `DELETE FROM api_keys WHERE key_id = $1 AND user_id = $2`
+)
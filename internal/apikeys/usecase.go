@@ -0,0 +1,18 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package apikeys
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// API keys UseCase interface
+type UseCase interface {
+	Create(ctx context.Context, key *models.APIKey) (*models.APIKeyWithSecret, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+	Delete(ctx context.Context, userID uuid.UUID, keyID uuid.UUID) error
+	Authenticate(ctx context.Context, key string) (*models.APIKey, error)
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/apikeys"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+const (
+	prefixBytes = 6
+	secretBytes = 32
+	// Last usage time is written at most once per interval to avoid db write on every request
+	lastUsedInterval = time.Minute
+)
+
+// API keys UseCase
+type apiKeysUC struct {
+	cfg         *config.Config
+	apiKeysRepo apikeys.Repository
+	logger      logger.Logger
+}
+
+// API keys UseCase constructor
+func NewAPIKeysUseCase(cfg *config.Config, apiKeysRepo apikeys.Repository, log logger.Logger) apikeys.UseCase {
+	return &apiKeysUC{cfg: cfg, apiKeysRepo: apiKeysRepo, logger: log}
+}
+
+// Create API key, plain key is returned only here
+func (u *apiKeysUC) Create(ctx context.Context, key *models.APIKey) (*models.APIKeyWithSecret, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "apiKeysUC.Create")
+	defer span.Finish()
+
+	scopes, err := normalizeScopes(key.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidAPIKeyExpiry.Error(), nil)
+	}
+
+	prefix, err := generatePrefix()
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "apiKeysUC.Create.generatePrefix"))
+	}
+	secret, err := utils.GenerateRandomToken(secretBytes)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "apiKeysUC.Create.GenerateRandomToken"))
+	}
+	plainKey := prefix + "." + secret
+
+	createdKey, err := u.apiKeysRepo.Create(ctx, &models.APIKey{
+		UserID:    key.UserID,
+		Name:      strings.TrimSpace(key.Name),
+		Prefix:    prefix,
+		KeyHash:   utils.HashToken(plainKey),
+		Scopes:    scopes,
+		ExpiresAt: key.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.APIKeyWithSecret{APIKey: createdKey, Key: plainKey}, nil
+}
+
+// Get API keys of user
+func (u *apiKeysUC) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "apiKeysUC.GetByUserID")
+	defer span.Finish()
+
+	return u.apiKeysRepo.GetByUserID(ctx, userID)
+}
+
+// Revoke API key of user
+func (u *apiKeysUC) Delete(ctx context.Context, userID uuid.UUID, keyID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "apiKeysUC.Delete")
+	defer span.Finish()
+
+	return u.apiKeysRepo.Delete(ctx, userID, keyID)
+}
+
+// Find valid API key by plain key
+func (u *apiKeysUC) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "apiKeysUC.Authenticate")
+	defer span.Finish()
+
+	parts := strings.SplitN(strings.TrimSpace(key), ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, httpErrors.NewRestError(http.StatusUnauthorized, httpErrors.InvalidAPIKey.Error(), nil)
+	}
+
+	apiKey, err := u.apiKeysRepo.GetByPrefix(ctx, parts[0])
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, httpErrors.NewRestError(http.StatusUnauthorized, httpErrors.InvalidAPIKey.Error(), nil)
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(utils.HashToken(key))) != 1 {
+		return nil, httpErrors.NewRestError(http.StatusUnauthorized, httpErrors.InvalidAPIKey.Error(), nil)
+	}
+
+	now := time.Now()
+	if apiKey.Expired(now) {
+		return nil, httpErrors.NewRestError(http.StatusUnauthorized, httpErrors.InvalidAPIKey.Error(), nil)
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedInterval {
+		if err = u.apiKeysRepo.UpdateLastUsed(ctx, apiKey.KeyID); err != nil {
+			u.logger.Errorf("apiKeysUC.Authenticate.UpdateLastUsed: %s", err)
+		}
+	}
+
+	return apiKey, nil
+}
+
+// Check scopes are known and remove duplicates
+func normalizeScopes(scopes models.Scopes) (models.Scopes, error) {
+	result := make(models.Scopes, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !models.APIKeyScopes.Has(scope) {
+			return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidAPIKeyScope.Error(), nil)
+		}
+		if !result.Has(scope) {
+			result = append(result, scope)
+		}
+	}
+	return result, nil
+}
+
+func generatePrefix() (string, error) {
+	b := make([]byte, prefixBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
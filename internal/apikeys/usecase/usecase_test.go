@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/apikeys/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+func TestAPIKeysUC_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAPIKeysRepo := mock.NewMockRepository(ctrl)
+	apiKeysUC := NewAPIKeysUseCase(cfg, mockAPIKeysRepo, apiLogger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Create", func(t *testing.T) {
+		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiKeysUC.Create")
+		defer span.Finish()
+
+		var stored *models.APIKey
+		mockAPIKeysRepo.EXPECT().Create(ctxWithTrace, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+				stored = key
+				return key, nil
+			},
+		)
+
+		createdKey, err := apiKeysUC.Create(ctx, &models.APIKey{
+			UserID: userID,
+			Name:   " ci ",
+			Scopes: models.Scopes{models.ScopeNewsWrite, models.ScopeNewsWrite},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "ci", stored.Name)
+		require.Equal(t, models.Scopes{models.ScopeNewsWrite}, stored.Scopes)
+		require.True(t, strings.HasPrefix(createdKey.Key, stored.Prefix+"."))
+		require.Equal(t, utils.HashToken(createdKey.Key), stored.KeyHash)
+	})
+
+	t.Run("Unknown scope", func(t *testing.T) {
+		_, err := apiKeysUC.Create(ctx, &models.APIKey{
+			UserID: userID,
+			Name:   "ci",
+			Scopes: models.Scopes{"users:delete"},
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Expiry in past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+		_, err := apiKeysUC.Create(ctx, &models.APIKey{
+			UserID:    userID,
+			Name:      "ci",
+			ExpiresAt: &expiresAt,
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestAPIKeysUC_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAPIKeysRepo := mock.NewMockRepository(ctrl)
+	apiKeysUC := NewAPIKeysUseCase(cfg, mockAPIKeysRepo, apiLogger)
+
+	ctx := context.Background()
+	prefix := "0a1b2c3d4e5f"
+	plainKey := prefix + ".secret"
+
+	t.Run("Valid key", func(t *testing.T) {
+		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiKeysUC.Authenticate")
+		defer span.Finish()
+
+		key := &models.APIKey{KeyID: uuid.New(), Prefix: prefix, KeyHash: utils.HashToken(plainKey)}
+		mockAPIKeysRepo.EXPECT().GetByPrefix(ctxWithTrace, prefix).Return(key, nil)
+		mockAPIKeysRepo.EXPECT().UpdateLastUsed(ctxWithTrace, key.KeyID).Return(nil)
+
+		apiKey, err := apiKeysUC.Authenticate(ctx, plainKey)
+		require.NoError(t, err)
+		require.Equal(t, key.KeyID, apiKey.KeyID)
+	})
+
+	t.Run("Wrong secret", func(t *testing.T) {
+		key := &models.APIKey{KeyID: uuid.New(), Prefix: prefix, KeyHash: utils.HashToken(plainKey)}
+		mockAPIKeysRepo.EXPECT().GetByPrefix(gomock.Any(), prefix).Return(key, nil)
+
+		_, err := apiKeysUC.Authenticate(ctx, prefix+".wrong")
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Expired key", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+		key := &models.APIKey{KeyID: uuid.New(), Prefix: prefix, KeyHash: utils.HashToken(plainKey), ExpiresAt: &expiresAt}
+		mockAPIKeysRepo.EXPECT().GetByPrefix(gomock.Any(), prefix).Return(key, nil)
+
+		_, err := apiKeysUC.Authenticate(ctx, plainKey)
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Unknown prefix", func(t *testing.T) {
+		mockAPIKeysRepo.EXPECT().GetByPrefix(gomock.Any(), "unknown").Return(nil, sql.ErrNoRows)
+
+		_, err := apiKeysUC.Authenticate(ctx, "unknown.secret")
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+	})
+}
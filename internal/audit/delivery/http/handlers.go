@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/audit"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Audit handlers
+type auditHandlers struct {
+	cfg     *config.Config
+	auditUC audit.UseCase
+	logger  logger.Logger
+}
+
+// NewAuditHandlers Audit handlers constructor
+func NewAuditHandlers(cfg *config.Config, auditUC audit.UseCase, log logger.Logger) audit.Handlers {
+	return &auditHandlers{cfg: cfg, auditUC: auditUC, logger: log}
+}
+
+// GetEntries godoc
+// @Summary Get audit log
+// @Description get audit log entries filtered by acting admin and affected user, requires audit:read permission
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Param actor_id query string false "actor_id"
+// @Param user_id query string false "user_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.AuditList
+// @Failure 400 {object} httpErrors.RestError
+// @Router /audit [get]
+func (h *auditHandlers) GetEntries() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "auditHandlers.GetEntries")
+		defer span.Finish()
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		filter := &models.AuditFilter{}
+		if filter.ActorID, err = parseOptionalUUID(c.QueryParam("actor_id")); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		if filter.UserID, err = parseOptionalUUID(c.QueryParam("user_id")); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		entries, err := h.auditUC.GetEntries(ctx, filter, paginationQuery)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, entries)
+	}
+}
+
+func parseOptionalUUID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/audit"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Audit Repository
+type auditRepo struct {
+	db *sqlx.DB
+}
+
+// Audit Repository constructor
+func NewAuditRepository(db *sqlx.DB) audit.Repository {
+	return &auditRepo{db: db}
+}
+
+// Create audit log entry
+func (r *auditRepo) Create(ctx context.Context, entry *models.AuditEntry) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "auditRepo.Create")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(
+		ctx,
+		createEntryQuery,
+		entry.ActorID,
+		entry.UserID,
+		entry.Action,
+		entry.Method,
+		entry.Path,
+		entry.Status,
+		entry.IP,
+		entry.RequestID,
+	); err != nil {
+		return errors.Wrap(err, "auditRepo.Create.ExecContext")
+	}
+
+	return nil
+}
+
+// Get audit log entries, newest first
+func (r *auditRepo) GetEntries(
+	ctx context.Context,
+	filter *models.AuditFilter,
+	pq *utils.PaginationQuery,
+) (*models.AuditList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "auditRepo.GetEntries")
+	defer span.Finish()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getTotalEntriesQuery, filter.ActorID, filter.UserID); err != nil {
+		return nil, errors.Wrap(err, "auditRepo.GetEntries.GetContext.totalCount")
+	}
+
+	if totalCount == 0 {
+		return &models.AuditList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Entries:    make([]*models.AuditEntry, 0),
+		}, nil
+	}
+
+	var entries = make([]*models.AuditEntry, 0, pq.GetSize())
+	if err := r.db.SelectContext(
+		ctx,
+		&entries,
+		getEntriesQuery,
+		filter.ActorID,
+		filter.UserID,
+		pq.GetOffset(),
+		pq.GetLimit(),
+	); err != nil {
+		return nil, errors.Wrap(err, "auditRepo.GetEntries.SelectContext")
+	}
+
+	return &models.AuditList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Entries:    entries,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+func TestAuditRepo_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	auditRepo := NewAuditRepository(sqlxDB)
+
+	t.Run("Create", func(t *testing.T) {
+		actorID := uuid.New()
+		userID := uuid.New()
+		entry := &models.AuditEntry{
+			ActorID:   &actorID,
+			UserID:    &userID,
+			Action:    models.AuditImpersonationRequest,
+			Method:    "GET",
+			Path:      "/api/v1/auth/me",
+			Status:    200,
+			IP:        "192.0.2.1",
+			RequestID: "request-id",
+		}
+
+		mock.ExpectExec(createEntryQuery).WithArgs(
+			entry.ActorID,
+			entry.UserID,
+			entry.Action,
+			entry.Method,
+			entry.Path,
+			entry.Status,
+			entry.IP,
+			entry.RequestID,
+		).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := auditRepo.Create(context.Background(), entry)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuditRepo_GetEntries(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	auditRepo := NewAuditRepository(sqlxDB)
+
+	t.Run("GetEntries", func(t *testing.T) {
+		actorID := uuid.New()
+		userID := uuid.New()
+		filter := &models.AuditFilter{ActorID: &actorID}
+		pq := &utils.PaginationQuery{Size: 10, Page: 1}
+
+		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+		rows := sqlmock.NewRows([]string{
+			"entry_id", "actor_id", "user_id", "action", "method", "path", "status", "ip", "request_id", "created_at",
+		}).AddRow(uuid.New(), actorID, userID, models.AuditImpersonationStart, "POST", "/api/v1/auth/impersonate", 200,
+			"192.0.2.1", "request-id", time.Now())
+
+		mock.ExpectQuery(getTotalEntriesQuery).WithArgs(filter.ActorID, filter.UserID).WillReturnRows(totalRows)
+		mock.ExpectQuery(getEntriesQuery).WithArgs(filter.ActorID, filter.UserID, 0, 10).WillReturnRows(rows)
+
+		entries, err := auditRepo.GetEntries(context.Background(), filter, pq)
+		require.NoError(t, err)
+		require.Equal(t, 1, entries.TotalCount)
+		require.Len(t, entries.Entries, 1)
+		require.Equal(t, actorID, *entries.Entries[0].ActorID)
+	})
+}
@@ -0,0 +1,15 @@
+package repository
+
+const (
+	createEntryQuery = `INSERT INTO audit_log (actor_id, user_id, action, method, path, status, ip, request_id, created_at) 
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())`
+
+	getTotalEntriesQuery = `SELECT COUNT(entry_id) FROM audit_log 
+						WHERE ($1::uuid IS NULL OR actor_id = $1) AND ($2::uuid IS NULL OR user_id = $2)`
+
+	getEntriesQuery = `SELECT entry_id, actor_id, user_id, action, method, path, status, ip, request_id, created_at 
+						FROM audit_log 
+						WHERE ($1::uuid IS NULL OR actor_id = $1) AND ($2::uuid IS NULL OR user_id = $2) 
+						ORDER BY created_at DESC 
+						OFFSET $3 LIMIT $4`
+)
@@ -0,0 +1,15 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package audit
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Audit UseCase
+type UseCase interface {
+	Record(ctx context.Context, entry *models.AuditEntry) error
+	GetEntries(ctx context.Context, filter *models.AuditFilter, pq *utils.PaginationQuery) (*models.AuditList, error)
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/audit"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Audit UseCase
+type auditUC struct {
+	cfg       *config.Config
+	auditRepo audit.Repository
+	logger    logger.Logger
+}
+
+// Audit UseCase constructor
+func NewAuditUseCase(cfg *config.Config, auditRepo audit.Repository, log logger.Logger) audit.UseCase {
+	return &auditUC{cfg: cfg, auditRepo: auditRepo, logger: log}
+}
+
+// Record audit log entry
+func (u *auditUC) Record(ctx context.Context, entry *models.AuditEntry) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "auditUC.Record")
+	defer span.Finish()
+
+	return u.auditRepo.Create(ctx, entry)
+}
+
+// Get audit log entries
+func (u *auditUC) GetEntries(
+	ctx context.Context,
+	filter *models.AuditFilter,
+	pq *utils.PaginationQuery,
+) (*models.AuditList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "auditUC.GetEntries")
+	defer span.Finish()
+
+	return u.auditRepo.GetEntries(ctx, filter, pq)
+}
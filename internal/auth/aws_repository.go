@@ -3,15 +3,14 @@ package auth
 
 import (
 	"context"
-
-	"github.com/minio/minio-go/v7"
+	"io"
 
 	"github.com/AleksK1NG/api-mc/internal/models"
 )
 
-// Minio AWS S3 interface
+// Avatar object storage interface, backed by configured blob store
 type AWSRepository interface {
-	PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error)
-	GetObject(ctx context.Context, bucket string, fileName string) (*minio.Object, error)
+	PutObject(ctx context.Context, input models.UploadInput) (string, error)
+	GetObject(ctx context.Context, bucket string, fileName string) (io.ReadCloser, error)
 	RemoveObject(ctx context.Context, bucket string, fileName string) error
 }
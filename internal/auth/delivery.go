@@ -15,4 +15,22 @@ type Handlers interface {
 	GetMe() echo.HandlerFunc
 	UploadAvatar() echo.HandlerFunc
 	GetCSRFToken() echo.HandlerFunc
+	RefreshToken() echo.HandlerFunc
+	RevokeRefreshToken() echo.HandlerFunc
+	ForgotPassword() echo.HandlerFunc
+	ResetPassword() echo.HandlerFunc
+	VerifyEmail() echo.HandlerFunc
+	ResendVerificationEmail() echo.HandlerFunc
+	LoginTwoFactor() echo.HandlerFunc
+	EnrollTwoFactor() echo.HandlerFunc
+	ConfirmTwoFactor() echo.HandlerFunc
+	DisableTwoFactor() echo.HandlerFunc
+	UnlockLogin() echo.HandlerFunc
+	GetSessions() echo.HandlerFunc
+	RevokeSession() echo.HandlerFunc
+	RevokeOtherSessions() echo.HandlerFunc
+	ChangePassword() echo.HandlerFunc
+	Impersonate() echo.HandlerFunc
+	GetPrivacy() echo.HandlerFunc
+	UpdatePrivacy() echo.HandlerFunc
 }
@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 
@@ -11,8 +12,10 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/audit"
 	"github.com/AleksK1NG/api-mc/internal/auth"
 	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/rbac"
 	"github.com/AleksK1NG/api-mc/internal/session"
 	"github.com/AleksK1NG/api-mc/pkg/csrf"
 	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
@@ -20,46 +23,64 @@ import (
 	"github.com/AleksK1NG/api-mc/pkg/utils"
 )
 
+const defaultImpersonationExpire = 60 * 30
+
 // Auth handlers
 type authHandlers struct {
-	cfg    *config.Config
-	authUC auth.UseCase
-	sessUC session.UCSession
-	logger logger.Logger
+	cfg     *config.Config
+	authUC  auth.UseCase
+	sessUC  session.UCSession
+	auditUC audit.UseCase
+	rbacUC  rbac.UseCase
+	logger  logger.Logger
 }
 
 // NewAuthHandlers Auth handlers constructor
-func NewAuthHandlers(cfg *config.Config, authUC auth.UseCase, sessUC session.UCSession, log logger.Logger) auth.Handlers {
-	return &authHandlers{cfg: cfg, authUC: authUC, sessUC: sessUC, logger: log}
+func NewAuthHandlers(
+	cfg *config.Config,
+	authUC auth.UseCase,
+	sessUC session.UCSession,
+	auditUC audit.UseCase,
+	rbacUC rbac.UseCase,
+	log logger.Logger,
+) auth.Handlers {
+	return &authHandlers{cfg: cfg, authUC: authUC, sessUC: sessUC, auditUC: auditUC, rbacUC: rbacUC, logger: log}
 }
 
 // Register godoc
 // @Summary Register new user
-// @Description register new user, returns user and token
+// @Description register new user, returns user and token, invite_code is required in invite only mode
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Success 201 {object} models.User
 // @Router /auth/register [post]
 func (h *authHandlers) Register() echo.HandlerFunc {
+	type Register struct {
+		models.User
+		InviteCode string `json:"invite_code" validate:"omitempty,lte=64"`
+	}
+
 	return func(c echo.Context) error {
 		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "auth.Register")
 		defer span.Finish()
 
-		user := &models.User{}
-		if err := utils.ReadRequest(c, user); err != nil {
+		request := &Register{}
+		if err := utils.ReadRequest(c, request); err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		createdUser, err := h.authUC.Register(ctx, user)
+		createdUser, err := h.authUC.Register(ctx, &request.User, request.InviteCode)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
 		sess, err := h.sessUC.CreateSession(ctx, &models.Session{
-			UserID: createdUser.User.UserID,
+			UserID:    createdUser.User.UserID,
+			IP:        c.RealIP(),
+			UserAgent: c.Request().UserAgent(),
 		}, h.cfg.Session.Expire)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
@@ -74,7 +95,7 @@ func (h *authHandlers) Register() echo.HandlerFunc {
 
 // Login godoc
 // @Summary Login new user
-// @Description login user, returns user and set session
+// @Description login user, returns user and set session, users with two factor authentication get challenge for /auth/login/2fa instead
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -101,11 +122,18 @@ func (h *authHandlers) Login() echo.HandlerFunc {
 		})
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
+			utils.SetRetryAfterHeader(c, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		if userWithToken.Challenge != "" {
+			return c.JSON(http.StatusOK, userWithToken)
+		}
+
 		sess, err := h.sessUC.CreateSession(ctx, &models.Session{
-			UserID: userWithToken.User.UserID,
+			UserID:    userWithToken.User.UserID,
+			IP:        c.RealIP(),
+			UserAgent: c.Request().UserAgent(),
 		}, h.cfg.Session.Expire)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
@@ -159,7 +187,7 @@ func (h *authHandlers) Logout() echo.HandlerFunc {
 // @Accept json
 // @Param id path int true "user_id"
 // @Produce json
-// @Success 200 {object} models.User
+// @Success 200 {object} models.SelfUser
 // @Router /auth/{id} [put]
 func (h *authHandlers) Update() echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -186,18 +214,25 @@ func (h *authHandlers) Update() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		return c.JSON(http.StatusOK, updatedUser)
+		viewer, _ := c.Get("user").(*models.User)
+		projected, err := h.authUC.ProjectUser(ctx, viewer, updatedUser)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, projected)
 	}
 }
 
 // GetUserByID godoc
 // @Summary get user by id
-// @Description get string by ID
+// @Description get user by ID, returns public profile following privacy settings, own profile for the user and full profile for users with users:read:private permission
 // @Tags Auth
 // @Accept  json
 // @Produce  json
 // @Param id path int true "user_id"
-// @Success 200 {object} models.User
+// @Success 200 {object} models.PublicUser
 // @Failure 500 {object} httpErrors.RestError
 // @Router /auth/{id} [get]
 func (h *authHandlers) GetUserByID() echo.HandlerFunc {
@@ -217,7 +252,14 @@ func (h *authHandlers) GetUserByID() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		return c.JSON(http.StatusOK, user)
+		viewer, _ := c.Get("user").(*models.User)
+		projected, err := h.authUC.ProjectUser(ctx, viewer, user)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, projected)
 	}
 }
 
@@ -251,6 +293,284 @@ func (h *authHandlers) Delete() echo.HandlerFunc {
 	}
 }
 
+// UnlockLogin godoc
+// @Summary Unlock user login
+// @Description reset failed login attempts and remove lockout of user account, admin only
+// @Tags Auth
+// @Accept json
+// @Param user_id path string true "user_id"
+// @Produce json
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /auth/{user_id}/unlock [post]
+func (h *authHandlers) UnlockLogin() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.UnlockLogin")
+		defer span.Finish()
+
+		uID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.authUC.UnlockLogin(ctx, uID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// Impersonate godoc
+// @Summary Impersonate user
+// @Description sign in as user with short marked session, sensitive actions are denied and all requests are audited, requires users:impersonate permission and every permission of target user
+// @Tags Auth
+// @Accept json
+// @Param user_id path string true "user_id"
+// @Produce json
+// @Success 200 {object} models.User
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Router /auth/{user_id}/impersonate [post]
+func (h *authHandlers) Impersonate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.Impersonate")
+		defer span.Finish()
+
+		admin, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		uID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		if uID == admin.UserID {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.ImpersonateSelf.Error(), nil))
+		}
+
+		user, err := h.authUC.GetByID(ctx, uID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		// Session takes target permissions, so target must not hold any permission actor does not have
+		if err = h.checkImpersonationTarget(ctx, user); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		expire := h.getImpersonationExpire()
+		sess, err := h.sessUC.CreateSession(ctx, &models.Session{
+			UserID:         user.UserID,
+			ImpersonatorID: &admin.UserID,
+			IP:             c.RealIP(),
+			UserAgent:      c.Request().UserAgent(),
+		}, expire)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		// Impersonation is not allowed without audit trail
+		if err = h.auditUC.Record(ctx, &models.AuditEntry{
+			ActorID:   &admin.UserID,
+			UserID:    &user.UserID,
+			Action:    models.AuditImpersonationStart,
+			Method:    c.Request().Method,
+			Path:      c.Request().URL.Path,
+			Status:    http.StatusOK,
+			IP:        c.RealIP(),
+			RequestID: utils.GetRequestID(c),
+		}); err != nil {
+			if delErr := h.sessUC.DeleteByID(ctx, sess); delErr != nil {
+				h.logger.Errorf("authHandlers.Impersonate.DeleteByID: %s", delErr)
+			}
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		cookie := utils.CreateSessionCookie(h.cfg, sess)
+		cookie.MaxAge = expire
+		c.SetCookie(cookie)
+
+		return c.JSON(http.StatusOK, user)
+	}
+}
+
+// GetSessions godoc
+// @Summary Get active sessions
+// @Description get active sessions of current user with last seen time, ip and user agent, required auth session cookie
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Session
+// @Failure 401 {object} httpErrors.RestError
+// @Router /auth/me/sessions [get]
+func (h *authHandlers) GetSessions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.GetSessions")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		currentSessionID, _ := c.Get("uid").(string)
+		sessions, err := h.sessUC.GetUserSessions(ctx, user.UserID, currentSessionID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, sessions)
+	}
+}
+
+// RevokeSession godoc
+// @Summary Revoke session
+// @Description sign out one of current user sessions, required auth session cookie
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param session_id path string true "session_id"
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /auth/me/sessions/{session_id} [delete]
+func (h *authHandlers) RevokeSession() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.RevokeSession")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		sessionID, err := uuid.Parse(c.Param("session_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.sessUC.RevokeSession(ctx, user.UserID, sessionID.String()); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if currentSessionID, _ := c.Get("uid").(string); currentSessionID == sessionID.String() {
+			utils.DeleteSessionCookie(c, h.cfg.Session.Name)
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// RevokeOtherSessions godoc
+// @Summary Revoke other sessions
+// @Description sign out all sessions of current user except current one, required auth session cookie
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {string} string	"ok"
+// @Failure 401 {object} httpErrors.RestError
+// @Router /auth/me/sessions [delete]
+func (h *authHandlers) RevokeOtherSessions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.RevokeOtherSessions")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		currentSessionID, ok := c.Get("uid").(string)
+		if !ok || currentSessionID == "" {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		if err := h.sessUC.RevokeUserSessions(ctx, user.UserID, currentSessionID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		// Refresh tokens are not bound to sessions, so none of them is kept
+		if err := h.authUC.RevokeUserRefreshTokens(ctx, user.UserID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description change password of current user, all sessions are revoked and caller gets new session cookie and CSRF token, required auth session cookie
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {string} string	"ok"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Router /auth/me/password [put]
+func (h *authHandlers) ChangePassword() echo.HandlerFunc {
+	type ChangePassword struct {
+		CurrentPassword string `json:"current_password" validate:"required"`
+		NewPassword     string `json:"new_password" validate:"required"`
+	}
+
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.ChangePassword")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		request := &ChangePassword{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.ChangePassword(ctx, user.UserID, request.CurrentPassword, request.NewPassword); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		sess, err := h.sessUC.CreateSession(ctx, &models.Session{
+			UserID:    user.UserID,
+			IP:        c.RealIP(),
+			UserAgent: c.Request().UserAgent(),
+		}, h.cfg.Session.Expire)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		c.SetCookie(utils.CreateSessionCookie(h.cfg, sess))
+		c.Response().Header().Set(csrf.CSRFHeader, csrf.MakeToken(sess, h.logger))
+		c.Response().Header().Set("Access-Control-Expose-Headers", csrf.CSRFHeader)
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
 // FindByName godoc
 // @Summary Find by name
 // @Description Find user by name
@@ -258,7 +578,7 @@ func (h *authHandlers) Delete() echo.HandlerFunc {
 // @Accept json
 // @Param name query string false "username" Format(username)
 // @Produce json
-// @Success 200 {object} models.UsersList
+// @Success 200 {object} models.UserProfilesList
 // @Failure 500 {object} httpErrors.RestError
 // @Router /auth/find [get]
 func (h *authHandlers) FindByName() echo.HandlerFunc {
@@ -283,7 +603,13 @@ func (h *authHandlers) FindByName() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		return c.JSON(http.StatusOK, response)
+		profiles, err := h.projectUsersList(ctx, c, response)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, profiles)
 	}
 }
 
@@ -296,7 +622,7 @@ func (h *authHandlers) FindByName() echo.HandlerFunc {
 // @Param size query int false "number of elements per page" Format(size)
 // @Param orderBy query int false "filter name" Format(orderBy)
 // @Produce json
-// @Success 200 {object} models.UsersList
+// @Success 200 {object} models.UserProfilesList
 // @Failure 500 {object} httpErrors.RestError
 // @Router /auth/all [get]
 func (h *authHandlers) GetUsers() echo.HandlerFunc {
@@ -316,7 +642,13 @@ func (h *authHandlers) GetUsers() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		return c.JSON(http.StatusOK, usersList)
+		profiles, err := h.projectUsersList(ctx, c, usersList)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, profiles)
 	}
 }
 
@@ -326,12 +658,12 @@ func (h *authHandlers) GetUsers() echo.HandlerFunc {
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Success 200 {object} models.User
+// @Success 200 {object} models.SelfUser
 // @Failure 500 {object} httpErrors.RestError
 // @Router /auth/me [get]
 func (h *authHandlers) GetMe() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		span, _ := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.GetMe")
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.GetMe")
 		defer span.Finish()
 
 		user, ok := c.Get("user").(*models.User)
@@ -340,7 +672,13 @@ func (h *authHandlers) GetMe() echo.HandlerFunc {
 			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
 		}
 
-		return c.JSON(http.StatusOK, user)
+		privacy, err := h.authUC.GetPrivacy(ctx, user.UserID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, user.Self(privacy))
 	}
 }
 
@@ -373,14 +711,15 @@ func (h *authHandlers) GetCSRFToken() echo.HandlerFunc {
 
 // UploadAvatar godoc
 // @Summary Post avatar
-// @Description Post user avatar image
+// @Description Post user avatar image, jpeg or png up to 10 MB, stored without metadata as square jpeg of 64, 256 and 512 px
 // @Tags Auth
 // @Accept  json
 // @Produce  json
 // @Param file formData file true "Body with image file"
 // @Param bucket query string true "aws s3 bucket" Format(bucket)
 // @Param id path int true "user_id"
-// @Success 200 {string} string	"ok"
+// @Success 200 {object} models.User
+// @Failure 400 {object} httpErrors.RestError
 // @Failure 500 {object} httpErrors.RestError
 // @Router /auth/{id}/avatar [post]
 func (h *authHandlers) UploadAvatar() echo.HandlerFunc {
@@ -437,3 +776,450 @@ func (h *authHandlers) UploadAvatar() echo.HandlerFunc {
 		return c.JSON(http.StatusOK, updatedUser)
 	}
 }
+
+// RefreshToken godoc
+// @Summary Refresh tokens
+// @Description exchange refresh token for a new access and refresh token pair, refresh token is rotated on every use
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.UserWithToken
+// @Failure 401 {object} httpErrors.RestError
+// @Router /auth/refresh [post]
+func (h *authHandlers) RefreshToken() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.RefreshToken")
+		defer span.Finish()
+
+		request := &models.RefreshTokenRequest{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		userWithToken, err := h.authUC.RefreshToken(ctx, request.RefreshToken)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, userWithToken)
+	}
+}
+
+// RevokeRefreshToken godoc
+// @Summary Revoke refresh token
+// @Description revoke refresh token and all tokens rotated from the same login
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {string} string	"ok"
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/revoke [post]
+func (h *authHandlers) RevokeRefreshToken() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.RevokeRefreshToken")
+		defer span.Finish()
+
+		request := &models.RefreshTokenRequest{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.RevokeRefreshToken(ctx, request.RefreshToken); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// ForgotPassword godoc
+// @Summary Forgot password
+// @Description send password reset link to user email, always responds ok to not disclose registered emails
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {string} string	"ok"
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/password/forgot [post]
+func (h *authHandlers) ForgotPassword() echo.HandlerFunc {
+	type ForgotPassword struct {
+		Email string `json:"email" validate:"required,lte=60,email"`
+	}
+
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.ForgotPassword")
+		defer span.Finish()
+
+		request := &ForgotPassword{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.ForgotPassword(ctx, request.Email); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description set new password using single use token from password reset email
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {string} string	"ok"
+// @Failure 400 {object} httpErrors.RestError
+// @Router /auth/password/reset [post]
+func (h *authHandlers) ResetPassword() echo.HandlerFunc {
+	type ResetPassword struct {
+		Token    string `json:"token" validate:"required"`
+		Password string `json:"password" validate:"required,gte=6"`
+	}
+
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.ResetPassword")
+		defer span.Finish()
+
+		request := &ResetPassword{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.ResetPassword(ctx, request.Token, request.Password); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// VerifyEmail godoc
+// @Summary Verify email
+// @Description confirm user email address using signed token from verification email
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token query string true "verification token"
+// @Success 200 {object} models.User
+// @Failure 400 {object} httpErrors.RestError
+// @Router /auth/verify [get]
+func (h *authHandlers) VerifyEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.VerifyEmail")
+		defer span.Finish()
+
+		user, err := h.authUC.VerifyEmail(ctx, c.QueryParam("token"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, user)
+	}
+}
+
+// ResendVerificationEmail godoc
+// @Summary Resend verification email
+// @Description send new email verification link to current user, required auth session cookie
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {string} string	"ok"
+// @Failure 400 {object} httpErrors.RestError
+// @Router /auth/verify/resend [post]
+func (h *authHandlers) ResendVerificationEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.ResendVerificationEmail")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		if err := h.authUC.ResendVerificationEmail(ctx, user.UserID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// LoginTwoFactor godoc
+// @Summary Login second step
+// @Description finish login with challenge and totp or recovery code, returns user and set session
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body models.TwoFactorLoginRequest true "challenge and code"
+// @Success 200 {object} models.UserWithToken
+// @Failure 401 {object} httpErrors.RestError
+// @Router /auth/login/2fa [post]
+func (h *authHandlers) LoginTwoFactor() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.LoginTwoFactor")
+		defer span.Finish()
+
+		request := &models.TwoFactorLoginRequest{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		userWithToken, err := h.authUC.LoginTwoFactor(ctx, request.Challenge, request.Code)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		sess, err := h.sessUC.CreateSession(ctx, &models.Session{
+			UserID:    userWithToken.User.UserID,
+			IP:        c.RealIP(),
+			UserAgent: c.Request().UserAgent(),
+		}, h.cfg.Session.Expire)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		c.SetCookie(utils.CreateSessionCookie(h.cfg, sess))
+
+		return c.JSON(http.StatusOK, userWithToken)
+	}
+}
+
+// EnrollTwoFactor godoc
+// @Summary Start two factor enrollment
+// @Description generate totp secret and otpauth uri for QR code, required auth session cookie
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.TwoFactorEnrollment
+// @Failure 400 {object} httpErrors.RestError
+// @Router /auth/me/2fa/enroll [post]
+func (h *authHandlers) EnrollTwoFactor() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.EnrollTwoFactor")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		enrollment, err := h.authUC.EnrollTwoFactor(ctx, user.UserID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, enrollment)
+	}
+}
+
+// ConfirmTwoFactor godoc
+// @Summary Confirm two factor enrollment
+// @Description enable two factor authentication with first totp code, returns recovery codes, required auth session cookie
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.RecoveryCodes
+// @Failure 400 {object} httpErrors.RestError
+// @Router /auth/me/2fa/confirm [post]
+func (h *authHandlers) ConfirmTwoFactor() echo.HandlerFunc {
+	type ConfirmTwoFactor struct {
+		Code string `json:"code" validate:"required,len=6,numeric"`
+	}
+
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.ConfirmTwoFactor")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		request := &ConfirmTwoFactor{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		recoveryCodes, err := h.authUC.ConfirmTwoFactor(ctx, user.UserID, request.Code)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, recoveryCodes)
+	}
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two factor authentication
+// @Description disable two factor authentication with current password and totp or recovery code, required auth session cookie
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {string} string	"ok"
+// @Failure 400 {object} httpErrors.RestError
+// @Router /auth/me/2fa/disable [post]
+func (h *authHandlers) DisableTwoFactor() echo.HandlerFunc {
+	type DisableTwoFactor struct {
+		Password string `json:"password" validate:"required"`
+		Code     string `json:"code" validate:"required,lte=32"`
+	}
+
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.DisableTwoFactor")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		request := &DisableTwoFactor{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.DisableTwoFactor(ctx, user.UserID, request.Password, request.Code); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// GetPrivacy godoc
+// @Summary Get privacy settings
+// @Description get which optional profile fields are shown on public profile, required auth session cookie
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.PrivacySettings
+// @Failure 401 {object} httpErrors.RestError
+// @Router /auth/me/privacy [get]
+func (h *authHandlers) GetPrivacy() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.GetPrivacy")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		privacy, err := h.authUC.GetPrivacy(ctx, user.UserID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, privacy)
+	}
+}
+
+// UpdatePrivacy godoc
+// @Summary Update privacy settings
+// @Description set which optional profile fields are shown on public profile, omitted fields are hidden, required auth session cookie
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.PrivacySettings
+// @Failure 400 {object} httpErrors.RestError
+// @Router /auth/me/privacy [put]
+func (h *authHandlers) UpdatePrivacy() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.UpdatePrivacy")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		request := &models.PrivacySettings{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		privacy, err := h.authUC.UpdatePrivacy(ctx, user.UserID, request)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, privacy)
+	}
+}
+
+// Project users list for caller, caller is taken from optional session
+func (h *authHandlers) projectUsersList(
+	ctx context.Context,
+	c echo.Context,
+	usersList *models.UsersList,
+) (*models.UserProfilesList, error) {
+	viewer, _ := c.Get("user").(*models.User)
+
+	users, err := h.authUC.ProjectUsers(ctx, viewer, usersList.Users)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserProfilesList{
+		TotalCount: usersList.TotalCount,
+		TotalPages: usersList.TotalPages,
+		Page:       usersList.Page,
+		Size:       usersList.Size,
+		HasMore:    usersList.HasMore,
+		Users:      users,
+	}, nil
+}
+
+func (h *authHandlers) checkImpersonationTarget(ctx context.Context, user *models.User) error {
+	if user.Role == nil {
+		return nil
+	}
+
+	permissions, err := h.rbacUC.GetRolePermissions(ctx, *user.Role)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		if !utils.HasPermission(ctx, permission) {
+			return httpErrors.NewRestError(http.StatusForbidden, httpErrors.ImpersonatePrivileged.Error(), nil)
+		}
+	}
+	return nil
+}
+
+func (h *authHandlers) getImpersonationExpire() int {
+	if h.cfg.Session.ImpersonationExpire > 0 {
+		return h.cfg.Session.ImpersonationExpire
+	}
+	return defaultImpersonationExpire
+}
@@ -1,8 +1,16 @@
 package http
 
 import (
+	"bytes"
+	"context"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"strings"
 	"testing"
 
@@ -13,8 +21,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/AleksK1NG/api-mc/config"
+	mockAudit "github.com/AleksK1NG/api-mc/internal/audit/mock"
 	"github.com/AleksK1NG/api-mc/internal/auth/mock"
 	"github.com/AleksK1NG/api-mc/internal/models"
+	mockRBAC "github.com/AleksK1NG/api-mc/internal/rbac/mock"
 	mockSess "github.com/AleksK1NG/api-mc/internal/session/mock"
 	"github.com/AleksK1NG/api-mc/pkg/converter"
 	"github.com/AleksK1NG/api-mc/pkg/logger"
@@ -40,7 +50,7 @@ func TestAuthHandlers_Register(t *testing.T) {
 	}
 
 	apiLogger := logger.NewApiLogger(cfg)
-	authHandlers := NewAuthHandlers(cfg, mockAuthUC, mockSessUC, apiLogger)
+	authHandlers := NewAuthHandlers(cfg, mockAuthUC, mockSessUC, nil, nil, apiLogger)
 
 	gender := "male"
 	user := &models.User{
@@ -76,10 +86,11 @@ func TestAuthHandlers_Register(t *testing.T) {
 	}
 	sess := &models.Session{
 		UserID: userUID,
+		IP:     "192.0.2.1",
 	}
 	session := "session"
 
-	mockAuthUC.EXPECT().Register(ctxWithTrace, gomock.Eq(user)).Return(userWithToken, nil)
+	mockAuthUC.EXPECT().Register(ctxWithTrace, gomock.Eq(user), "").Return(userWithToken, nil)
 	mockSessUC.EXPECT().CreateSession(ctxWithTrace, gomock.Eq(sess), 10).Return(session, nil)
 
 	err = handlerFunc(c)
@@ -106,7 +117,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 	}
 
 	apiLogger := logger.NewApiLogger(cfg)
-	authHandlers := NewAuthHandlers(cfg, mockAuthUC, mockSessUC, apiLogger)
+	authHandlers := NewAuthHandlers(cfg, mockAuthUC, mockSessUC, nil, nil, apiLogger)
 
 	type Login struct {
 		Email    string `json:"email" db:"email" validate:"omitempty,lte=60,email"`
@@ -148,6 +159,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 	}
 	sess := &models.Session{
 		UserID: userUID,
+		IP:     "192.0.2.1",
 	}
 	session := "session"
 
@@ -178,7 +190,7 @@ func TestAuthHandlers_Logout(t *testing.T) {
 	}
 
 	apiLogger := logger.NewApiLogger(cfg)
-	authHandlers := NewAuthHandlers(cfg, mockAuthUC, mockSessUC, apiLogger)
+	authHandlers := NewAuthHandlers(cfg, mockAuthUC, mockSessUC, nil, nil, apiLogger)
 	sessionKey := "session-id"
 	cookieValue := "cookieValue"
 
@@ -208,3 +220,186 @@ func TestAuthHandlers_Logout(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, err)
 }
+
+func TestAuthHandlers_Impersonate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthUC := mock.NewMockUseCase(ctrl)
+	mockSessUC := mockSess.NewMockUCSession(ctrl)
+	mockAuditUC := mockAudit.NewMockUseCase(ctrl)
+	mockRBACUC := mockRBAC.NewMockUseCase(ctrl)
+
+	cfg := &config.Config{
+		Session: config.Session{
+			Name:                "session-id",
+			Expire:              3600,
+			ImpersonationExpire: 600,
+		},
+		Logger: config.Logger{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	authHandlers := NewAuthHandlers(cfg, mockAuthUC, mockSessUC, mockAuditUC, mockRBACUC, apiLogger)
+
+	admin := &models.User{UserID: uuid.New()}
+	user := &models.User{UserID: uuid.New()}
+
+	t.Run("Impersonate", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/"+user.UserID.String()+"/impersonate", nil)
+		rec := httptest.NewRecorder()
+
+		c := e.NewContext(req, rec)
+		c.SetParamNames("user_id")
+		c.SetParamValues(user.UserID.String())
+		c.Set("user", admin)
+		ctx := utils.GetRequestCtx(c)
+		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authHandlers.Impersonate")
+		defer span.Finish()
+
+		sess := &models.Session{
+			UserID:         user.UserID,
+			ImpersonatorID: &admin.UserID,
+			IP:             "192.0.2.1",
+		}
+
+		mockAuthUC.EXPECT().GetByID(ctxWithTrace, user.UserID).Return(user, nil)
+		mockSessUC.EXPECT().CreateSession(ctxWithTrace, gomock.Eq(sess), 600).Return("session", nil)
+		mockAuditUC.EXPECT().Record(ctxWithTrace, gomock.Any()).DoAndReturn(func(_ interface{}, entry *models.AuditEntry) error {
+			require.Equal(t, models.AuditImpersonationStart, entry.Action)
+			require.Equal(t, admin.UserID, *entry.ActorID)
+			require.Equal(t, user.UserID, *entry.UserID)
+			return nil
+		})
+
+		err := authHandlers.Impersonate()(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Header().Get(echo.HeaderSetCookie), "Max-Age=600")
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/"+admin.UserID.String()+"/impersonate", nil)
+		rec := httptest.NewRecorder()
+
+		c := e.NewContext(req, rec)
+		c.SetParamNames("user_id")
+		c.SetParamValues(admin.UserID.String())
+		c.Set("user", admin)
+
+		err := authHandlers.Impersonate()(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Privileged target", func(t *testing.T) {
+		adminRole := models.RoleAdmin
+		target := &models.User{UserID: uuid.New(), Role: &adminRole}
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/"+target.UserID.String()+"/impersonate", nil)
+		req = req.WithContext(context.WithValue(req.Context(), utils.PermissionsCtxKey{}, []string{models.PermissionUsersImpersonate}))
+		rec := httptest.NewRecorder()
+
+		c := e.NewContext(req, rec)
+		c.SetParamNames("user_id")
+		c.SetParamValues(target.UserID.String())
+		c.Set("user", admin)
+
+		mockAuthUC.EXPECT().GetByID(gomock.Any(), target.UserID).Return(target, nil)
+		mockRBACUC.EXPECT().GetRolePermissions(gomock.Any(), models.RoleAdmin).
+			Return([]string{models.PermissionUsersImpersonate, models.PermissionRolesManage}, nil)
+
+		err := authHandlers.Impersonate()(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestAuthHandlers_UploadAvatar(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthUC := mock.NewMockUseCase(ctrl)
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	authHandlers := NewAuthHandlers(cfg, mockAuthUC, nil, nil, nil, apiLogger)
+
+	userUID := uuid.New()
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+
+	pngBuf := bytes.NewBuffer(nil)
+	require.NoError(t, png.Encode(pngBuf, img))
+	jpegBuf := bytes.NewBuffer(nil)
+	require.NoError(t, jpeg.Encode(jpegBuf, img, nil))
+	gifBuf := bytes.NewBuffer(nil)
+	require.NoError(t, gif.Encode(gifBuf, img, nil))
+
+	tests := []struct {
+		name        string
+		contentType string
+		content     []byte
+		status      int
+	}{
+		{"png", "image/png", pngBuf.Bytes(), http.StatusOK},
+		{"jpeg", "image/jpeg", jpegBuf.Bytes(), http.StatusOK},
+		{"jpg", "image/jpg", jpegBuf.Bytes(), http.StatusOK},
+		{"gif", "image/gif", gifBuf.Bytes(), http.StatusBadRequest},
+		{"bmp", "image/bmp", append([]byte("BM"), make([]byte, 64)...), http.StatusBadRequest},
+		{"webp", "image/webp", append([]byte("RIFF\x00\x00\x00\x00WEBPVP"), make([]byte, 64)...), http.StatusBadRequest},
+		{"tiff", "image/tiff", append([]byte("II*\x00"), make([]byte, 64)...), http.StatusBadRequest},
+		{"ico", "image/vnd.microsoft.icon", append([]byte("\x00\x00\x01\x00"), make([]byte, 64)...), http.StatusBadRequest},
+		{"svg", "image/svg+xml", []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), http.StatusBadRequest},
+		{"png declared as gif", "image/gif", pngBuf.Bytes(), http.StatusBadRequest},
+		{"gif declared as png", "image/png", gifBuf.Bytes(), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			body := bytes.NewBuffer(nil)
+			writer := multipart.NewWriter(body)
+			header := make(textproto.MIMEHeader)
+			header.Set("Content-Disposition", `form-data; name="file"; filename="avatar"`)
+			header.Set(echo.HeaderContentType, tt.contentType)
+			part, err := writer.CreatePart(header)
+			require.NoError(t, err)
+			_, err = part.Write(tt.content)
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/"+userUID.String()+"/avatar?bucket=avatars", body)
+			req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+			rec := httptest.NewRecorder()
+
+			c := e.NewContext(req, rec)
+			c.SetParamNames("user_id")
+			c.SetParamValues(userUID.String())
+
+			if tt.status == http.StatusOK {
+				mockAuthUC.EXPECT().UploadAvatar(gomock.Any(), userUID, gomock.Any()).Return(&models.User{UserID: userUID}, nil)
+			}
+
+			err = authHandlers.UploadAvatar()(c)
+			require.NoError(t, err)
+			require.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
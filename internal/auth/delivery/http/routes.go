@@ -2,24 +2,47 @@ package http
 
 import (
 	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
 
 	"github.com/AleksK1NG/api-mc/internal/auth"
 	"github.com/AleksK1NG/api-mc/internal/middleware"
+	"github.com/AleksK1NG/api-mc/internal/models"
 )
 
+// Avatars are resized by server, uploads may be bigger than server wide body limit
+const avatarBodyLimit = "10M"
+
 // Map auth routes
 func MapAuthRoutes(authGroup *echo.Group, h auth.Handlers, mw *middleware.MiddlewareManager) {
 	authGroup.POST("/register", h.Register())
 	authGroup.POST("/login", h.Login())
+	authGroup.POST("/login/2fa", h.LoginTwoFactor())
 	authGroup.POST("/logout", h.Logout())
-	authGroup.GET("/find", h.FindByName())
-	authGroup.GET("/all", h.GetUsers())
-	authGroup.GET("/:user_id", h.GetUserByID())
+	authGroup.POST("/refresh", h.RefreshToken())
+	authGroup.POST("/revoke", h.RevokeRefreshToken())
+	authGroup.POST("/password/forgot", h.ForgotPassword())
+	authGroup.POST("/password/reset", h.ResetPassword())
+	authGroup.GET("/verify", h.VerifyEmail())
+	authGroup.GET("/find", h.FindByName(), mw.OptionalAuthSessionMiddleware)
+	authGroup.GET("/all", h.GetUsers(), mw.OptionalAuthSessionMiddleware)
+	authGroup.GET("/:user_id", h.GetUserByID(), mw.OptionalAuthSessionMiddleware)
 	// authGroup.Use(middleware.AuthJWTMiddleware(authUC, cfg))
 	authGroup.Use(mw.AuthSessionMiddleware)
 	authGroup.GET("/me", h.GetMe())
 	authGroup.GET("/token", h.GetCSRFToken())
-	authGroup.POST("/:user_id/avatar", h.UploadAvatar(), mw.CSRF)
+	authGroup.POST("/verify/resend", h.ResendVerificationEmail(), mw.CSRF)
+	authGroup.POST("/me/2fa/enroll", h.EnrollTwoFactor(), mw.CSRF, mw.DenyImpersonation)
+	authGroup.POST("/me/2fa/confirm", h.ConfirmTwoFactor(), mw.CSRF, mw.DenyImpersonation)
+	authGroup.POST("/me/2fa/disable", h.DisableTwoFactor(), mw.CSRF, mw.DenyImpersonation)
+	authGroup.GET("/me/privacy", h.GetPrivacy())
+	authGroup.PUT("/me/privacy", h.UpdatePrivacy(), mw.CSRF)
+	authGroup.GET("/me/sessions", h.GetSessions())
+	authGroup.DELETE("/me/sessions", h.RevokeOtherSessions(), mw.CSRF, mw.DenyImpersonation)
+	authGroup.DELETE("/me/sessions/:session_id", h.RevokeSession(), mw.CSRF, mw.DenyImpersonation)
+	authGroup.PUT("/me/password", h.ChangePassword(), mw.CSRF, mw.DenyImpersonation)
+	authGroup.POST("/:user_id/avatar", h.UploadAvatar(), echoMiddleware.BodyLimit(avatarBodyLimit), mw.OwnerOrAdminMiddleware(), mw.CSRF)
 	authGroup.PUT("/:user_id", h.Update(), mw.OwnerOrAdminMiddleware(), mw.CSRF)
-	authGroup.DELETE("/:user_id", h.Delete(), mw.CSRF, mw.RoleBasedAuthMiddleware([]string{"admin"}))
+	authGroup.DELETE("/:user_id", h.Delete(), mw.CSRF, mw.DenyImpersonation, mw.RequirePermission(models.PermissionUsersDelete))
+	authGroup.POST("/:user_id/unlock", h.UnlockLogin(), mw.CSRF, mw.RequirePermission(models.PermissionUsersUnlock))
+	authGroup.POST("/:user_id/impersonate", h.Impersonate(), mw.CSRF, mw.DenyImpersonation, mw.RequirePermission(models.PermissionUsersImpersonate))
 }
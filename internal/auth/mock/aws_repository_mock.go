@@ -8,7 +8,7 @@ import (
 	context "context"
 	models "github.com/AleksK1NG/api-mc/internal/models"
 	gomock "github.com/golang/mock/gomock"
-	minio "github.com/minio/minio-go/v7"
+	io "io"
 	reflect "reflect"
 )
 
@@ -36,10 +36,10 @@ func (m *MockAWSRepository) EXPECT() *MockAWSRepositoryMockRecorder {
 }
 
 // PutObject mocks base method
-func (m *MockAWSRepository) PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error) {
+func (m *MockAWSRepository) PutObject(ctx context.Context, input models.UploadInput) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PutObject", ctx, input)
-	ret0, _ := ret[0].(*minio.UploadInfo)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -51,10 +51,10 @@ func (mr *MockAWSRepositoryMockRecorder) PutObject(ctx, input interface{}) *gomo
 }
 
 // GetObject mocks base method
-func (m *MockAWSRepository) GetObject(ctx context.Context, bucket, fileName string) (*minio.Object, error) {
+func (m *MockAWSRepository) GetObject(ctx context.Context, bucket, fileName string) (io.ReadCloser, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetObject", ctx, bucket, fileName)
-	ret0, _ := ret[0].(*minio.Object)
+	ret0, _ := ret[0].(io.ReadCloser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
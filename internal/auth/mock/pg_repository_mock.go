@@ -66,6 +66,36 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, user interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, user)
 }
 
+// UpdateAvatars mocks base method
+func (m *MockRepository) UpdateAvatars(ctx context.Context, userID uuid.UUID, avatars models.Avatars) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvatars", ctx, userID, avatars)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAvatars indicates an expected call of UpdateAvatars
+func (mr *MockRepositoryMockRecorder) UpdateAvatars(ctx, userID, avatars interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvatars", reflect.TypeOf((*MockRepository)(nil).UpdateAvatars), ctx, userID, avatars)
+}
+
+// UpdateRole mocks base method
+func (m *MockRepository) UpdateRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, userID, role)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole
+func (mr *MockRepositoryMockRecorder) UpdateRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockRepository)(nil).UpdateRole), ctx, userID, role)
+}
+
 // Delete mocks base method
 func (m *MockRepository) Delete(ctx context.Context, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -139,3 +169,133 @@ func (mr *MockRepositoryMockRecorder) GetUsers(ctx, pq interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockRepository)(nil).GetUsers), ctx, pq)
 }
+
+// UpdatePassword mocks base method
+func (m *MockRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword
+func (mr *MockRepositoryMockRecorder) UpdatePassword(ctx, userID, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockRepository)(nil).UpdatePassword), ctx, userID, password)
+}
+
+// VerifyEmail mocks base method
+func (m *MockRepository) VerifyEmail(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail
+func (mr *MockRepositoryMockRecorder) VerifyEmail(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockRepository)(nil).VerifyEmail), ctx, userID)
+}
+
+// SetTOTPSecret mocks base method
+func (m *MockRepository) SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTOTPSecret", ctx, userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTOTPSecret indicates an expected call of SetTOTPSecret
+func (mr *MockRepositoryMockRecorder) SetTOTPSecret(ctx, userID, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTOTPSecret", reflect.TypeOf((*MockRepository)(nil).SetTOTPSecret), ctx, userID, secret)
+}
+
+// EnableTOTP mocks base method
+func (m *MockRepository) EnableTOTP(ctx context.Context, userID uuid.UUID, recoveryCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTP", ctx, userID, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTOTP indicates an expected call of EnableTOTP
+func (mr *MockRepositoryMockRecorder) EnableTOTP(ctx, userID, recoveryCodeHashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTP", reflect.TypeOf((*MockRepository)(nil).EnableTOTP), ctx, userID, recoveryCodeHashes)
+}
+
+// DisableTOTP mocks base method
+func (m *MockRepository) DisableTOTP(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP
+func (mr *MockRepositoryMockRecorder) DisableTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockRepository)(nil).DisableTOTP), ctx, userID)
+}
+
+// UseRecoveryCode mocks base method
+func (m *MockRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode
+func (mr *MockRepositoryMockRecorder) UseRecoveryCode(ctx, userID, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockRepository)(nil).UseRecoveryCode), ctx, userID, codeHash)
+}
+
+// GetPrivacy mocks base method
+func (m *MockRepository) GetPrivacy(ctx context.Context, userID uuid.UUID) (*models.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacy", ctx, userID)
+	ret0, _ := ret[0].(*models.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacy indicates an expected call of GetPrivacy
+func (mr *MockRepositoryMockRecorder) GetPrivacy(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacy", reflect.TypeOf((*MockRepository)(nil).GetPrivacy), ctx, userID)
+}
+
+// GetPrivacyByUserIDs mocks base method
+func (m *MockRepository) GetPrivacyByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*models.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacyByUserIDs", ctx, userIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]*models.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacyByUserIDs indicates an expected call of GetPrivacyByUserIDs
+func (mr *MockRepositoryMockRecorder) GetPrivacyByUserIDs(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacyByUserIDs", reflect.TypeOf((*MockRepository)(nil).GetPrivacyByUserIDs), ctx, userIDs)
+}
+
+// UpdatePrivacy mocks base method
+func (m *MockRepository) UpdatePrivacy(ctx context.Context, userID uuid.UUID, privacy *models.PrivacySettings) (*models.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrivacy", ctx, userID, privacy)
+	ret0, _ := ret[0].(*models.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrivacy indicates an expected call of UpdatePrivacy
+func (mr *MockRepositoryMockRecorder) UpdatePrivacy(ctx, userID, privacy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrivacy", reflect.TypeOf((*MockRepository)(nil).UpdatePrivacy), ctx, userID, privacy)
+}
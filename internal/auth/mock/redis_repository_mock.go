@@ -8,7 +8,9 @@ import (
 	context "context"
 	models "github.com/AleksK1NG/api-mc/internal/models"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	reflect "reflect"
+	time "time"
 )
 
 // MockRedisRepository is a mock of RedisRepository interface
@@ -76,3 +78,260 @@ func (mr *MockRedisRepositoryMockRecorder) DeleteUserCtx(ctx, key interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserCtx", reflect.TypeOf((*MockRedisRepository)(nil).DeleteUserCtx), ctx, key)
 }
+
+// SetRefreshTokenCtx mocks base method
+func (m *MockRedisRepository) SetRefreshTokenCtx(ctx context.Context, userKey, familyKey, key string, seconds int, token *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRefreshTokenCtx", ctx, userKey, familyKey, key, seconds, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRefreshTokenCtx indicates an expected call of SetRefreshTokenCtx
+func (mr *MockRedisRepositoryMockRecorder) SetRefreshTokenCtx(ctx, userKey, familyKey, key, seconds, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRefreshTokenCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetRefreshTokenCtx), ctx, userKey, familyKey, key, seconds, token)
+}
+
+// GetRefreshTokenCtx mocks base method
+func (m *MockRedisRepository) GetRefreshTokenCtx(ctx context.Context, key string) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshTokenCtx", ctx, key)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshTokenCtx indicates an expected call of GetRefreshTokenCtx
+func (mr *MockRedisRepositoryMockRecorder) GetRefreshTokenCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetRefreshTokenCtx), ctx, key)
+}
+
+// MarkRefreshTokenUsedCtx mocks base method
+func (m *MockRedisRepository) MarkRefreshTokenUsedCtx(ctx context.Context, key string, seconds int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRefreshTokenUsedCtx", ctx, key, seconds)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRefreshTokenUsedCtx indicates an expected call of MarkRefreshTokenUsedCtx
+func (mr *MockRedisRepositoryMockRecorder) MarkRefreshTokenUsedCtx(ctx, key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRefreshTokenUsedCtx", reflect.TypeOf((*MockRedisRepository)(nil).MarkRefreshTokenUsedCtx), ctx, key, seconds)
+}
+
+// DeleteRefreshFamilyCtx mocks base method
+func (m *MockRedisRepository) DeleteRefreshFamilyCtx(ctx context.Context, familyKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRefreshFamilyCtx", ctx, familyKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRefreshFamilyCtx indicates an expected call of DeleteRefreshFamilyCtx
+func (mr *MockRedisRepositoryMockRecorder) DeleteRefreshFamilyCtx(ctx, familyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRefreshFamilyCtx", reflect.TypeOf((*MockRedisRepository)(nil).DeleteRefreshFamilyCtx), ctx, familyKey)
+}
+
+// DeleteUserRefreshFamiliesCtx mocks base method
+func (m *MockRedisRepository) DeleteUserRefreshFamiliesCtx(ctx context.Context, userKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserRefreshFamiliesCtx", ctx, userKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserRefreshFamiliesCtx indicates an expected call of DeleteUserRefreshFamiliesCtx
+func (mr *MockRedisRepositoryMockRecorder) DeleteUserRefreshFamiliesCtx(ctx, userKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserRefreshFamiliesCtx", reflect.TypeOf((*MockRedisRepository)(nil).DeleteUserRefreshFamiliesCtx), ctx, userKey)
+}
+
+// SetPasswordResetCtx mocks base method
+func (m *MockRedisRepository) SetPasswordResetCtx(ctx context.Context, key string, seconds int, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPasswordResetCtx", ctx, key, seconds, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPasswordResetCtx indicates an expected call of SetPasswordResetCtx
+func (mr *MockRedisRepositoryMockRecorder) SetPasswordResetCtx(ctx, key, seconds, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPasswordResetCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetPasswordResetCtx), ctx, key, seconds, userID)
+}
+
+// GetPasswordResetCtx mocks base method
+func (m *MockRedisRepository) GetPasswordResetCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetCtx", ctx, key)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetCtx indicates an expected call of GetPasswordResetCtx
+func (mr *MockRedisRepositoryMockRecorder) GetPasswordResetCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetPasswordResetCtx), ctx, key)
+}
+
+// PopPasswordResetCtx mocks base method
+func (m *MockRedisRepository) PopPasswordResetCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopPasswordResetCtx", ctx, key)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopPasswordResetCtx indicates an expected call of PopPasswordResetCtx
+func (mr *MockRedisRepositoryMockRecorder) PopPasswordResetCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopPasswordResetCtx", reflect.TypeOf((*MockRedisRepository)(nil).PopPasswordResetCtx), ctx, key)
+}
+
+// SetTwoFactorChallengeCtx mocks base method
+func (m *MockRedisRepository) SetTwoFactorChallengeCtx(ctx context.Context, key string, seconds int, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTwoFactorChallengeCtx", ctx, key, seconds, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTwoFactorChallengeCtx indicates an expected call of SetTwoFactorChallengeCtx
+func (mr *MockRedisRepositoryMockRecorder) SetTwoFactorChallengeCtx(ctx, key, seconds, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTwoFactorChallengeCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetTwoFactorChallengeCtx), ctx, key, seconds, userID)
+}
+
+// GetTwoFactorChallengeCtx mocks base method
+func (m *MockRedisRepository) GetTwoFactorChallengeCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactorChallengeCtx", ctx, key)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactorChallengeCtx indicates an expected call of GetTwoFactorChallengeCtx
+func (mr *MockRedisRepositoryMockRecorder) GetTwoFactorChallengeCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactorChallengeCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetTwoFactorChallengeCtx), ctx, key)
+}
+
+// IncrTwoFactorAttemptsCtx mocks base method
+func (m *MockRedisRepository) IncrTwoFactorAttemptsCtx(ctx context.Context, key string, seconds int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrTwoFactorAttemptsCtx", ctx, key, seconds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrTwoFactorAttemptsCtx indicates an expected call of IncrTwoFactorAttemptsCtx
+func (mr *MockRedisRepositoryMockRecorder) IncrTwoFactorAttemptsCtx(ctx, key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrTwoFactorAttemptsCtx", reflect.TypeOf((*MockRedisRepository)(nil).IncrTwoFactorAttemptsCtx), ctx, key, seconds)
+}
+
+// DeleteTwoFactorChallengeCtx mocks base method
+func (m *MockRedisRepository) DeleteTwoFactorChallengeCtx(ctx context.Context, key, attemptsKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactorChallengeCtx", ctx, key, attemptsKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTwoFactorChallengeCtx indicates an expected call of DeleteTwoFactorChallengeCtx
+func (mr *MockRedisRepositoryMockRecorder) DeleteTwoFactorChallengeCtx(ctx, key, attemptsKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactorChallengeCtx", reflect.TypeOf((*MockRedisRepository)(nil).DeleteTwoFactorChallengeCtx), ctx, key, attemptsKey)
+}
+
+// SetTwoFactorStepCtx mocks base method
+func (m *MockRedisRepository) SetTwoFactorStepCtx(ctx context.Context, key string, step int64, seconds int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTwoFactorStepCtx", ctx, key, step, seconds)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTwoFactorStepCtx indicates an expected call of SetTwoFactorStepCtx
+func (mr *MockRedisRepositoryMockRecorder) SetTwoFactorStepCtx(ctx, key, step, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTwoFactorStepCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetTwoFactorStepCtx), ctx, key, step, seconds)
+}
+
+// IncrLoginFailuresCtx mocks base method
+func (m *MockRedisRepository) IncrLoginFailuresCtx(ctx context.Context, key string, seconds int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLoginFailuresCtx", ctx, key, seconds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrLoginFailuresCtx indicates an expected call of IncrLoginFailuresCtx
+func (mr *MockRedisRepositoryMockRecorder) IncrLoginFailuresCtx(ctx, key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLoginFailuresCtx", reflect.TypeOf((*MockRedisRepository)(nil).IncrLoginFailuresCtx), ctx, key, seconds)
+}
+
+// SetLoginLockCtx mocks base method
+func (m *MockRedisRepository) SetLoginLockCtx(ctx context.Context, key string, seconds int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLoginLockCtx", ctx, key, seconds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLoginLockCtx indicates an expected call of SetLoginLockCtx
+func (mr *MockRedisRepositoryMockRecorder) SetLoginLockCtx(ctx, key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoginLockCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetLoginLockCtx), ctx, key, seconds)
+}
+
+// GetLoginLockCtx mocks base method
+func (m *MockRedisRepository) GetLoginLockCtx(ctx context.Context, keys ...string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLoginLockCtx", varargs...)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginLockCtx indicates an expected call of GetLoginLockCtx
+func (mr *MockRedisRepositoryMockRecorder) GetLoginLockCtx(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginLockCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetLoginLockCtx), varargs...)
+}
+
+// DeleteLoginFailuresCtx mocks base method
+func (m *MockRedisRepository) DeleteLoginFailuresCtx(ctx context.Context, keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteLoginFailuresCtx", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginFailuresCtx indicates an expected call of DeleteLoginFailuresCtx
+func (mr *MockRedisRepositoryMockRecorder) DeleteLoginFailuresCtx(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginFailuresCtx", reflect.TypeOf((*MockRedisRepository)(nil).DeleteLoginFailuresCtx), varargs...)
+}
@@ -37,18 +37,18 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 }
 
 // Register mocks base method
-func (m *MockUseCase) Register(ctx context.Context, user *models.User) (*models.UserWithToken, error) {
+func (m *MockUseCase) Register(ctx context.Context, user *models.User, inviteCode string) (*models.UserWithToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", ctx, user)
+	ret := m.ctrl.Call(m, "Register", ctx, user, inviteCode)
 	ret0, _ := ret[0].(*models.UserWithToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Register indicates an expected call of Register
-func (mr *MockUseCaseMockRecorder) Register(ctx, user interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) Register(ctx, user, inviteCode interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUseCase)(nil).Register), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUseCase)(nil).Register), ctx, user, inviteCode)
 }
 
 // Login mocks base method
@@ -81,6 +81,21 @@ func (mr *MockUseCaseMockRecorder) Update(ctx, user interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUseCase)(nil).Update), ctx, user)
 }
 
+// UpdateRole mocks base method
+func (m *MockUseCase) UpdateRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, userID, role)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole
+func (mr *MockUseCaseMockRecorder) UpdateRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockUseCase)(nil).UpdateRole), ctx, userID, role)
+}
+
 // Delete mocks base method
 func (m *MockUseCase) Delete(ctx context.Context, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -154,3 +169,265 @@ func (mr *MockUseCaseMockRecorder) UploadAvatar(ctx, userID, file interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockUseCase)(nil).UploadAvatar), ctx, userID, file)
 }
+
+// RefreshToken mocks base method
+func (m *MockUseCase) RefreshToken(ctx context.Context, refreshToken string) (*models.UserWithToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(*models.UserWithToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken
+func (mr *MockUseCaseMockRecorder) RefreshToken(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockUseCase)(nil).RefreshToken), ctx, refreshToken)
+}
+
+// RevokeRefreshToken mocks base method
+func (m *MockUseCase) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken
+func (mr *MockUseCaseMockRecorder) RevokeRefreshToken(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockUseCase)(nil).RevokeRefreshToken), ctx, refreshToken)
+}
+
+// RevokeUserRefreshTokens mocks base method
+func (m *MockUseCase) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserRefreshTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserRefreshTokens indicates an expected call of RevokeUserRefreshTokens
+func (mr *MockUseCaseMockRecorder) RevokeUserRefreshTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRefreshTokens", reflect.TypeOf((*MockUseCase)(nil).RevokeUserRefreshTokens), ctx, userID)
+}
+
+// ForgotPassword mocks base method
+func (m *MockUseCase) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword
+func (mr *MockUseCaseMockRecorder) ForgotPassword(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockUseCase)(nil).ForgotPassword), ctx, email)
+}
+
+// ResetPassword mocks base method
+func (m *MockUseCase) ResetPassword(ctx context.Context, token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword
+func (mr *MockUseCaseMockRecorder) ResetPassword(ctx, token, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUseCase)(nil).ResetPassword), ctx, token, password)
+}
+
+// VerifyEmail mocks base method
+func (m *MockUseCase) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail
+func (mr *MockUseCaseMockRecorder) VerifyEmail(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUseCase)(nil).VerifyEmail), ctx, token)
+}
+
+// ResendVerificationEmail mocks base method
+func (m *MockUseCase) ResendVerificationEmail(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationEmail", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerificationEmail indicates an expected call of ResendVerificationEmail
+func (mr *MockUseCaseMockRecorder) ResendVerificationEmail(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationEmail", reflect.TypeOf((*MockUseCase)(nil).ResendVerificationEmail), ctx, userID)
+}
+
+// LoginTwoFactor mocks base method
+func (m *MockUseCase) LoginTwoFactor(ctx context.Context, challenge, code string) (*models.UserWithToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginTwoFactor", ctx, challenge, code)
+	ret0, _ := ret[0].(*models.UserWithToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginTwoFactor indicates an expected call of LoginTwoFactor
+func (mr *MockUseCaseMockRecorder) LoginTwoFactor(ctx, challenge, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginTwoFactor", reflect.TypeOf((*MockUseCase)(nil).LoginTwoFactor), ctx, challenge, code)
+}
+
+// LoginExternal mocks base method
+func (m *MockUseCase) LoginExternal(ctx context.Context, userID uuid.UUID) (*models.UserWithToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginExternal", ctx, userID)
+	ret0, _ := ret[0].(*models.UserWithToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginExternal indicates an expected call of LoginExternal
+func (mr *MockUseCaseMockRecorder) LoginExternal(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginExternal", reflect.TypeOf((*MockUseCase)(nil).LoginExternal), ctx, userID)
+}
+
+// UnlockLogin mocks base method
+func (m *MockUseCase) UnlockLogin(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockLogin", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockLogin indicates an expected call of UnlockLogin
+func (mr *MockUseCaseMockRecorder) UnlockLogin(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockLogin", reflect.TypeOf((*MockUseCase)(nil).UnlockLogin), ctx, userID)
+}
+
+// ChangePassword mocks base method
+func (m *MockUseCase) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword
+func (mr *MockUseCaseMockRecorder) ChangePassword(ctx, userID, currentPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUseCase)(nil).ChangePassword), ctx, userID, currentPassword, newPassword)
+}
+
+// EnrollTwoFactor mocks base method
+func (m *MockUseCase) EnrollTwoFactor(ctx context.Context, userID uuid.UUID) (*models.TwoFactorEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", ctx, userID)
+	ret0, _ := ret[0].(*models.TwoFactorEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor
+func (mr *MockUseCaseMockRecorder) EnrollTwoFactor(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockUseCase)(nil).EnrollTwoFactor), ctx, userID)
+}
+
+// ConfirmTwoFactor mocks base method
+func (m *MockUseCase) ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) (*models.RecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", ctx, userID, code)
+	ret0, _ := ret[0].(*models.RecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor
+func (mr *MockUseCaseMockRecorder) ConfirmTwoFactor(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockUseCase)(nil).ConfirmTwoFactor), ctx, userID, code)
+}
+
+// DisableTwoFactor mocks base method
+func (m *MockUseCase) DisableTwoFactor(ctx context.Context, userID uuid.UUID, password, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", ctx, userID, password, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor
+func (mr *MockUseCaseMockRecorder) DisableTwoFactor(ctx, userID, password, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockUseCase)(nil).DisableTwoFactor), ctx, userID, password, code)
+}
+
+// GetPrivacy mocks base method
+func (m *MockUseCase) GetPrivacy(ctx context.Context, userID uuid.UUID) (*models.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacy", ctx, userID)
+	ret0, _ := ret[0].(*models.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacy indicates an expected call of GetPrivacy
+func (mr *MockUseCaseMockRecorder) GetPrivacy(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacy", reflect.TypeOf((*MockUseCase)(nil).GetPrivacy), ctx, userID)
+}
+
+// UpdatePrivacy mocks base method
+func (m *MockUseCase) UpdatePrivacy(ctx context.Context, userID uuid.UUID, privacy *models.PrivacySettings) (*models.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrivacy", ctx, userID, privacy)
+	ret0, _ := ret[0].(*models.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrivacy indicates an expected call of UpdatePrivacy
+func (mr *MockUseCaseMockRecorder) UpdatePrivacy(ctx, userID, privacy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrivacy", reflect.TypeOf((*MockUseCase)(nil).UpdatePrivacy), ctx, userID, privacy)
+}
+
+// ProjectUser mocks base method
+func (m *MockUseCase) ProjectUser(ctx context.Context, viewer, user *models.User) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectUser", ctx, viewer, user)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectUser indicates an expected call of ProjectUser
+func (mr *MockUseCaseMockRecorder) ProjectUser(ctx, viewer, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectUser", reflect.TypeOf((*MockUseCase)(nil).ProjectUser), ctx, viewer, user)
+}
+
+// ProjectUsers mocks base method
+func (m *MockUseCase) ProjectUsers(ctx context.Context, viewer *models.User, users []*models.User) ([]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectUsers", ctx, viewer, users)
+	ret0, _ := ret[0].([]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectUsers indicates an expected call of ProjectUsers
+func (mr *MockUseCaseMockRecorder) ProjectUsers(ctx, viewer, users interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectUsers", reflect.TypeOf((*MockUseCase)(nil).ProjectUsers), ctx, viewer, users)
+}
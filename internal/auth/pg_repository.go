@@ -14,9 +14,20 @@ import (
 type Repository interface {
 	Register(ctx context.Context, user *models.User) (*models.User, error)
 	Update(ctx context.Context, user *models.User) (*models.User, error)
+	UpdateAvatars(ctx context.Context, userID uuid.UUID, avatars models.Avatars) (*models.User, error)
+	UpdateRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error)
 	Delete(ctx context.Context, userID uuid.UUID) error
 	GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	FindByName(ctx context.Context, name string, query *utils.PaginationQuery) (*models.UsersList, error)
 	FindByEmail(ctx context.Context, user *models.User) (*models.User, error)
 	GetUsers(ctx context.Context, pq *utils.PaginationQuery) (*models.UsersList, error)
+	UpdatePassword(ctx context.Context, userID uuid.UUID, password string) error
+	VerifyEmail(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error
+	EnableTOTP(ctx context.Context, userID uuid.UUID, recoveryCodeHashes []string) error
+	DisableTOTP(ctx context.Context, userID uuid.UUID) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+	GetPrivacy(ctx context.Context, userID uuid.UUID) (*models.PrivacySettings, error)
+	GetPrivacyByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*models.PrivacySettings, error)
+	UpdatePrivacy(ctx context.Context, userID uuid.UUID, privacy *models.PrivacySettings) (*models.PrivacySettings, error)
 }
@@ -3,6 +3,9 @@ package auth
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/AleksK1NG/api-mc/internal/models"
 )
@@ -12,4 +15,21 @@ type RedisRepository interface {
 	GetByIDCtx(ctx context.Context, key string) (*models.User, error)
 	SetUserCtx(ctx context.Context, key string, seconds int, user *models.User) error
 	DeleteUserCtx(ctx context.Context, key string) error
+	SetRefreshTokenCtx(ctx context.Context, userKey string, familyKey string, key string, seconds int, token *models.RefreshToken) error
+	GetRefreshTokenCtx(ctx context.Context, key string) (*models.RefreshToken, error)
+	MarkRefreshTokenUsedCtx(ctx context.Context, key string, seconds int) (bool, error)
+	DeleteRefreshFamilyCtx(ctx context.Context, familyKey string) error
+	DeleteUserRefreshFamiliesCtx(ctx context.Context, userKey string) error
+	SetPasswordResetCtx(ctx context.Context, key string, seconds int, userID uuid.UUID) error
+	GetPasswordResetCtx(ctx context.Context, key string) (uuid.UUID, error)
+	PopPasswordResetCtx(ctx context.Context, key string) (uuid.UUID, error)
+	SetTwoFactorChallengeCtx(ctx context.Context, key string, seconds int, userID uuid.UUID) error
+	GetTwoFactorChallengeCtx(ctx context.Context, key string) (uuid.UUID, error)
+	IncrTwoFactorAttemptsCtx(ctx context.Context, key string, seconds int) (int64, error)
+	DeleteTwoFactorChallengeCtx(ctx context.Context, key string, attemptsKey string) error
+	SetTwoFactorStepCtx(ctx context.Context, key string, step int64, seconds int) (bool, error)
+	IncrLoginFailuresCtx(ctx context.Context, key string, seconds int) (int64, error)
+	SetLoginLockCtx(ctx context.Context, key string, seconds int) error
+	GetLoginLockCtx(ctx context.Context, keys ...string) (time.Duration, error)
+	DeleteLoginFailuresCtx(ctx context.Context, keys ...string) error
 }
@@ -3,62 +3,63 @@ package repository
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/google/uuid"
-	"github.com/minio/minio-go/v7"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 
 	"github.com/AleksK1NG/api-mc/internal/auth"
 	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
 )
 
 // Auth AWS S3 repository
 type authAWSRepository struct {
-	client *minio.Client
+	store blobstore.BlobStore
 }
 
 // Auth AWS S3 repository constructor
-func NewAuthAWSRepository(awsClient *minio.Client) auth.AWSRepository {
-	return &authAWSRepository{client: awsClient}
+func NewAuthAWSRepository(blobStore blobstore.BlobStore) auth.AWSRepository {
+	return &authAWSRepository{store: blobStore}
 }
 
-// Upload file to AWS
-func (aws *authAWSRepository) PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error) {
+// Upload public file, returns stored object name
+func (aws *authAWSRepository) PutObject(ctx context.Context, input models.UploadInput) (string, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authAWSRepository.PutObject")
 	defer span.Finish()
 
-	options := minio.PutObjectOptions{
-		ContentType:  input.ContentType,
-		UserMetadata: map[string]string{"x-amz-acl": "public-read"},
+	objectName := input.ObjectName
+	if objectName == "" {
+		objectName = aws.generateFileName(input.Name)
 	}
 
-	uploadInfo, err := aws.client.PutObject(ctx, input.BucketName, aws.generateFileName(input.Name), input.File, input.Size, options)
-	if err != nil {
-		return nil, errors.Wrap(err, "authAWSRepository.FileUpload.PutObject")
+	options := blobstore.PutOptions{ContentType: input.ContentType, Public: true}
+	if err := aws.store.Put(ctx, input.BucketName, objectName, input.File, input.Size, options); err != nil {
+		return "", errors.Wrap(err, "authAWSRepository.FileUpload.PutObject")
 	}
 
-	return &uploadInfo, err
+	return objectName, nil
 }
 
-// Download file from AWS
-func (aws *authAWSRepository) GetObject(ctx context.Context, bucket string, fileName string) (*minio.Object, error) {
+// Download file
+func (aws *authAWSRepository) GetObject(ctx context.Context, bucket string, fileName string) (io.ReadCloser, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authAWSRepository.GetObject")
 	defer span.Finish()
 
-	object, err := aws.client.GetObject(ctx, bucket, fileName, minio.GetObjectOptions{})
+	object, err := aws.store.Get(ctx, bucket, fileName)
 	if err != nil {
 		return nil, errors.Wrap(err, "authAWSRepository.FileDownload.GetObject")
 	}
 	return object, nil
 }
 
-// Delete file from AWS
+// Delete file
 func (aws *authAWSRepository) RemoveObject(ctx context.Context, bucket string, fileName string) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authAWSRepository.RemoveObject")
 	defer span.Finish()
 
-	if err := aws.client.RemoveObject(ctx, bucket, fileName, minio.RemoveObjectOptions{}); err != nil {
+	if err := aws.store.Remove(ctx, bucket, fileName); err != nil {
 		return errors.Wrap(err, "authAWSRepository.RemoveObject")
 	}
 	return nil
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -24,15 +25,15 @@ func NewAuthRepository(db *sqlx.DB) auth.Repository {
 	return &authRepo{db: db}
 }
 
-// Create new user
+// Create new user, role defaults to user when not set
 func (r *authRepo) Register(ctx context.Context, user *models.User) (*models.User, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.Register")
 	defer span.Finish()
 
 	u := &models.User{}
 	if err := r.db.QueryRowxContext(ctx, createUserQuery, &user.FirstName, &user.LastName, &user.Email,
-		&user.Password, &user.Role, &user.About, &user.Avatar, &user.PhoneNumber, &user.Address, &user.City,
-		&user.Gender, &user.Postcode, &user.Birthday,
+		&user.Password, &user.About, &user.PhoneNumber, &user.Address, &user.City,
+		&user.Gender, &user.Postcode, &user.Birthday, &user.Role,
 	).StructScan(u); err != nil {
 		return nil, errors.Wrap(err, "authRepo.Register.StructScan")
 	}
@@ -47,7 +48,7 @@ func (r *authRepo) Update(ctx context.Context, user *models.User) (*models.User,
 
 	u := &models.User{}
 	if err := r.db.GetContext(ctx, u, updateUserQuery, &user.FirstName, &user.LastName, &user.Email,
-		&user.Role, &user.About, &user.Avatar, &user.PhoneNumber, &user.Address, &user.City, &user.Gender,
+		&user.About, &user.PhoneNumber, &user.Address, &user.City, &user.Gender,
 		&user.Postcode, &user.Birthday, &user.UserID,
 	); err != nil {
 		return nil, errors.Wrap(err, "authRepo.Update.GetContext")
@@ -56,6 +57,32 @@ func (r *authRepo) Update(ctx context.Context, user *models.User) (*models.User,
 	return u, nil
 }
 
+// Replace user avatars
+func (r *authRepo) UpdateAvatars(ctx context.Context, userID uuid.UUID, avatars models.Avatars) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.UpdateAvatars")
+	defer span.Finish()
+
+	u := &models.User{}
+	if err := r.db.GetContext(ctx, u, updateAvatarsQuery, avatars, userID); err != nil {
+		return nil, errors.Wrap(err, "authRepo.UpdateAvatars.GetContext")
+	}
+
+	return u, nil
+}
+
+// Set user role
+func (r *authRepo) UpdateRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.UpdateRole")
+	defer span.Finish()
+
+	u := &models.User{}
+	if err := r.db.GetContext(ctx, u, updateUserRoleQuery, role, userID); err != nil {
+		return nil, errors.Wrap(err, "authRepo.UpdateRole.GetContext")
+	}
+
+	return u, nil
+}
+
 // Delete existing user
 func (r *authRepo) Delete(ctx context.Context, userID uuid.UUID) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.Delete")
@@ -192,3 +219,217 @@ func (r *authRepo) FindByEmail(ctx context.Context, user *models.User) (*models.
 	}
 	return foundUser, nil
 }
+
+// Update user password hash
+func (r *authRepo) UpdatePassword(ctx context.Context, userID uuid.UUID, password string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.UpdatePassword")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, updatePasswordQuery, password, userID)
+	if err != nil {
+		return errors.Wrap(err, "authRepo.UpdatePassword.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "authRepo.UpdatePassword.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "authRepo.UpdatePassword.rowsAffected")
+	}
+
+	return nil
+}
+
+// Mark user email as verified
+func (r *authRepo) VerifyEmail(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.VerifyEmail")
+	defer span.Finish()
+
+	u := &models.User{}
+	if err := r.db.GetContext(ctx, u, verifyEmailQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "authRepo.VerifyEmail.GetContext")
+	}
+
+	return u, nil
+}
+
+// Store pending totp secret, enabled two factor authentication is never overwritten
+func (r *authRepo) SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.SetTOTPSecret")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, setTOTPSecretQuery, secret, userID)
+	if err != nil {
+		return errors.Wrap(err, "authRepo.SetTOTPSecret.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "authRepo.SetTOTPSecret.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "authRepo.SetTOTPSecret.rowsAffected")
+	}
+
+	return nil
+}
+
+// Enable two factor authentication and replace recovery codes
+func (r *authRepo) EnableTOTP(ctx context.Context, userID uuid.UUID, recoveryCodeHashes []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.EnableTOTP")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "authRepo.EnableTOTP.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, enableTOTPQuery, userID)
+	if err != nil {
+		return errors.Wrap(err, "authRepo.EnableTOTP.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "authRepo.EnableTOTP.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "authRepo.EnableTOTP.rowsAffected")
+	}
+
+	if _, err = tx.ExecContext(ctx, deleteRecoveryCodesQuery, userID); err != nil {
+		return errors.Wrap(err, "authRepo.EnableTOTP.deleteRecoveryCodes")
+	}
+
+	for _, codeHash := range recoveryCodeHashes {
+		if _, err = tx.ExecContext(ctx, createRecoveryCodeQuery, userID, codeHash); err != nil {
+			return errors.Wrap(err, "authRepo.EnableTOTP.createRecoveryCode")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "authRepo.EnableTOTP.Commit")
+	}
+
+	return nil
+}
+
+// Disable two factor authentication and delete recovery codes
+func (r *authRepo) DisableTOTP(ctx context.Context, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.DisableTOTP")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "authRepo.DisableTOTP.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, disableTOTPQuery, userID); err != nil {
+		return errors.Wrap(err, "authRepo.DisableTOTP.ExecContext")
+	}
+
+	if _, err = tx.ExecContext(ctx, deleteRecoveryCodesQuery, userID); err != nil {
+		return errors.Wrap(err, "authRepo.DisableTOTP.deleteRecoveryCodes")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "authRepo.DisableTOTP.Commit")
+	}
+
+	return nil
+}
+
+// Mark recovery code as used, returns sql.ErrNoRows for unknown or already used code
+func (r *authRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.UseRecoveryCode")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, useRecoveryCodeQuery, userID, codeHash)
+	if err != nil {
+		return errors.Wrap(err, "authRepo.UseRecoveryCode.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "authRepo.UseRecoveryCode.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "authRepo.UseRecoveryCode.rowsAffected")
+	}
+
+	return nil
+}
+
+// Get privacy settings of user, returns sql.ErrNoRows when user never changed them
+func (r *authRepo) GetPrivacy(ctx context.Context, userID uuid.UUID) (*models.PrivacySettings, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.GetPrivacy")
+	defer span.Finish()
+
+	privacy := &models.PrivacySettings{}
+	if err := r.db.GetContext(ctx, privacy, getPrivacyQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "authRepo.GetPrivacy.GetContext")
+	}
+
+	return privacy, nil
+}
+
+// Get privacy settings of users, users without settings are missing from result
+func (r *authRepo) GetPrivacyByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*models.PrivacySettings, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.GetPrivacyByUserIDs")
+	defer span.Finish()
+
+	result := make(map[uuid.UUID]*models.PrivacySettings, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, id.String())
+	}
+
+	var rows []struct {
+		UserID uuid.UUID `db:"user_id"`
+		models.PrivacySettings
+	}
+	if err := r.db.SelectContext(ctx, &rows, getPrivacyByUserIDsQuery, strings.Join(ids, ",")); err != nil {
+		return nil, errors.Wrap(err, "authRepo.GetPrivacyByUserIDs.SelectContext")
+	}
+
+	for i := range rows {
+		result[rows[i].UserID] = &rows[i].PrivacySettings
+	}
+
+	return result, nil
+}
+
+// Create or replace privacy settings of user
+func (r *authRepo) UpdatePrivacy(
+	ctx context.Context,
+	userID uuid.UUID,
+	privacy *models.PrivacySettings,
+) (*models.PrivacySettings, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.UpdatePrivacy")
+	defer span.Finish()
+
+	updated := &models.PrivacySettings{}
+	if err := r.db.GetContext(
+		ctx,
+		updated,
+		upsertPrivacyQuery,
+		userID,
+		privacy.ShowEmail,
+		privacy.ShowPhoneNumber,
+		privacy.ShowAddress,
+		privacy.ShowLocation,
+		privacy.ShowGender,
+		privacy.ShowBirthday,
+	); err != nil {
+		return nil, errors.Wrap(err, "authRepo.UpdatePrivacy.GetContext")
+	}
+
+	return updated, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
@@ -43,8 +44,8 @@ func TestAuthRepo_Register(t *testing.T) {
 		}
 
 		mock.ExpectQuery(createUserQuery).WithArgs(&user.FirstName, &user.LastName, &user.Email,
-			&user.Password, &user.Role, &user.About, &user.Avatar, &user.PhoneNumber, &user.Address, &user.City,
-			&user.Gender, &user.Postcode, &user.Birthday).WillReturnRows(rows)
+			&user.Password, &user.About, &user.PhoneNumber, &user.Address, &user.City,
+			&user.Gender, &user.Postcode, &user.Birthday, &user.Role).WillReturnRows(rows)
 
 		createdUser, err := authRepo.Register(context.Background(), user)
 
@@ -154,7 +155,7 @@ func TestAuthRepo_Update(t *testing.T) {
 		}
 
 		mock.ExpectQuery(updateUserQuery).WithArgs(&user.FirstName, &user.LastName, &user.Email,
-			&user.Role, &user.About, &user.Avatar, &user.PhoneNumber, &user.Address, &user.City, &user.Gender,
+			&user.About, &user.PhoneNumber, &user.Address, &user.City, &user.Gender,
 			&user.Postcode, &user.Birthday, &user.UserID).WillReturnRows(rows)
 
 		updatedUser, err := authRepo.Update(context.Background(), user)
@@ -165,6 +166,32 @@ func TestAuthRepo_Update(t *testing.T) {
 	})
 }
 
+func TestAuthRepo_UpdateRole(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	authRepo := NewAuthRepository(sqlxDB)
+
+	t.Run("UpdateRole", func(t *testing.T) {
+		uid := uuid.New()
+		role := "moderator"
+
+		rows := sqlmock.NewRows([]string{"user_id", "email", "role"}).AddRow(uid, "alex@gmail.com", role)
+
+		mock.ExpectQuery(updateUserRoleQuery).WithArgs(role, uid).WillReturnRows(rows)
+
+		updatedUser, err := authRepo.UpdateRole(context.Background(), uid, role)
+		require.NoError(t, err)
+		require.Equal(t, role, *updatedUser.Role)
+	})
+}
+
 func TestAuthRepo_FindByEmail(t *testing.T) {
 	t.Parallel()
 
@@ -268,3 +295,177 @@ func TestAuthRepo_FindByName(t *testing.T) {
 		require.NotNil(t, usersList)
 	})
 }
+
+func TestAuthRepo_UpdatePassword(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	authRepo := NewAuthRepository(sqlxDB)
+
+	t.Run("UpdatePassword", func(t *testing.T) {
+		uid := uuid.New()
+		password := "hashed password"
+
+		mock.ExpectExec(updatePasswordQuery).WithArgs(password, uid).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := authRepo.UpdatePassword(context.Background(), uid, password)
+		require.NoError(t, err)
+	})
+
+	t.Run("UpdatePassword No rows", func(t *testing.T) {
+		uid := uuid.New()
+		password := "hashed password"
+
+		mock.ExpectExec(updatePasswordQuery).WithArgs(password, uid).WillReturnResult(sqlmock.NewResult(1, 0))
+
+		err := authRepo.UpdatePassword(context.Background(), uid, password)
+		require.NotNil(t, err)
+	})
+}
+
+func TestAuthRepo_VerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	authRepo := NewAuthRepository(sqlxDB)
+
+	t.Run("VerifyEmail", func(t *testing.T) {
+		uid := uuid.New()
+		verifiedAt := time.Now()
+
+		rows := sqlmock.NewRows([]string{"user_id", "email", "email_verified_at"}).AddRow(
+			uid, "alex@mail.ru", verifiedAt)
+
+		mock.ExpectQuery(verifyEmailQuery).WithArgs(uid).WillReturnRows(rows)
+
+		user, err := authRepo.VerifyEmail(context.Background(), uid)
+		require.NoError(t, err)
+		require.NotNil(t, user.EmailVerifiedAt)
+		require.True(t, verifiedAt.Equal(*user.EmailVerifiedAt))
+	})
+}
+
+func TestAuthRepo_EnableTOTP(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	authRepo := NewAuthRepository(sqlxDB)
+
+	t.Run("EnableTOTP", func(t *testing.T) {
+		uid := uuid.New()
+		hashes := []string{"first hash", "second hash"}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(enableTOTPQuery).WithArgs(uid).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(deleteRecoveryCodesQuery).WithArgs(uid).WillReturnResult(sqlmock.NewResult(0, 0))
+		for _, hash := range hashes {
+			mock.ExpectExec(createRecoveryCodeQuery).WithArgs(uid, hash).WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+		mock.ExpectCommit()
+
+		err := authRepo.EnableTOTP(context.Background(), uid, hashes)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("EnableTOTP Not enrolled", func(t *testing.T) {
+		uid := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(enableTOTPQuery).WithArgs(uid).WillReturnResult(sqlmock.NewResult(1, 0))
+		mock.ExpectRollback()
+
+		err := authRepo.EnableTOTP(context.Background(), uid, []string{"hash"})
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuthRepo_UseRecoveryCode(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	authRepo := NewAuthRepository(sqlxDB)
+
+	t.Run("UseRecoveryCode", func(t *testing.T) {
+		uid := uuid.New()
+
+		mock.ExpectExec(useRecoveryCodeQuery).WithArgs(uid, "hash").WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := authRepo.UseRecoveryCode(context.Background(), uid, "hash")
+		require.NoError(t, err)
+	})
+
+	t.Run("UseRecoveryCode Used", func(t *testing.T) {
+		uid := uuid.New()
+
+		mock.ExpectExec(useRecoveryCodeQuery).WithArgs(uid, "hash").WillReturnResult(sqlmock.NewResult(1, 0))
+
+		err := authRepo.UseRecoveryCode(context.Background(), uid, "hash")
+		require.Error(t, err)
+	})
+}
+
+func TestAuthRepo_GetPrivacyByUserIDs(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	authRepo := NewAuthRepository(sqlxDB)
+
+	t.Run("GetPrivacyByUserIDs", func(t *testing.T) {
+		first := uuid.New()
+		second := uuid.New()
+
+		rows := sqlmock.NewRows([]string{
+			"user_id", "show_email", "show_phone_number", "show_address", "show_location", "show_gender", "show_birthday",
+		}).AddRow(first, true, false, false, true, false, false)
+
+		mock.ExpectQuery(getPrivacyByUserIDsQuery).
+			WithArgs(first.String() + "," + second.String()).
+			WillReturnRows(rows)
+
+		privacy, err := authRepo.GetPrivacyByUserIDs(context.Background(), []uuid.UUID{first, second})
+		require.NoError(t, err)
+		require.Len(t, privacy, 1)
+		require.True(t, privacy[first].ShowEmail)
+		require.True(t, privacy[first].ShowLocation)
+		require.Nil(t, privacy[second])
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		privacy, err := authRepo.GetPrivacyByUserIDs(context.Background(), nil)
+		require.NoError(t, err)
+		require.Empty(t, privacy)
+	})
+}
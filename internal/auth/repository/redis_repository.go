@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 
@@ -13,6 +14,16 @@ import (
 	"github.com/AleksK1NG/api-mc/internal/models"
 )
 
+// Stores step only when it is greater than stored one, so check and update are atomic
+var setTwoFactorStepScript = redis.NewScript(`
+local last = tonumber(redis.call('GET', KEYS[1]))
+if last and last >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'EX', ARGV[2])
+return 1
+`)
+
 // Auth redis repository
 type authRedisRepo struct {
 	redisClient *redis.Client
@@ -64,3 +75,284 @@ func (a *authRedisRepo) DeleteUserCtx(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+// Store refresh token, add it to the token family and the family to the user families index
+func (a *authRedisRepo) SetRefreshTokenCtx(ctx context.Context, userKey string, familyKey string, key string, seconds int, token *models.RefreshToken) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.SetRefreshTokenCtx")
+	defer span.Finish()
+
+	tokenBytes, err := json.Marshal(token)
+	if err != nil {
+		return errors.Wrap(err, "authRedisRepo.SetRefreshTokenCtx.json.Marshal")
+	}
+
+	expiration := time.Second * time.Duration(seconds)
+	pipe := a.redisClient.TxPipeline()
+	pipe.Set(ctx, key, tokenBytes, expiration)
+	pipe.SAdd(ctx, familyKey, key)
+	pipe.Expire(ctx, familyKey, expiration)
+	pipe.SAdd(ctx, userKey, familyKey)
+	pipe.Expire(ctx, userKey, expiration)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "authRedisRepo.SetRefreshTokenCtx.pipe.Exec")
+	}
+
+	return nil
+}
+
+// Get refresh token by key
+func (a *authRedisRepo) GetRefreshTokenCtx(ctx context.Context, key string) (*models.RefreshToken, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.GetRefreshTokenCtx")
+	defer span.Finish()
+
+	tokenBytes, err := a.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "authRedisRepo.GetRefreshTokenCtx.redisClient.Get")
+	}
+
+	token := &models.RefreshToken{}
+	if err = json.Unmarshal(tokenBytes, token); err != nil {
+		return nil, errors.Wrap(err, "authRedisRepo.GetRefreshTokenCtx.json.Unmarshal")
+	}
+
+	return token, nil
+}
+
+// Mark refresh token as used, returns false if it was already used before
+func (a *authRedisRepo) MarkRefreshTokenUsedCtx(ctx context.Context, key string, seconds int) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.MarkRefreshTokenUsedCtx")
+	defer span.Finish()
+
+	firstUse, err := a.redisClient.SetNX(ctx, key, true, time.Second*time.Duration(seconds)).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "authRedisRepo.MarkRefreshTokenUsedCtx.redisClient.SetNX")
+	}
+
+	return firstUse, nil
+}
+
+// Delete all refresh tokens of the family
+func (a *authRedisRepo) DeleteRefreshFamilyCtx(ctx context.Context, familyKey string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.DeleteRefreshFamilyCtx")
+	defer span.Finish()
+
+	keys, err := a.redisClient.SMembers(ctx, familyKey).Result()
+	if err != nil {
+		return errors.Wrap(err, "authRedisRepo.DeleteRefreshFamilyCtx.redisClient.SMembers")
+	}
+
+	if err = a.redisClient.Del(ctx, append(keys, familyKey)...).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.DeleteRefreshFamilyCtx.redisClient.Del")
+	}
+
+	return nil
+}
+
+// Delete all refresh token families of the user with their tokens
+func (a *authRedisRepo) DeleteUserRefreshFamiliesCtx(ctx context.Context, userKey string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.DeleteUserRefreshFamiliesCtx")
+	defer span.Finish()
+
+	familyKeys, err := a.redisClient.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return errors.Wrap(err, "authRedisRepo.DeleteUserRefreshFamiliesCtx.redisClient.SMembers")
+	}
+
+	keys := append(familyKeys, userKey)
+	for _, familyKey := range familyKeys {
+		tokenKeys, err := a.redisClient.SMembers(ctx, familyKey).Result()
+		if err != nil {
+			return errors.Wrap(err, "authRedisRepo.DeleteUserRefreshFamiliesCtx.redisClient.SMembers")
+		}
+		keys = append(keys, tokenKeys...)
+	}
+
+	if err = a.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.DeleteUserRefreshFamiliesCtx.redisClient.Del")
+	}
+
+	return nil
+}
+
+// Store password reset token owner
+func (a *authRedisRepo) SetPasswordResetCtx(ctx context.Context, key string, seconds int, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.SetPasswordResetCtx")
+	defer span.Finish()
+
+	if err := a.redisClient.Set(ctx, key, userID.String(), time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.SetPasswordResetCtx.redisClient.Set")
+	}
+
+	return nil
+}
+
+// Get password reset token owner without consuming token
+func (a *authRedisRepo) GetPasswordResetCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.GetPasswordResetCtx")
+	defer span.Finish()
+
+	userIDString, err := a.redisClient.Get(ctx, key).Result()
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.GetPasswordResetCtx.redisClient.Get")
+	}
+
+	userID, err := uuid.Parse(userIDString)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.GetPasswordResetCtx.uuid.Parse")
+	}
+
+	return userID, nil
+}
+
+// Get password reset token owner and delete token, so it can be used only once
+func (a *authRedisRepo) PopPasswordResetCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.PopPasswordResetCtx")
+	defer span.Finish()
+
+	pipe := a.redisClient.TxPipeline()
+	get := pipe.Get(ctx, key)
+	pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.PopPasswordResetCtx.pipe.Exec")
+	}
+
+	userID, err := uuid.Parse(get.Val())
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.PopPasswordResetCtx.uuid.Parse")
+	}
+
+	return userID, nil
+}
+
+// Store two factor login challenge owner
+func (a *authRedisRepo) SetTwoFactorChallengeCtx(ctx context.Context, key string, seconds int, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.SetTwoFactorChallengeCtx")
+	defer span.Finish()
+
+	if err := a.redisClient.Set(ctx, key, userID.String(), time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.SetTwoFactorChallengeCtx.redisClient.Set")
+	}
+
+	return nil
+}
+
+// Get two factor login challenge owner
+func (a *authRedisRepo) GetTwoFactorChallengeCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.GetTwoFactorChallengeCtx")
+	defer span.Finish()
+
+	value, err := a.redisClient.Get(ctx, key).Result()
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.GetTwoFactorChallengeCtx.redisClient.Get")
+	}
+
+	userID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "authRedisRepo.GetTwoFactorChallengeCtx.uuid.Parse")
+	}
+
+	return userID, nil
+}
+
+// Increment failed two factor attempts counter, returns current attempts count
+func (a *authRedisRepo) IncrTwoFactorAttemptsCtx(ctx context.Context, key string, seconds int) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.IncrTwoFactorAttemptsCtx")
+	defer span.Finish()
+
+	pipe := a.redisClient.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, time.Second*time.Duration(seconds))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, errors.Wrap(err, "authRedisRepo.IncrTwoFactorAttemptsCtx.pipe.Exec")
+	}
+
+	return incr.Val(), nil
+}
+
+// Delete two factor login challenge with its attempts counter
+func (a *authRedisRepo) DeleteTwoFactorChallengeCtx(ctx context.Context, key string, attemptsKey string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.DeleteTwoFactorChallengeCtx")
+	defer span.Finish()
+
+	if err := a.redisClient.Del(ctx, key, attemptsKey).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.DeleteTwoFactorChallengeCtx.redisClient.Del")
+	}
+
+	return nil
+}
+
+// Save last accepted totp time step, returns false when step is not newer than the saved one
+func (a *authRedisRepo) SetTwoFactorStepCtx(ctx context.Context, key string, step int64, seconds int) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.SetTwoFactorStepCtx")
+	defer span.Finish()
+
+	accepted, err := setTwoFactorStepScript.Run(ctx, a.redisClient, []string{key}, step, seconds).Int()
+	if err != nil {
+		return false, errors.Wrap(err, "authRedisRepo.SetTwoFactorStepCtx.Run")
+	}
+
+	return accepted == 1, nil
+}
+
+// Increment failed login attempts counter, returns current failures count
+func (a *authRedisRepo) IncrLoginFailuresCtx(ctx context.Context, key string, seconds int) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.IncrLoginFailuresCtx")
+	defer span.Finish()
+
+	pipe := a.redisClient.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, time.Second*time.Duration(seconds))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, errors.Wrap(err, "authRedisRepo.IncrLoginFailuresCtx.pipe.Exec")
+	}
+
+	return incr.Val(), nil
+}
+
+// Block login attempts for duration in seconds
+func (a *authRedisRepo) SetLoginLockCtx(ctx context.Context, key string, seconds int) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.SetLoginLockCtx")
+	defer span.Finish()
+
+	if err := a.redisClient.Set(ctx, key, true, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.SetLoginLockCtx.redisClient.Set")
+	}
+
+	return nil
+}
+
+// Get longest remaining lock duration of given keys, zero when none of them is locked
+func (a *authRedisRepo) GetLoginLockCtx(ctx context.Context, keys ...string) (time.Duration, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.GetLoginLockCtx")
+	defer span.Finish()
+
+	pipe := a.redisClient.Pipeline()
+	ttls := make([]*redis.DurationCmd, 0, len(keys))
+	for _, key := range keys {
+		ttls = append(ttls, pipe.TTL(ctx, key))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, errors.Wrap(err, "authRedisRepo.GetLoginLockCtx.pipe.Exec")
+	}
+
+	var lock time.Duration
+	for _, ttl := range ttls {
+		if ttl.Val() > lock {
+			lock = ttl.Val()
+		}
+	}
+
+	return lock, nil
+}
+
+// Delete failed login counters and locks
+func (a *authRedisRepo) DeleteLoginFailuresCtx(ctx context.Context, keys ...string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.DeleteLoginFailuresCtx")
+	defer span.Finish()
+
+	if err := a.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.DeleteLoginFailuresCtx.redisClient.Del")
+	}
+
+	return nil
+}
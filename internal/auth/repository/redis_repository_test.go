@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis"
 	"github.com/go-redis/redis/v8"
@@ -84,3 +85,204 @@ func TestAuthRedisRepo_DeleteUserCtx(t *testing.T) {
 		require.Nil(t, err)
 	})
 }
+
+func TestAuthRedisRepo_RefreshTokenCtx(t *testing.T) {
+	t.Parallel()
+
+	authRedisRepo := SetupRedis()
+
+	t.Run("SetRefreshTokenCtx", func(t *testing.T) {
+		familyKey := uuid.New().String()
+		key := uuid.New().String()
+		token := &models.RefreshToken{
+			FamilyID: familyKey,
+			UserID:   uuid.New(),
+		}
+
+		err := authRedisRepo.SetRefreshTokenCtx(context.Background(), uuid.New().String(), familyKey, key, 10, token)
+		require.NoError(t, err)
+
+		storedToken, err := authRedisRepo.GetRefreshTokenCtx(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, token.UserID, storedToken.UserID)
+	})
+
+	t.Run("MarkRefreshTokenUsedCtx", func(t *testing.T) {
+		key := uuid.New().String()
+
+		firstUse, err := authRedisRepo.MarkRefreshTokenUsedCtx(context.Background(), key, 10)
+		require.NoError(t, err)
+		require.True(t, firstUse)
+
+		firstUse, err = authRedisRepo.MarkRefreshTokenUsedCtx(context.Background(), key, 10)
+		require.NoError(t, err)
+		require.False(t, firstUse)
+	})
+
+	t.Run("DeleteRefreshFamilyCtx", func(t *testing.T) {
+		familyKey := uuid.New().String()
+		key := uuid.New().String()
+		token := &models.RefreshToken{
+			FamilyID: familyKey,
+			UserID:   uuid.New(),
+		}
+
+		err := authRedisRepo.SetRefreshTokenCtx(context.Background(), uuid.New().String(), familyKey, key, 10, token)
+		require.NoError(t, err)
+
+		err = authRedisRepo.DeleteRefreshFamilyCtx(context.Background(), familyKey)
+		require.NoError(t, err)
+
+		storedToken, err := authRedisRepo.GetRefreshTokenCtx(context.Background(), key)
+		require.Error(t, err)
+		require.Nil(t, storedToken)
+	})
+
+	t.Run("DeleteUserRefreshFamiliesCtx", func(t *testing.T) {
+		userKey := uuid.New().String()
+		keys := []string{uuid.New().String(), uuid.New().String()}
+
+		for _, key := range keys {
+			familyKey := uuid.New().String()
+			token := &models.RefreshToken{
+				FamilyID: familyKey,
+				UserID:   uuid.New(),
+			}
+			err := authRedisRepo.SetRefreshTokenCtx(context.Background(), userKey, familyKey, key, 10, token)
+			require.NoError(t, err)
+		}
+
+		err := authRedisRepo.DeleteUserRefreshFamiliesCtx(context.Background(), userKey)
+		require.NoError(t, err)
+
+		for _, key := range keys {
+			storedToken, err := authRedisRepo.GetRefreshTokenCtx(context.Background(), key)
+			require.Error(t, err)
+			require.Nil(t, storedToken)
+		}
+	})
+}
+
+func TestAuthRedisRepo_PasswordResetCtx(t *testing.T) {
+	t.Parallel()
+
+	authRedisRepo := SetupRedis()
+
+	t.Run("PopPasswordResetCtx", func(t *testing.T) {
+		key := uuid.New().String()
+		userID := uuid.New()
+
+		err := authRedisRepo.SetPasswordResetCtx(context.Background(), key, 10, userID)
+		require.NoError(t, err)
+
+		storedUserID, err := authRedisRepo.GetPasswordResetCtx(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, userID, storedUserID)
+
+		storedUserID, err = authRedisRepo.PopPasswordResetCtx(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, userID, storedUserID)
+
+		_, err = authRedisRepo.PopPasswordResetCtx(context.Background(), key)
+		require.Error(t, err)
+	})
+}
+
+func TestAuthRedisRepo_TwoFactorChallengeCtx(t *testing.T) {
+	t.Parallel()
+
+	authRedisRepo := SetupRedis()
+
+	t.Run("TwoFactorChallengeCtx", func(t *testing.T) {
+		key := uuid.New().String()
+		attemptsKey := uuid.New().String()
+		userID := uuid.New()
+
+		err := authRedisRepo.SetTwoFactorChallengeCtx(context.Background(), key, 10, userID)
+		require.NoError(t, err)
+
+		storedUserID, err := authRedisRepo.GetTwoFactorChallengeCtx(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, userID, storedUserID)
+
+		attempts, err := authRedisRepo.IncrTwoFactorAttemptsCtx(context.Background(), attemptsKey, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), attempts)
+
+		attempts, err = authRedisRepo.IncrTwoFactorAttemptsCtx(context.Background(), attemptsKey, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), attempts)
+
+		err = authRedisRepo.DeleteTwoFactorChallengeCtx(context.Background(), key, attemptsKey)
+		require.NoError(t, err)
+
+		_, err = authRedisRepo.GetTwoFactorChallengeCtx(context.Background(), key)
+		require.Error(t, err)
+	})
+}
+
+func TestAuthRedisRepo_SetTwoFactorStepCtx(t *testing.T) {
+	t.Parallel()
+
+	authRedisRepo := SetupRedis()
+
+	t.Run("SetTwoFactorStepCtx", func(t *testing.T) {
+		key := uuid.New().String()
+
+		accepted, err := authRedisRepo.SetTwoFactorStepCtx(context.Background(), key, 100, 90)
+		require.NoError(t, err)
+		require.True(t, accepted)
+
+		accepted, err = authRedisRepo.SetTwoFactorStepCtx(context.Background(), key, 100, 90)
+		require.NoError(t, err)
+		require.False(t, accepted)
+
+		accepted, err = authRedisRepo.SetTwoFactorStepCtx(context.Background(), key, 99, 90)
+		require.NoError(t, err)
+		require.False(t, accepted)
+
+		accepted, err = authRedisRepo.SetTwoFactorStepCtx(context.Background(), key, 101, 90)
+		require.NoError(t, err)
+		require.True(t, accepted)
+	})
+}
+
+func TestAuthRedisRepo_LoginFailuresCtx(t *testing.T) {
+	t.Parallel()
+
+	authRedisRepo := SetupRedis()
+
+	t.Run("LoginFailuresCtx", func(t *testing.T) {
+		failuresKey := uuid.New().String()
+		emailLockKey := uuid.New().String()
+		ipLockKey := uuid.New().String()
+
+		failures, err := authRedisRepo.IncrLoginFailuresCtx(context.Background(), failuresKey, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), failures)
+
+		failures, err = authRedisRepo.IncrLoginFailuresCtx(context.Background(), failuresKey, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), failures)
+
+		lock, err := authRedisRepo.GetLoginLockCtx(context.Background(), emailLockKey, ipLockKey)
+		require.NoError(t, err)
+		require.Zero(t, lock)
+
+		err = authRedisRepo.SetLoginLockCtx(context.Background(), emailLockKey, 10)
+		require.NoError(t, err)
+		err = authRedisRepo.SetLoginLockCtx(context.Background(), ipLockKey, 30)
+		require.NoError(t, err)
+
+		lock, err = authRedisRepo.GetLoginLockCtx(context.Background(), emailLockKey, ipLockKey)
+		require.NoError(t, err)
+		require.Equal(t, 30*time.Second, lock)
+
+		err = authRedisRepo.DeleteLoginFailuresCtx(context.Background(), failuresKey, emailLockKey, ipLockKey)
+		require.NoError(t, err)
+
+		lock, err = authRedisRepo.GetLoginLockCtx(context.Background(), emailLockKey, ipLockKey)
+		require.NoError(t, err)
+		require.Zero(t, lock)
+	})
+}
@@ -1,41 +1,45 @@
 package repository
 
 const (
-	createUserQuery = `INSERT INTO users (first_name, last_name, email, password, role, about, avatar, phone_number, address,
-	               		city, gender, postcode, birthday, created_at, updated_at, login_date)
-						VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, ''), 'user'), $6, $7, $8, $9, $10, $11, $12, $13, now(), now(), now()) 
+	createUserQuery = `INSERT INTO users (first_name, last_name, email, password, about, phone_number, address,
+	               		city, gender, postcode, birthday, role, created_at, updated_at, login_date)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, COALESCE($12, 'user'), now(), now(), now()) 
 						RETURNING *`
 
 	updateUserQuery = `UPDATE users 
 						SET first_name = COALESCE(NULLIF($1, ''), first_name),
 						    last_name = COALESCE(NULLIF($2, ''), last_name),
 						    email = COALESCE(NULLIF($3, ''), email),
-						    role = COALESCE(NULLIF($4, ''), role),
-						    about = COALESCE(NULLIF($5, ''), about),
-						    avatar = COALESCE(NULLIF($6, ''), avatar),
-						    phone_number = COALESCE(NULLIF($7, ''), phone_number),
-						    address = COALESCE(NULLIF($8, ''), address),
-						    city = COALESCE(NULLIF($9, ''), city),
-						    gender = COALESCE(NULLIF($10, ''), gender),
-						    postcode = COALESCE(NULLIF($11, 0), postcode),
-						    birthday = COALESCE(NULLIF($12, '')::date, birthday),
+						    about = COALESCE(NULLIF($4, ''), about),
+						    phone_number = COALESCE(NULLIF($5, ''), phone_number),
+						    address = COALESCE(NULLIF($6, ''), address),
+						    city = COALESCE(NULLIF($7, ''), city),
+						    gender = COALESCE(NULLIF($8, ''), gender),
+						    postcode = COALESCE(NULLIF($9, 0), postcode),
+						    birthday = COALESCE(NULLIF($10, '')::date, birthday),
+						    email_verified_at = CASE WHEN NULLIF($3, '') IS NULL OR $3 = email THEN email_verified_at END,
 						    updated_at = now()
-						WHERE user_id = $13
+						WHERE user_id = $11
 						RETURNING *
 						`
 
+	updateAvatarsQuery = `UPDATE users SET avatars = $1, updated_at = now() WHERE user_id = $2 RETURNING *`
+
+	updateUserRoleQuery = `UPDATE users SET role = $1, updated_at = now() WHERE user_id = $2 RETURNING *`
+
 	deleteUserQuery = `DELETE FROM users WHERE user_id = $1`
 
-	getUserQuery = `SELECT user_id, first_name, last_name, email, role, about, avatar, phone_number, 
-       				 address, city, gender, postcode, birthday, created_at, updated_at, login_date  
+	getUserQuery = `SELECT user_id, first_name, last_name, email, role, about, avatars, phone_number, 
+       				 address, city, gender, postcode, birthday, created_at, updated_at, login_date, email_verified_at,
+       				 totp_secret, totp_enabled_at
 					 FROM users 
 					 WHERE user_id = $1`
 
 	getTotalCount = `SELECT COUNT(user_id) FROM users 
 						WHERE first_name ILIKE '%' || $1 || '%' or last_name ILIKE '%' || $1 || '%'`
 
-	findUsers = `SELECT user_id, first_name, last_name, email, role, about, avatar, phone_number, address,
-	              city, gender, postcode, birthday, created_at, updated_at, login_date 
+	findUsers = `SELECT user_id, first_name, last_name, email, role, about, avatars, phone_number, address,
+	              city, gender, postcode, birthday, created_at, updated_at, login_date, email_verified_at
 				  FROM users 
 				  WHERE first_name ILIKE '%' || $1 || '%' or last_name ILIKE '%' || $1 || '%'
 				  ORDER BY first_name, last_name
@@ -44,13 +48,52 @@ const (
 
 	getTotal = `SELECT COUNT(user_id) FROM users`
 
-	getUsers = `SELECT user_id, first_name, last_name, email, role, about, avatar, phone_number, 
-       			 address, city, gender, postcode, birthday, created_at, updated_at, login_date
+	getUsers = `SELECT user_id, first_name, last_name, email, role, about, avatars, phone_number, 
+       			 address, city, gender, postcode, birthday, created_at, updated_at, login_date, email_verified_at
 				 FROM users 
 				 ORDER BY COALESCE(NULLIF($1, ''), first_name) OFFSET $2 LIMIT $3`
 
-	findUserByEmail = `SELECT user_id, first_name, last_name, email, role, about, avatar, phone_number, 
-       			 		address, city, gender, postcode, birthday, created_at, updated_at, login_date, password
+	findUserByEmail = `SELECT user_id, first_name, last_name, email, role, about, avatars, phone_number, 
+       			 		address, city, gender, postcode, birthday, created_at, updated_at, login_date, email_verified_at, 
+       			 		totp_secret, totp_enabled_at, password
 				 		FROM users 
 				 		WHERE email = $1`
+
+	updatePasswordQuery = `UPDATE users SET password = $1, updated_at = now() WHERE user_id = $2`
+
+	verifyEmailQuery = `UPDATE users 
+						SET email_verified_at = COALESCE(email_verified_at, now()), updated_at = now() 
+						WHERE user_id = $1 
+						RETURNING *`
+
+	setTOTPSecretQuery = `UPDATE users SET totp_secret = $1, updated_at = now() WHERE user_id = $2 AND totp_enabled_at IS NULL`
+
+	enableTOTPQuery = `UPDATE users SET totp_enabled_at = now(), updated_at = now() 
+						WHERE user_id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL`
+
+	disableTOTPQuery = `UPDATE users SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = now() WHERE user_id = $1`
+
+	deleteRecoveryCodesQuery = `DELETE FROM user_recovery_codes WHERE user_id = $1`
+
+	createRecoveryCodeQuery = `INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2)`
+
+	useRecoveryCodeQuery = `UPDATE user_recovery_codes SET used_at = now() 
+							WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	getPrivacyQuery = `SELECT show_email, show_phone_number, show_address, show_location, show_gender, show_birthday 
+						FROM user_privacy 
+						WHERE user_id = $1`
+
+	getPrivacyByUserIDsQuery = `SELECT user_id, show_email, show_phone_number, show_address, show_location, show_gender, show_birthday 
+						FROM user_privacy 
+						WHERE user_id = ANY(string_to_array($1, ',')::uuid[])`
+
+	upsertPrivacyQuery = `INSERT INTO user_privacy (user_id, show_email, show_phone_number, show_address, show_location, 
+						show_gender, show_birthday, updated_at) 
+						VALUES ($1, $2, $3, $4, $5, $6, $7, now()) 
+						ON CONFLICT (user_id) DO UPDATE 
+						SET show_email = EXCLUDED.show_email, show_phone_number = EXCLUDED.show_phone_number, 
+						show_address = EXCLUDED.show_address, show_location = EXCLUDED.show_location, 
+						show_gender = EXCLUDED.show_gender, show_birthday = EXCLUDED.show_birthday, updated_at = now() 
+						RETURNING show_email, show_phone_number, show_address, show_location, show_gender, show_birthday`
 )
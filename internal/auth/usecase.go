@@ -12,12 +12,31 @@ import (
 
 // Auth repository interface
 type UseCase interface {
-	Register(ctx context.Context, user *models.User) (*models.UserWithToken, error)
+	Register(ctx context.Context, user *models.User, inviteCode string) (*models.UserWithToken, error)
 	Login(ctx context.Context, user *models.User) (*models.UserWithToken, error)
 	Update(ctx context.Context, user *models.User) (*models.User, error)
+	UpdateRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error)
 	Delete(ctx context.Context, userID uuid.UUID) error
 	GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	FindByName(ctx context.Context, name string, query *utils.PaginationQuery) (*models.UsersList, error)
 	GetUsers(ctx context.Context, pq *utils.PaginationQuery) (*models.UsersList, error)
 	UploadAvatar(ctx context.Context, userID uuid.UUID, file models.UploadInput) (*models.User, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*models.UserWithToken, error)
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
+	VerifyEmail(ctx context.Context, token string) (*models.User, error)
+	ResendVerificationEmail(ctx context.Context, userID uuid.UUID) error
+	LoginTwoFactor(ctx context.Context, challenge string, code string) (*models.UserWithToken, error)
+	LoginExternal(ctx context.Context, userID uuid.UUID) (*models.UserWithToken, error)
+	UnlockLogin(ctx context.Context, userID uuid.UUID) error
+	ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword string, newPassword string) error
+	EnrollTwoFactor(ctx context.Context, userID uuid.UUID) (*models.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) (*models.RecoveryCodes, error)
+	DisableTwoFactor(ctx context.Context, userID uuid.UUID, password string, code string) error
+	GetPrivacy(ctx context.Context, userID uuid.UUID) (*models.PrivacySettings, error)
+	UpdatePrivacy(ctx context.Context, userID uuid.UUID, privacy *models.PrivacySettings) (*models.PrivacySettings, error)
+	ProjectUser(ctx context.Context, viewer *models.User, user *models.User) (interface{}, error)
+	ProjectUsers(ctx context.Context, viewer *models.User, users []*models.User) ([]interface{}, error)
 }
@@ -1,9 +1,16 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
+	"io/ioutil"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/opentracing/opentracing-go"
@@ -11,33 +18,99 @@ import (
 
 	"github.com/AleksK1NG/api-mc/config"
 	"github.com/AleksK1NG/api-mc/internal/auth"
+	"github.com/AleksK1NG/api-mc/internal/invites"
 	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/moderation"
+	"github.com/AleksK1NG/api-mc/internal/session"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
 	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/imaging"
+	"github.com/AleksK1NG/api-mc/pkg/jwtkeys"
 	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/mailer"
+	"github.com/AleksK1NG/api-mc/pkg/passwords"
+	"github.com/AleksK1NG/api-mc/pkg/totp"
 	"github.com/AleksK1NG/api-mc/pkg/utils"
 )
 
 const (
-	basePrefix    = "api-auth:"
-	cacheDuration = 3600
+	basePrefix               = "api-auth:"
+	refreshTokenPrefix       = "api-auth-refresh:"
+	refreshTokenUsedPrefix   = "api-auth-refresh-used:"
+	refreshTokenFamilyPrefix = "api-auth-refresh-family:"
+	refreshTokenUserPrefix   = "api-auth-refresh-user:"
+	passwordResetPrefix      = "api-auth-password-reset:"
+	twoFactorPrefix          = "api-auth-2fa-challenge:"
+	twoFactorAttemptsPrefix  = "api-auth-2fa-attempts:"
+	twoFactorStepPrefix      = "api-auth-2fa-step:"
+	cacheDuration            = 3600
+	defaultRefreshExpire     = 60 * 60 * 24 * 30
+	defaultResetExpire       = 60 * 60
+	resetTokenBytes          = 32
+	defaultChallengeExpire   = 60 * 5
+	defaultTOTPIssuer        = "api-mc"
+	maxTwoFactorAttempts     = 5
+	recoveryCodesCount       = 10
+	loginFailuresPrefix      = "api-auth-login-failures:"
+	loginLockPrefix          = "api-auth-login-lock:"
+	defaultMaxEmailAttempts  = 10
+	defaultMaxIPAttempts     = 50
+	defaultAttemptsWindow    = 60 * 15
+	defaultLockoutDuration   = 60 * 15
+	defaultDelayAfter        = 3
+	defaultBaseDelay         = 1
+	defaultMaxDelay          = 60
+	avatarContentType        = "image/jpeg"
 )
 
+// Avatar sizes in pixels
+var avatarSizes = []int{64, 256, 512}
+
 // Auth UseCase
 type authUC struct {
-	cfg       *config.Config
-	authRepo  auth.Repository
-	redisRepo auth.RedisRepository
-	awsRepo   auth.AWSRepository
-	logger    logger.Logger
+	cfg          *config.Config
+	authRepo     auth.Repository
+	redisRepo    auth.RedisRepository
+	awsRepo      auth.AWSRepository
+	sessUC       session.UCSession
+	moderationUC moderation.UseCase
+	invitesUC    invites.UseCase
+	mailer       mailer.Mailer
+	jwtKeys      *jwtkeys.KeySet
+	hasher       *passwords.Hasher
+	logger       logger.Logger
 }
 
 // Auth UseCase constructor
-func NewAuthUseCase(cfg *config.Config, authRepo auth.Repository, redisRepo auth.RedisRepository, awsRepo auth.AWSRepository, log logger.Logger) auth.UseCase {
-	return &authUC{cfg: cfg, authRepo: authRepo, redisRepo: redisRepo, awsRepo: awsRepo, logger: log}
+func NewAuthUseCase(
+	cfg *config.Config,
+	authRepo auth.Repository,
+	redisRepo auth.RedisRepository,
+	awsRepo auth.AWSRepository,
+	sessUC session.UCSession,
+	moderationUC moderation.UseCase,
+	invitesUC invites.UseCase,
+	mailer mailer.Mailer,
+	jwtKeys *jwtkeys.KeySet,
+	log logger.Logger,
+) auth.UseCase {
+	return &authUC{
+		cfg:          cfg,
+		authRepo:     authRepo,
+		redisRepo:    redisRepo,
+		awsRepo:      awsRepo,
+		sessUC:       sessUC,
+		moderationUC: moderationUC,
+		invitesUC:    invitesUC,
+		mailer:       mailer,
+		jwtKeys:      jwtKeys,
+		hasher:       passwords.NewHasher(passwords.Params(cfg.PasswordHash)),
+		logger:       log,
+	}
 }
 
-// Create new user
-func (u *authUC) Register(ctx context.Context, user *models.User) (*models.UserWithToken, error) {
+// Create new user, invite code is required and its role is assigned in invite only mode
+func (u *authUC) Register(ctx context.Context, user *models.User, inviteCode string) (*models.UserWithToken, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.Register")
 	defer span.Finish()
 
@@ -46,25 +119,54 @@ func (u *authUC) Register(ctx context.Context, user *models.User) (*models.UserW
 		return nil, httpErrors.NewRestErrorWithMessage(http.StatusBadRequest, httpErrors.ErrEmailAlreadyExists, nil)
 	}
 
-	if err = user.PrepareCreate(); err != nil {
+	if err = u.getPasswordPolicy().Validate(strings.TrimSpace(user.Password), user.Email, user.FirstName, user.LastName); err != nil {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil)
+	}
+
+	if err = user.PrepareCreate(u.hasher); err != nil {
 		return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "authUC.Register.PrepareCreate"))
 	}
 
+	var invite *models.Invite
+	if u.cfg.Auth.InviteOnly {
+		invite, err = u.invitesUC.Redeem(ctx, inviteCode)
+		if err != nil {
+			return nil, err
+		}
+		user.Role = invite.Role
+	}
+
 	createdUser, err := u.authRepo.Register(ctx, user)
 	if err != nil {
+		if invite != nil {
+			u.releaseInvite(ctx, invite)
+		}
 		return nil, err
 	}
 	createdUser.SanitizePassword()
 
-	token, err := utils.GenerateJWTToken(createdUser, u.cfg)
-	if err != nil {
-		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.Register.GenerateJWTToken"))
+	// Every invited user must be linked to its invite, user is removed and invite use is given back otherwise
+	if invite != nil {
+		if err = u.invitesUC.RecordRedemption(ctx, invite.InviteID, createdUser.UserID); err != nil {
+			if delErr := u.authRepo.Delete(ctx, createdUser.UserID); delErr != nil {
+				u.logger.Errorf("authUC.Register.Delete: %s", delErr)
+			}
+			u.releaseInvite(ctx, invite)
+			return nil, err
+		}
 	}
 
-	return &models.UserWithToken{
-		User:  createdUser,
-		Token: token,
-	}, nil
+	if err = u.sendVerificationEmail(ctx, createdUser); err != nil {
+		u.logger.Errorf("authUC.Register.sendVerificationEmail: %s", err)
+	}
+
+	return u.generateUserWithTokens(ctx, createdUser, "")
+}
+
+func (u *authUC) releaseInvite(ctx context.Context, invite *models.Invite) {
+	if err := u.invitesUC.Release(ctx, invite.InviteID); err != nil {
+		u.logger.Errorf("authUC.Register.Release: %s", err)
+	}
 }
 
 // Update existing user
@@ -92,19 +194,53 @@ func (u *authUC) Update(ctx context.Context, user *models.User) (*models.User, e
 	return updatedUser, nil
 }
 
+// Assign role to user, role is never taken from register or update payload
+func (u *authUC) UpdateRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.UpdateRole")
+	defer span.Finish()
+
+	updatedUser, err := u.authRepo.UpdateRole(ctx, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedUser.SanitizePassword()
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.GenerateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("AuthUC.UpdateRole.DeleteUserCtx: %s", err)
+	}
+
+	return updatedUser, nil
+}
+
 // Delete new user
 func (u *authUC) Delete(ctx context.Context, userID uuid.UUID) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.Delete")
 	defer span.Finish()
 
-	if err := u.authRepo.Delete(ctx, userID); err != nil {
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
 		return err
 	}
 
-	if err := u.redisRepo.DeleteUserCtx(ctx, u.GenerateUserKey(userID.String())); err != nil {
+	if err = u.authRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	u.removeAvatars(ctx, user.Avatars)
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.GenerateUserKey(userID.String())); err != nil {
 		u.logger.Errorf("AuthUC.Delete.DeleteUserCtx: %s", err)
 	}
 
+	if err = u.sessUC.RevokeUserSessions(ctx, userID, ""); err != nil {
+		u.logger.Errorf("AuthUC.Delete.RevokeUserSessions: %s", err)
+	}
+
+	if err = u.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		u.logger.Errorf("AuthUC.Delete.RevokeUserRefreshTokens: %s", err)
+	}
+
 	return nil
 }
 
@@ -151,62 +287,1014 @@ func (u *authUC) GetUsers(ctx context.Context, pq *utils.PaginationQuery) (*mode
 	return u.authRepo.GetUsers(ctx, pq)
 }
 
+// Get privacy settings of user, defaults hide all optional fields
+func (u *authUC) GetPrivacy(ctx context.Context, userID uuid.UUID) (*models.PrivacySettings, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.GetPrivacy")
+	defer span.Finish()
+
+	privacy, err := u.authRepo.GetPrivacy(ctx, userID)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return &models.PrivacySettings{}, nil
+		}
+		return nil, err
+	}
+
+	return privacy, nil
+}
+
+// Update privacy settings of user
+func (u *authUC) UpdatePrivacy(
+	ctx context.Context,
+	userID uuid.UUID,
+	privacy *models.PrivacySettings,
+) (*models.PrivacySettings, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.UpdatePrivacy")
+	defer span.Finish()
+
+	return u.authRepo.UpdatePrivacy(ctx, userID, privacy)
+}
+
+// Project user for viewer, see ProjectUsers
+func (u *authUC) ProjectUser(ctx context.Context, viewer *models.User, user *models.User) (interface{}, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ProjectUser")
+	defer span.Finish()
+
+	projected, err := u.ProjectUsers(ctx, viewer, []*models.User{user})
+	if err != nil {
+		return nil, err
+	}
+
+	return projected[0], nil
+}
+
+// Project users for viewer: admin projection for users with users:read:private permission,
+// own projection of viewer itself and public profile following privacy settings for everyone else, viewer may be nil
+func (u *authUC) ProjectUsers(ctx context.Context, viewer *models.User, users []*models.User) ([]interface{}, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ProjectUsers")
+	defer span.Finish()
+
+	userIDs := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.UserID)
+	}
+
+	privacy, err := u.authRepo.GetPrivacyByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	isAdmin := viewer != nil && utils.HasPermission(ctx, models.PermissionUsersReadPrivate)
+
+	projected := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		switch {
+		case isAdmin:
+			projected = append(projected, user.Admin(privacy[user.UserID]))
+		case viewer != nil && viewer.UserID == user.UserID:
+			projected = append(projected, user.Self(privacy[user.UserID]))
+		default:
+			projected = append(projected, user.Public(privacy[user.UserID]))
+		}
+	}
+
+	return projected, nil
+}
+
 // Login user, returns user model with jwt token
 func (u *authUC) Login(ctx context.Context, user *models.User) (*models.UserWithToken, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.Login")
 	defer span.Finish()
 
+	emailID := u.generateLoginEmailID(user.Email)
+	ipID := u.generateLoginIPID(utils.GetIPFromCtx(ctx))
+
+	if err := u.checkLoginLock(ctx, emailID, ipID); err != nil {
+		return nil, err
+	}
+
 	foundUser, err := u.authRepo.FindByEmail(ctx, user)
 	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			if lockErr := u.registerLoginFailure(ctx, emailID, ipID); lockErr != nil {
+				return nil, lockErr
+			}
+		}
 		return nil, err
 	}
 
 	if err = foundUser.ComparePasswords(user.Password); err != nil {
+		if lockErr := u.registerLoginFailure(ctx, emailID, ipID); lockErr != nil {
+			return nil, lockErr
+		}
 		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.GetUsers.ComparePasswords"))
 	}
 
+	u.rehashPassword(ctx, foundUser, user.Password)
 	foundUser.SanitizePassword()
 
-	token, err := utils.GenerateJWTToken(foundUser, u.cfg)
-	if err != nil {
-		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.GetUsers.GenerateJWTToken"))
+	if err = u.moderationUC.CheckAccount(ctx, foundUser.UserID); err != nil {
+		return nil, err
 	}
 
-	return &models.UserWithToken{
-		User:  foundUser,
-		Token: token,
-	}, nil
+	// Failures are kept until second factor passes, so failed codes count towards the same lockout
+	if foundUser.TwoFactorEnabled() {
+		return u.createTwoFactorChallenge(ctx, foundUser)
+	}
+
+	u.resetLoginFailures(ctx, emailID)
+
+	return u.generateUserWithTokens(ctx, foundUser, "")
 }
 
-// Upload user avatar
+// Upload user avatar, image is re-encoded without metadata, oriented, cropped to square and stored in every avatar size
 func (u *authUC) UploadAvatar(ctx context.Context, userID uuid.UUID, file models.UploadInput) (*models.User, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.UploadAvatar")
 	defer span.Finish()
 
-	uploadInfo, err := u.awsRepo.PutObject(ctx, file)
+	user, err := u.authRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.UploadAvatar.PutObject"))
+		return nil, err
 	}
 
-	avatarURL := u.generateAWSMinioURL(file.BucketName, uploadInfo.Key)
+	data, err := ioutil.ReadAll(file.File)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.UploadAvatar.ReadAll"))
+	}
 
-	updatedUser, err := u.authRepo.Update(ctx, &models.User{
-		UserID: userID,
-		Avatar: &avatarURL,
-	})
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidImage.Error(), errors.Wrap(err, "authUC.UploadAvatar.Decode"))
+	}
+	square := imaging.CropSquare(img)
+
+	version := strconv.FormatInt(time.Now().UnixNano(), 10)
+	avatars := make(models.Avatars, len(avatarSizes))
+	for _, size := range avatarSizes {
+		encoded, err := imaging.EncodeJPEG(imaging.Resize(square, size, size))
+		if err != nil {
+			return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.UploadAvatar.EncodeJPEG"))
+		}
+
+		objectName, err := u.awsRepo.PutObject(ctx, models.UploadInput{
+			File:        bytes.NewReader(encoded),
+			Name:        file.Name,
+			ObjectName:  avatarObjectName(userID, version, size),
+			Size:        int64(len(encoded)),
+			ContentType: avatarContentType,
+			BucketName:  file.BucketName,
+		})
+		if err != nil {
+			return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.UploadAvatar.PutObject"))
+		}
+
+		avatars[strconv.Itoa(size)] = blobstore.ObjectURL(u.cfg, file.BucketName, objectName)
+	}
+
+	updatedUser, err := u.authRepo.UpdateAvatars(ctx, userID, avatars)
 	if err != nil {
 		return nil, err
 	}
 
+	// Every upload has new keys, so previous objects are never referenced again
+	u.removeAvatars(ctx, user.Avatars)
+
 	updatedUser.SanitizePassword()
 
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.GenerateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("AuthUC.UploadAvatar.DeleteUserCtx: %s", err)
+	}
+
 	return updatedUser, nil
 }
 
+// Exchange refresh token for a new token pair, reused tokens revoke the whole family
+func (u *authUC) RefreshToken(ctx context.Context, refreshToken string) (*models.UserWithToken, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.RefreshToken")
+	defer span.Finish()
+
+	tokenHash := utils.HashToken(refreshToken)
+	storedToken, err := u.redisRepo.GetRefreshTokenCtx(ctx, u.generateRefreshTokenKey(tokenHash))
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.RefreshToken.GetRefreshTokenCtx"))
+	}
+
+	firstUse, err := u.redisRepo.MarkRefreshTokenUsedCtx(ctx, u.generateRefreshTokenUsedKey(tokenHash), u.getRefreshTokenExpire())
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.RefreshToken.MarkRefreshTokenUsedCtx"))
+	}
+	if !firstUse {
+		u.logger.Errorf("authUC.RefreshToken reused refresh token, UserID: %s, FamilyID: %s", storedToken.UserID, storedToken.FamilyID)
+		if err = u.redisRepo.DeleteRefreshFamilyCtx(ctx, u.generateRefreshTokenFamilyKey(storedToken.FamilyID)); err != nil {
+			u.logger.Errorf("authUC.RefreshToken.DeleteRefreshFamilyCtx: %s", err)
+		}
+		return nil, httpErrors.NewUnauthorizedError(httpErrors.InvalidRefreshToken)
+	}
+
+	user, err := u.GetByID(ctx, storedToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.moderationUC.CheckAccount(ctx, user.UserID); err != nil {
+		return nil, err
+	}
+
+	return u.generateUserWithTokens(ctx, user, storedToken.FamilyID)
+}
+
+// Revoke refresh token with the whole family
+func (u *authUC) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.RevokeRefreshToken")
+	defer span.Finish()
+
+	storedToken, err := u.redisRepo.GetRefreshTokenCtx(ctx, u.generateRefreshTokenKey(utils.HashToken(refreshToken)))
+	if err != nil {
+		u.logger.Errorf("authUC.RevokeRefreshToken.GetRefreshTokenCtx: %s", err)
+		return nil
+	}
+
+	return u.redisRepo.DeleteRefreshFamilyCtx(ctx, u.generateRefreshTokenFamilyKey(storedToken.FamilyID))
+}
+
+// Send single use password reset link, unknown emails are ignored to not disclose registered users
+func (u *authUC) ForgotPassword(ctx context.Context, email string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ForgotPassword")
+	defer span.Finish()
+
+	foundUser, err := u.authRepo.FindByEmail(ctx, &models.User{Email: strings.ToLower(strings.TrimSpace(email))})
+	if err != nil {
+		u.logger.Errorf("authUC.ForgotPassword.FindByEmail: %s", err)
+		return nil
+	}
+
+	token, err := utils.GenerateRandomToken(resetTokenBytes)
+	if err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ForgotPassword.GenerateRandomToken"))
+	}
+
+	expire := u.getPasswordResetExpire()
+	if err = u.redisRepo.SetPasswordResetCtx(ctx, u.generatePasswordResetKey(utils.HashToken(token)), expire, foundUser.UserID); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ForgotPassword.SetPasswordResetCtx"))
+	}
+
+	if err = u.mailer.Send(ctx, &mailer.Message{
+		To:      []string{foundUser.Email},
+		Subject: "Password reset",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nUse the link below to set a new password, it is valid for %d minutes:\n\n%s?token=%s\n\nIf you did not request a password reset, ignore this email.\n",
+			foundUser.FirstName,
+			expire/60,
+			u.cfg.Auth.PasswordResetURL,
+			token,
+		),
+	}); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ForgotPassword.Send"))
+	}
+
+	return nil
+}
+
+// Revoke all refresh token families of user
+func (u *authUC) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.RevokeUserRefreshTokens")
+	defer span.Finish()
+
+	return u.redisRepo.DeleteUserRefreshFamiliesCtx(ctx, u.generateRefreshTokenUserKey(userID.String()))
+}
+
+// Consume password reset token and set new password
+func (u *authUC) ResetPassword(ctx context.Context, token string, password string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ResetPassword")
+	defer span.Finish()
+
+	resetKey := u.generatePasswordResetKey(utils.HashToken(token))
+	userID, err := u.redisRepo.GetPasswordResetCtx(ctx, resetKey)
+	if err != nil {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidResetToken.Error(), errors.Wrap(err, "authUC.ResetPassword.GetPasswordResetCtx"))
+	}
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Token is consumed only by valid password, so user can retry with the same link
+	password = strings.TrimSpace(password)
+	if err = u.getPasswordPolicy().Validate(password, user.Email, user.FirstName, user.LastName); err != nil {
+		return httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil)
+	}
+
+	poppedUserID, err := u.redisRepo.PopPasswordResetCtx(ctx, resetKey)
+	if err != nil || poppedUserID != userID {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidResetToken.Error(), errors.Wrap(err, "authUC.ResetPassword.PopPasswordResetCtx"))
+	}
+
+	updatedUser := &models.User{UserID: userID, Password: password}
+	if err = updatedUser.HashPassword(u.hasher); err != nil {
+		return httpErrors.NewBadRequestError(errors.Wrap(err, "authUC.ResetPassword.HashPassword"))
+	}
+
+	if err = u.authRepo.UpdatePassword(ctx, userID, updatedUser.Password); err != nil {
+		return err
+	}
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.GenerateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("AuthUC.ResetPassword.DeleteUserCtx: %s", err)
+	}
+
+	// Sessions opened with old password could be stolen ones
+	if err = u.sessUC.RevokeUserSessions(ctx, userID, ""); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ResetPassword.RevokeUserSessions"))
+	}
+
+	if err = u.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ResetPassword.RevokeUserRefreshTokens"))
+	}
+
+	return nil
+}
+
+// Change password of logged in user, all user sessions are revoked
+func (u *authUC) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword string, newPassword string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ChangePassword")
+	defer span.Finish()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	foundUser, err := u.authRepo.FindByEmail(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if err = foundUser.ComparePasswords(currentPassword); err != nil {
+		return httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.ChangePassword.ComparePasswords"))
+	}
+
+	newPassword = strings.TrimSpace(newPassword)
+	if foundUser.ComparePasswords(newPassword) == nil {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.SamePassword.Error(), nil)
+	}
+
+	if err = u.getPasswordPolicy().Validate(newPassword, user.Email, user.FirstName, user.LastName); err != nil {
+		return httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil)
+	}
+
+	updatedUser := &models.User{UserID: userID, Password: newPassword}
+	if err = updatedUser.HashPassword(u.hasher); err != nil {
+		return httpErrors.NewBadRequestError(errors.Wrap(err, "authUC.ChangePassword.HashPassword"))
+	}
+
+	if err = u.authRepo.UpdatePassword(ctx, userID, updatedUser.Password); err != nil {
+		return err
+	}
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.GenerateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("AuthUC.ChangePassword.DeleteUserCtx: %s", err)
+	}
+
+	if err = u.sessUC.RevokeUserSessions(ctx, userID, ""); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ChangePassword.RevokeUserSessions"))
+	}
+
+	if err = u.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ChangePassword.RevokeUserRefreshTokens"))
+	}
+
+	return nil
+}
+
+// Confirm user email by signed verification token
+func (u *authUC) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.VerifyEmail")
+	defer span.Finish()
+
+	claims, err := utils.ParseEmailVerificationToken(token, u.jwtKeys)
+	if err != nil {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidVerifyToken.Error(), errors.Wrap(err, "authUC.VerifyEmail.ParseEmailVerificationToken"))
+	}
+
+	userID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidVerifyToken.Error(), errors.Wrap(err, "authUC.VerifyEmail.Parse"))
+	}
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Link was issued for an address the user has since changed
+	if user.Email != claims.Email {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidVerifyToken.Error(), nil)
+	}
+
+	verifiedUser, err := u.authRepo.VerifyEmail(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.GenerateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("AuthUC.VerifyEmail.DeleteUserCtx: %s", err)
+	}
+
+	verifiedUser.SanitizePassword()
+
+	return verifiedUser, nil
+}
+
+// Send new email verification link to the user
+func (u *authUC) ResendVerificationEmail(ctx context.Context, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ResendVerificationEmail")
+	defer span.Finish()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.EmailAlreadyVerified.Error(), nil)
+	}
+
+	if err = u.sendVerificationEmail(ctx, user); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ResendVerificationEmail.sendVerificationEmail"))
+	}
+
+	return nil
+}
+
+func (u *authUC) sendVerificationEmail(ctx context.Context, user *models.User) error {
+	token, err := utils.GenerateEmailVerificationToken(user, u.cfg, u.jwtKeys)
+	if err != nil {
+		return errors.Wrap(err, "authUC.sendVerificationEmail.GenerateEmailVerificationToken")
+	}
+
+	return u.mailer.Send(ctx, &mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nPlease confirm your email address, the link is valid for %d hours:\n\n%s?token=%s\n",
+			user.FirstName,
+			utils.GetEmailVerificationExpire(u.cfg)/3600,
+			u.cfg.Auth.EmailVerificationURL,
+			token,
+		),
+	})
+}
+
+// Finish login started with password using totp or recovery code
+func (u *authUC) LoginTwoFactor(ctx context.Context, challenge string, code string) (*models.UserWithToken, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.LoginTwoFactor")
+	defer span.Finish()
+
+	challengeHash := utils.HashToken(challenge)
+	challengeKey := u.generateTwoFactorKey(challengeHash)
+	attemptsKey := u.generateTwoFactorAttemptsKey(challengeHash)
+
+	userID, err := u.redisRepo.GetTwoFactorChallengeCtx(ctx, challengeKey)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.LoginTwoFactor.GetTwoFactorChallengeCtx"))
+	}
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failed codes are counted per user like failed passwords, new challenges do not give new attempts
+	emailID := u.generateLoginEmailID(user.Email)
+	ipID := u.generateLoginIPID(utils.GetIPFromCtx(ctx))
+	if err = u.checkLoginLock(ctx, emailID, ipID); err != nil {
+		return nil, err
+	}
+
+	if !user.TwoFactorEnabled() || !u.checkTwoFactorCode(ctx, user, code) {
+		lockErr := u.registerLoginFailure(ctx, emailID, ipID)
+		attempts, err := u.redisRepo.IncrTwoFactorAttemptsCtx(ctx, attemptsKey, u.getChallengeExpire())
+		if err != nil {
+			u.logger.Errorf("authUC.LoginTwoFactor.IncrTwoFactorAttemptsCtx: %s", err)
+		}
+		if lockErr != nil || err != nil || attempts >= maxTwoFactorAttempts {
+			if err = u.redisRepo.DeleteTwoFactorChallengeCtx(ctx, challengeKey, attemptsKey); err != nil {
+				u.logger.Errorf("authUC.LoginTwoFactor.DeleteTwoFactorChallengeCtx: %s", err)
+			}
+		}
+		if lockErr != nil {
+			return nil, lockErr
+		}
+		return nil, httpErrors.NewUnauthorizedError(httpErrors.InvalidTwoFactorCode)
+	}
+
+	if err = u.redisRepo.DeleteTwoFactorChallengeCtx(ctx, challengeKey, attemptsKey); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.LoginTwoFactor.DeleteTwoFactorChallengeCtx"))
+	}
+
+	if err = u.moderationUC.CheckAccount(ctx, user.UserID); err != nil {
+		return nil, err
+	}
+
+	u.resetLoginFailures(ctx, emailID)
+	user.SanitizePassword()
+
+	return u.generateUserWithTokens(ctx, user, "")
+}
+
+// Login user already authenticated by external identity provider, two factor authentication still applies
+func (u *authUC) LoginExternal(ctx context.Context, userID uuid.UUID) (*models.UserWithToken, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.LoginExternal")
+	defer span.Finish()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.SanitizePassword()
+
+	if err = u.moderationUC.CheckAccount(ctx, user.UserID); err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled() {
+		return u.createTwoFactorChallenge(ctx, user)
+	}
+
+	return u.generateUserWithTokens(ctx, user, "")
+}
+
+// Start two factor enrollment, returns secret and otpauth uri for authenticator apps
+func (u *authUC) EnrollTwoFactor(ctx context.Context, userID uuid.UUID) (*models.TwoFactorEnrollment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.EnrollTwoFactor")
+	defer span.Finish()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled() {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.TwoFactorEnabled.Error(), nil)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.EnrollTwoFactor.GenerateSecret"))
+	}
+
+	if err = u.authRepo.SetTOTPSecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &models.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totp.URI(u.getTOTPIssuer(), user.Email, secret),
+	}, nil
+}
+
+// Confirm two factor enrollment with first valid code, returns single use recovery codes
+func (u *authUC) ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) (*models.RecoveryCodes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ConfirmTwoFactor")
+	defer span.Finish()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled() {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.TwoFactorEnabled.Error(), nil)
+	}
+	if user.TOTPSecret == nil {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.TwoFactorNotEnrolled.Error(), nil)
+	}
+	if !u.acceptTOTPCode(ctx, user, code) {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidTwoFactorCode.Error(), nil)
+	}
+
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		recoveryCode, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ConfirmTwoFactor.GenerateRecoveryCode"))
+		}
+		codes = append(codes, recoveryCode)
+		hashes = append(hashes, utils.HashToken(normalizeRecoveryCode(recoveryCode)))
+	}
+
+	if err = u.authRepo.EnableTOTP(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.GenerateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("AuthUC.ConfirmTwoFactor.DeleteUserCtx: %s", err)
+	}
+
+	return &models.RecoveryCodes{Codes: codes}, nil
+}
+
+// Disable two factor authentication, requires current password and valid code
+func (u *authUC) DisableTwoFactor(ctx context.Context, userID uuid.UUID, password string, code string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.DisableTwoFactor")
+	defer span.Finish()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TwoFactorEnabled() {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.TwoFactorNotEnabled.Error(), nil)
+	}
+
+	foundUser, err := u.authRepo.FindByEmail(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if err = foundUser.ComparePasswords(password); err != nil {
+		return httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.DisableTwoFactor.ComparePasswords"))
+	}
+
+	if !u.checkTwoFactorCode(ctx, user, code) {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidTwoFactorCode.Error(), nil)
+	}
+
+	if err = u.authRepo.DisableTOTP(ctx, userID); err != nil {
+		return err
+	}
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.GenerateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("AuthUC.DisableTwoFactor.DeleteUserCtx: %s", err)
+	}
+
+	return nil
+}
+
+// Remove failed login counters and lock of user email
+func (u *authUC) UnlockLogin(ctx context.Context, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.UnlockLogin")
+	defer span.Finish()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	emailID := u.generateLoginEmailID(user.Email)
+	if err = u.redisRepo.DeleteLoginFailuresCtx(
+		ctx,
+		u.generateLoginFailuresKey(emailID),
+		u.generateLoginLockKey(emailID),
+	); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.UnlockLogin.DeleteLoginFailuresCtx"))
+	}
+
+	return nil
+}
+
+// Return too many requests error while email or ip is locked
+func (u *authUC) checkLoginLock(ctx context.Context, ids ...string) error {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			keys = append(keys, u.generateLoginLockKey(id))
+		}
+	}
+
+	lock, err := u.redisRepo.GetLoginLockCtx(ctx, keys...)
+	if err != nil {
+		u.logger.Errorf("authUC.checkLoginLock.GetLoginLockCtx: %s", err)
+		return nil
+	}
+	if lock > 0 {
+		return httpErrors.NewTooManyRequestsError(int(math.Ceil(lock.Seconds())), httpErrors.TooManyLoginAttempts)
+	}
+
+	return nil
+}
+
+// Count failed login, email gets progressive delay and lockout, ip gets lockout only
+func (u *authUC) registerLoginFailure(ctx context.Context, emailID string, ipID string) error {
+	throttle := u.getLoginThrottle()
+
+	var lockSeconds int
+	failures, err := u.redisRepo.IncrLoginFailuresCtx(ctx, u.generateLoginFailuresKey(emailID), throttle.AttemptsWindow)
+	if err != nil {
+		u.logger.Errorf("authUC.registerLoginFailure.IncrLoginFailuresCtx: %s", err)
+		return nil
+	}
+	switch {
+	case failures >= int64(throttle.MaxEmailAttempts):
+		lockSeconds = throttle.LockoutDuration
+	case failures >= int64(throttle.DelayAfter):
+		lockSeconds = loginDelay(throttle, failures)
+	}
+	if lockSeconds > 0 {
+		if err = u.redisRepo.SetLoginLockCtx(ctx, u.generateLoginLockKey(emailID), lockSeconds); err != nil {
+			u.logger.Errorf("authUC.registerLoginFailure.SetLoginLockCtx: %s", err)
+		}
+	}
+
+	if ipID != "" {
+		ipFailures, err := u.redisRepo.IncrLoginFailuresCtx(ctx, u.generateLoginFailuresKey(ipID), throttle.AttemptsWindow)
+		if err != nil {
+			u.logger.Errorf("authUC.registerLoginFailure.IncrLoginFailuresCtx: %s", err)
+		} else if ipFailures >= int64(throttle.MaxIPAttempts) {
+			if err = u.redisRepo.SetLoginLockCtx(ctx, u.generateLoginLockKey(ipID), throttle.LockoutDuration); err != nil {
+				u.logger.Errorf("authUC.registerLoginFailure.SetLoginLockCtx: %s", err)
+			}
+			if throttle.LockoutDuration > lockSeconds {
+				lockSeconds = throttle.LockoutDuration
+			}
+		}
+	}
+
+	// Only lockout is reported, progressive delay applies to next attempt
+	if lockSeconds >= throttle.LockoutDuration {
+		return httpErrors.NewTooManyRequestsError(lockSeconds, httpErrors.TooManyLoginAttempts)
+	}
+
+	return nil
+}
+
+// Remove failed login counter and lock of email after successful login
+func (u *authUC) resetLoginFailures(ctx context.Context, emailID string) {
+	if err := u.redisRepo.DeleteLoginFailuresCtx(
+		ctx,
+		u.generateLoginFailuresKey(emailID),
+		u.generateLoginLockKey(emailID),
+	); err != nil {
+		u.logger.Errorf("authUC.resetLoginFailures.DeleteLoginFailuresCtx: %s", err)
+	}
+}
+
+// Exponential delay in seconds: base * 2^(failures - delayAfter), capped by max delay
+func loginDelay(throttle config.LoginThrottle, failures int64) int {
+	delay := throttle.BaseDelay
+	for i := int64(throttle.DelayAfter); i < failures; i++ {
+		delay *= 2
+		if delay >= throttle.MaxDelay {
+			return throttle.MaxDelay
+		}
+	}
+	if delay > throttle.MaxDelay {
+		return throttle.MaxDelay
+	}
+	return delay
+}
+
+func (u *authUC) getLoginThrottle() config.LoginThrottle {
+	throttle := u.cfg.LoginThrottle
+	if throttle.MaxEmailAttempts <= 0 {
+		throttle.MaxEmailAttempts = defaultMaxEmailAttempts
+	}
+	if throttle.MaxIPAttempts <= 0 {
+		throttle.MaxIPAttempts = defaultMaxIPAttempts
+	}
+	if throttle.AttemptsWindow <= 0 {
+		throttle.AttemptsWindow = defaultAttemptsWindow
+	}
+	if throttle.LockoutDuration <= 0 {
+		throttle.LockoutDuration = defaultLockoutDuration
+	}
+	if throttle.DelayAfter <= 0 {
+		throttle.DelayAfter = defaultDelayAfter
+	}
+	if throttle.BaseDelay <= 0 {
+		throttle.BaseDelay = defaultBaseDelay
+	}
+	if throttle.MaxDelay <= 0 {
+		throttle.MaxDelay = defaultMaxDelay
+	}
+	return throttle
+}
+
+func (u *authUC) generateLoginEmailID(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func (u *authUC) generateLoginIPID(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	return "ip:" + ip
+}
+
+func (u *authUC) generateLoginFailuresKey(id string) string {
+	return fmt.Sprintf("%s: %s", loginFailuresPrefix, id)
+}
+
+func (u *authUC) generateLoginLockKey(id string) string {
+	return fmt.Sprintf("%s: %s", loginLockPrefix, id)
+}
+
+// Create short lived challenge, tokens are issued only after second factor
+func (u *authUC) createTwoFactorChallenge(ctx context.Context, user *models.User) (*models.UserWithToken, error) {
+	challenge, err := utils.GenerateRandomToken(resetTokenBytes)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.createTwoFactorChallenge.GenerateRandomToken"))
+	}
+
+	if err = u.redisRepo.SetTwoFactorChallengeCtx(
+		ctx,
+		u.generateTwoFactorKey(utils.HashToken(challenge)),
+		u.getChallengeExpire(),
+		user.UserID,
+	); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.createTwoFactorChallenge.SetTwoFactorChallengeCtx"))
+	}
+
+	return &models.UserWithToken{Challenge: challenge}, nil
+}
+
+// Check totp code, falls back to single use recovery code
+func (u *authUC) checkTwoFactorCode(ctx context.Context, user *models.User, code string) bool {
+	if user.TOTPSecret == nil {
+		return false
+	}
+
+	if u.acceptTOTPCode(ctx, user, code) {
+		return true
+	}
+
+	if err := u.authRepo.UseRecoveryCode(ctx, user.UserID, utils.HashToken(normalizeRecoveryCode(code))); err != nil {
+		u.logger.Errorf("authUC.checkTwoFactorCode.UseRecoveryCode: %s", err)
+		return false
+	}
+
+	return true
+}
+
+// Check totp code and reject codes of time steps at or before the last accepted one, so a code works only once
+func (u *authUC) acceptTOTPCode(ctx context.Context, user *models.User, code string) bool {
+	step, ok := totp.ValidateStep(code, *user.TOTPSecret, time.Now())
+	if !ok {
+		return false
+	}
+
+	accepted, err := u.redisRepo.SetTwoFactorStepCtx(ctx, u.generateTwoFactorStepKey(user.UserID.String()), step, totp.Window)
+	if err != nil {
+		u.logger.Errorf("authUC.acceptTOTPCode.SetTwoFactorStepCtx: %s", err)
+		return false
+	}
+
+	return accepted
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+}
+
+// Generate access and refresh tokens, empty familyID starts a new refresh token family
+func (u *authUC) generateUserWithTokens(ctx context.Context, user *models.User, familyID string) (*models.UserWithToken, error) {
+	token, err := utils.GenerateJWTToken(user, u.cfg, u.jwtKeys)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.generateUserWithTokens.GenerateJWTToken"))
+	}
+
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.generateUserWithTokens.GenerateRefreshToken"))
+	}
+
+	if familyID == "" {
+		familyID = uuid.New().String()
+	}
+
+	expire := u.getRefreshTokenExpire()
+	if err = u.redisRepo.SetRefreshTokenCtx(
+		ctx,
+		u.generateRefreshTokenUserKey(user.UserID.String()),
+		u.generateRefreshTokenFamilyKey(familyID),
+		u.generateRefreshTokenKey(utils.HashToken(refreshToken)),
+		expire,
+		&models.RefreshToken{
+			FamilyID:  familyID,
+			UserID:    user.UserID,
+			ExpiresAt: time.Now().Add(time.Second * time.Duration(expire)),
+		},
+	); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.generateUserWithTokens.SetRefreshTokenCtx"))
+	}
+
+	return &models.UserWithToken{
+		User:         user,
+		Token:        token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (u *authUC) getRefreshTokenExpire() int {
+	if u.cfg.Server.RefreshTokenExpire <= 0 {
+		return defaultRefreshExpire
+	}
+	return u.cfg.Server.RefreshTokenExpire
+}
+
+func (u *authUC) getPasswordResetExpire() int {
+	if u.cfg.Auth.PasswordResetExpire <= 0 {
+		return defaultResetExpire
+	}
+	return u.cfg.Auth.PasswordResetExpire
+}
+
+func (u *authUC) getChallengeExpire() int {
+	if u.cfg.Auth.TwoFactorChallengeExpire <= 0 {
+		return defaultChallengeExpire
+	}
+	return u.cfg.Auth.TwoFactorChallengeExpire
+}
+
+// Upgrade password hash made with outdated algorithm or params, failure does not affect login
+func (u *authUC) rehashPassword(ctx context.Context, user *models.User, password string) {
+	if !u.hasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	rehashed := &models.User{UserID: user.UserID, Password: password}
+	if err := rehashed.HashPassword(u.hasher); err != nil {
+		u.logger.Errorf("authUC.rehashPassword.HashPassword: %s", err)
+		return
+	}
+
+	if err := u.authRepo.UpdatePassword(ctx, user.UserID, rehashed.Password); err != nil {
+		u.logger.Errorf("authUC.rehashPassword.UpdatePassword: %s", err)
+	}
+}
+
+func (u *authUC) getPasswordPolicy() passwords.Policy {
+	return passwords.Policy{
+		MinLength:      u.cfg.PasswordPolicy.MinLength,
+		MaxLength:      u.cfg.PasswordPolicy.MaxLength,
+		RequireUpper:   u.cfg.PasswordPolicy.RequireUpper,
+		RequireLower:   u.cfg.PasswordPolicy.RequireLower,
+		RequireDigit:   u.cfg.PasswordPolicy.RequireDigit,
+		RequireSpecial: u.cfg.PasswordPolicy.RequireSpecial,
+	}
+}
+
+func (u *authUC) getTOTPIssuer() string {
+	if u.cfg.Auth.TOTPIssuer == "" {
+		return defaultTOTPIssuer
+	}
+	return u.cfg.Auth.TOTPIssuer
+}
+
+func (u *authUC) generateTwoFactorKey(challengeHash string) string {
+	return fmt.Sprintf("%s: %s", twoFactorPrefix, challengeHash)
+}
+
+func (u *authUC) generateTwoFactorAttemptsKey(challengeHash string) string {
+	return fmt.Sprintf("%s: %s", twoFactorAttemptsPrefix, challengeHash)
+}
+
+func (u *authUC) generateTwoFactorStepKey(userID string) string {
+	return fmt.Sprintf("%s: %s", twoFactorStepPrefix, userID)
+}
+
+func (u *authUC) generatePasswordResetKey(tokenHash string) string {
+	return fmt.Sprintf("%s: %s", passwordResetPrefix, tokenHash)
+}
+
+func (u *authUC) generateRefreshTokenKey(tokenHash string) string {
+	return fmt.Sprintf("%s: %s", refreshTokenPrefix, tokenHash)
+}
+
+func (u *authUC) generateRefreshTokenUsedKey(tokenHash string) string {
+	return fmt.Sprintf("%s: %s", refreshTokenUsedPrefix, tokenHash)
+}
+
+func (u *authUC) generateRefreshTokenFamilyKey(familyID string) string {
+	return fmt.Sprintf("%s: %s", refreshTokenFamilyPrefix, familyID)
+}
+
+func (u *authUC) generateRefreshTokenUserKey(userID string) string {
+	return fmt.Sprintf("%s: %s", refreshTokenUserPrefix, userID)
+}
+
 func (u *authUC) GenerateUserKey(userID string) string {
 	return fmt.Sprintf("%s: %s", basePrefix, userID)
 }
 
-func (u *authUC) generateAWSMinioURL(bucket string, key string) string {
-	return fmt.Sprintf("%s/minio/%s/%s", u.cfg.AWS.MinioEndpoint, bucket, key)
+// Avatar object key is <user_id>/<version>/<size>.jpg, version is new on every upload so cached urls never show a stale image
+func avatarObjectName(userID uuid.UUID, version string, size int) string {
+	return fmt.Sprintf("%s/%s/%d.jpg", userID.String(), version, size)
+}
+
+// Remove stored objects of avatars, objects left after errors are removed by storage reconciliation
+func (u *authUC) removeAvatars(ctx context.Context, avatars models.Avatars) {
+	for _, url := range avatars.URLs() {
+		bucket, objectName, ok := blobstore.ParseObjectURL(u.cfg, url)
+		if !ok {
+			continue
+		}
+		if err := u.awsRepo.RemoveObject(ctx, bucket, objectName); err != nil {
+			u.logger.Errorf("authUC.removeAvatars.RemoveObject: %s", err)
+		}
+	}
 }
@@ -1,22 +1,39 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
-	"github.com/minio/minio-go/v7"
 	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/AleksK1NG/api-mc/config"
 	"github.com/AleksK1NG/api-mc/internal/auth/mock"
+	"github.com/AleksK1NG/api-mc/internal/auth/repository"
+	invitesMock "github.com/AleksK1NG/api-mc/internal/invites/mock"
 	"github.com/AleksK1NG/api-mc/internal/models"
+	moderationMock "github.com/AleksK1NG/api-mc/internal/moderation/mock"
+	sessionMock "github.com/AleksK1NG/api-mc/internal/session/mock"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/jwtkeys"
 	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/mailer"
+	"github.com/AleksK1NG/api-mc/pkg/passwords"
+	"github.com/AleksK1NG/api-mc/pkg/totp"
 	"github.com/AleksK1NG/api-mc/pkg/utils"
 )
 
@@ -40,10 +57,12 @@ func TestAuthUC_Register(t *testing.T) {
 
 	apiLogger := logger.NewApiLogger(cfg)
 	mockAuthRepo := mock.NewMockRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, nil, nil, apiLogger)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	memoryMailer := mailer.NewMemoryMailer()
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, memoryMailer, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
 
 	user := &models.User{
-		Password: "123456",
+		Password: "12345678",
 		Email:    "email@gmail.com",
 	}
 
@@ -53,11 +72,125 @@ func TestAuthUC_Register(t *testing.T) {
 
 	mockAuthRepo.EXPECT().FindByEmail(ctxWithTrace, gomock.Eq(user)).Return(nil, sql.ErrNoRows)
 	mockAuthRepo.EXPECT().Register(ctxWithTrace, gomock.Eq(user)).Return(user, nil)
+	mockRedisRepo.EXPECT().SetRefreshTokenCtx(ctxWithTrace, gomock.Any(), gomock.Any(), gomock.Any(), defaultRefreshExpire, gomock.Any()).Return(nil)
+
+	weakUser := &models.User{
+		Password:  "alexander1",
+		FirstName: "Alexander",
+		Email:     "weak@gmail.com",
+	}
+	mockAuthRepo.EXPECT().FindByEmail(ctxWithTrace, gomock.Eq(weakUser)).Return(nil, sql.ErrNoRows)
 
-	createdUSer, err := authUC.Register(ctx, user)
+	_, err := authUC.Register(ctx, weakUser, "")
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+
+	createdUSer, err := authUC.Register(ctx, user, "")
 	require.NoError(t, err)
 	require.NotNil(t, createdUSer)
 	require.Nil(t, err)
+
+	msg := memoryMailer.Last()
+	require.NotNil(t, msg)
+	require.Equal(t, []string{user.Email}, msg.To)
+	require.Contains(t, msg.Body, "?token=")
+}
+
+func TestAuthUC_RegisterInviteOnly(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Auth: config.Auth{
+			InviteOnly: true,
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockInvitesUC := invitesMock.NewMockUseCase(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, mockInvitesUC, mailer.NewMemoryMailer(), jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	ctx := context.Background()
+
+	t.Run("Invite role is assigned", func(t *testing.T) {
+		role := "moderator"
+		invite := &models.Invite{InviteID: uuid.New(), MaxUses: 1, Role: &role}
+		user := &models.User{Password: "12345678", Email: "invited@gmail.com"}
+
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Eq(user)).Return(nil, sql.ErrNoRows)
+		mockInvitesUC.EXPECT().Redeem(gomock.Any(), "code").Return(invite, nil)
+		mockAuthRepo.EXPECT().Register(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, u *models.User) (*models.User, error) {
+				require.Equal(t, &role, u.Role)
+				u.UserID = uuid.New()
+				return u, nil
+			},
+		)
+		mockInvitesUC.EXPECT().RecordRedemption(gomock.Any(), invite.InviteID, gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().SetRefreshTokenCtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), defaultRefreshExpire, gomock.Any()).Return(nil)
+
+		createdUser, err := authUC.Register(ctx, user, "code")
+		require.NoError(t, err)
+		require.Equal(t, role, *createdUser.User.Role)
+	})
+
+	t.Run("Invalid invite", func(t *testing.T) {
+		user := &models.User{Password: "12345678", Email: "uninvited@gmail.com"}
+
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Eq(user)).Return(nil, sql.ErrNoRows)
+		mockInvitesUC.EXPECT().Redeem(gomock.Any(), "used").Return(
+			nil, httpErrors.NewRestError(http.StatusForbidden, httpErrors.InvalidInvite.Error(), nil),
+		)
+
+		createdUser, err := authUC.Register(ctx, user, "used")
+		require.Nil(t, createdUser)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Invite is released when register fails", func(t *testing.T) {
+		invite := &models.Invite{InviteID: uuid.New(), MaxUses: 1}
+		user := &models.User{Password: "12345678", Email: "failed@gmail.com"}
+
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Eq(user)).Return(nil, sql.ErrNoRows)
+		mockInvitesUC.EXPECT().Redeem(gomock.Any(), "code").Return(invite, nil)
+		mockAuthRepo.EXPECT().Register(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("db error"))
+		mockInvitesUC.EXPECT().Release(gomock.Any(), invite.InviteID).Return(nil)
+
+		createdUser, err := authUC.Register(ctx, user, "code")
+		require.Nil(t, createdUser)
+		require.Error(t, err)
+	})
+
+	t.Run("User is removed when redemption is not recorded", func(t *testing.T) {
+		invite := &models.Invite{InviteID: uuid.New(), MaxUses: 1}
+		user := &models.User{Password: "12345678", Email: "unrecorded@gmail.com"}
+		userID := uuid.New()
+
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Eq(user)).Return(nil, sql.ErrNoRows)
+		mockInvitesUC.EXPECT().Redeem(gomock.Any(), "code").Return(invite, nil)
+		mockAuthRepo.EXPECT().Register(gomock.Any(), gomock.Any()).Return(&models.User{UserID: userID}, nil)
+		mockInvitesUC.EXPECT().RecordRedemption(gomock.Any(), invite.InviteID, userID).Return(fmt.Errorf("db error"))
+		mockAuthRepo.EXPECT().Delete(gomock.Any(), userID).Return(nil)
+		mockInvitesUC.EXPECT().Release(gomock.Any(), invite.InviteID).Return(nil)
+
+		createdUser, err := authUC.Register(ctx, user, "code")
+		require.Nil(t, createdUser)
+		require.Error(t, err)
+	})
 }
 
 func TestAuthUC_Update(t *testing.T) {
@@ -82,7 +215,7 @@ func TestAuthUC_Update(t *testing.T) {
 
 	mockAuthRepo := mock.NewMockRepository(ctrl)
 	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, apiLogger)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
 
 	user := &models.User{
 		Password: "123456",
@@ -125,11 +258,17 @@ func TestAuthUC_Delete(t *testing.T) {
 
 	mockAuthRepo := mock.NewMockRepository(ctrl)
 	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, apiLogger)
+	mockSessUC := sessionMock.NewMockUCSession(ctrl)
+	mockAWSRepo := mock.NewMockAWSRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, mockAWSRepo, mockSessUC, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
 
 	user := &models.User{
 		Password: "123456",
 		Email:    "email@gmail.com",
+		Avatars: models.Avatars{
+			"64":  "/minio/avatars/uid/1/64.jpg",
+			"512": "/minio/avatars/uid/1/512.jpg",
+		},
 	}
 	key := fmt.Sprintf("%s: %s", basePrefix, user.UserID)
 
@@ -137,8 +276,13 @@ func TestAuthUC_Delete(t *testing.T) {
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.Delete")
 	defer span.Finish()
 
+	mockAuthRepo.EXPECT().GetByID(ctxWithTrace, gomock.Eq(user.UserID)).Return(user, nil)
 	mockAuthRepo.EXPECT().Delete(ctxWithTrace, gomock.Eq(user.UserID)).Return(nil)
+	mockAWSRepo.EXPECT().RemoveObject(ctxWithTrace, "avatars", "uid/1/64.jpg").Return(nil)
+	mockAWSRepo.EXPECT().RemoveObject(ctxWithTrace, "avatars", "uid/1/512.jpg").Return(nil)
 	mockRedisRepo.EXPECT().DeleteUserCtx(ctxWithTrace, key).Return(nil)
+	mockSessUC.EXPECT().RevokeUserSessions(ctxWithTrace, user.UserID, "").Return(nil)
+	mockRedisRepo.EXPECT().DeleteUserRefreshFamiliesCtx(gomock.Any(), fmt.Sprintf("%s: %s", refreshTokenUserPrefix, user.UserID)).Return(nil)
 
 	err := authUC.Delete(ctx, user.UserID)
 	require.NoError(t, err)
@@ -166,7 +310,7 @@ func TestAuthUC_GetByID(t *testing.T) {
 	apiLogger := logger.NewApiLogger(cfg)
 	mockAuthRepo := mock.NewMockRepository(ctrl)
 	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, apiLogger)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
 
 	user := &models.User{
 		Password: "123456",
@@ -209,7 +353,7 @@ func TestAuthUC_FindByName(t *testing.T) {
 	apiLogger := logger.NewApiLogger(cfg)
 	mockAuthRepo := mock.NewMockRepository(ctrl)
 	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, apiLogger)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
 
 	userName := "name"
 	query := &utils.PaginationQuery{
@@ -252,7 +396,7 @@ func TestAuthUC_GetUsers(t *testing.T) {
 	apiLogger := logger.NewApiLogger(cfg)
 	mockAuthRepo := mock.NewMockRepository(ctrl)
 	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, apiLogger)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
 
 	query := &utils.PaginationQuery{
 		Size:    10,
@@ -294,7 +438,8 @@ func TestAuthUC_Login(t *testing.T) {
 	apiLogger := logger.NewApiLogger(cfg)
 	mockAuthRepo := mock.NewMockRepository(ctrl)
 	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, apiLogger)
+	mockModerationUC := moderationMock.NewMockUseCase(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, mockModerationUC, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
 
 	ctx := context.Background()
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.Login")
@@ -313,12 +458,192 @@ func TestAuthUC_Login(t *testing.T) {
 		Password: string(hashPassword),
 	}
 
+	emailLockKey := fmt.Sprintf("%s: email:%s", loginLockPrefix, user.Email)
+	emailFailuresKey := fmt.Sprintf("%s: email:%s", loginFailuresPrefix, user.Email)
+
+	mockRedisRepo.EXPECT().GetLoginLockCtx(ctxWithTrace, emailLockKey).Return(time.Duration(0), nil)
 	mockAuthRepo.EXPECT().FindByEmail(ctxWithTrace, gomock.Eq(user)).Return(mockUser, nil)
+	mockAuthRepo.EXPECT().UpdatePassword(ctxWithTrace, mockUser.UserID, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID uuid.UUID, password string) error {
+			require.True(t, strings.HasPrefix(password, "$argon2id$"))
+			return passwords.Compare(password, user.Password)
+		},
+	)
+	mockRedisRepo.EXPECT().DeleteLoginFailuresCtx(ctxWithTrace, emailFailuresKey, emailLockKey).Return(nil)
+	mockModerationUC.EXPECT().CheckAccount(ctxWithTrace, gomock.Any()).Return(nil)
+	mockRedisRepo.EXPECT().SetRefreshTokenCtx(ctxWithTrace, gomock.Any(), gomock.Any(), gomock.Any(), defaultRefreshExpire, gomock.Any()).Return(nil)
 
 	userWithToken, err := authUC.Login(ctx, user)
 	require.NoError(t, err)
 	require.Nil(t, err)
 	require.NotNil(t, userWithToken)
+
+	t.Run("Current hash is kept", func(t *testing.T) {
+		argon2Hash, err := passwords.NewHasher(passwords.Params(cfg.PasswordHash)).Hash(user.Password)
+		require.NoError(t, err)
+		currentUser := &models.User{
+			UserID:   uuid.New(),
+			Email:    "email@gmail.com",
+			Password: argon2Hash,
+		}
+
+		mockRedisRepo.EXPECT().GetLoginLockCtx(ctxWithTrace, emailLockKey).Return(time.Duration(0), nil)
+		mockAuthRepo.EXPECT().FindByEmail(ctxWithTrace, gomock.Eq(user)).Return(currentUser, nil)
+		mockRedisRepo.EXPECT().DeleteLoginFailuresCtx(ctxWithTrace, emailFailuresKey, emailLockKey).Return(nil)
+		mockModerationUC.EXPECT().CheckAccount(ctxWithTrace, currentUser.UserID).Return(nil)
+		mockRedisRepo.EXPECT().SetRefreshTokenCtx(ctxWithTrace, gomock.Any(), gomock.Any(), gomock.Any(), defaultRefreshExpire, gomock.Any()).Return(nil)
+
+		userWithToken, err := authUC.Login(ctx, user)
+		require.NoError(t, err)
+		require.NotNil(t, userWithToken)
+	})
+
+	t.Run("Suspended", func(t *testing.T) {
+		suspendedErr := httpErrors.NewRestError(http.StatusForbidden, httpErrors.AccountSuspended.Error(), nil)
+
+		mockRedisRepo.EXPECT().GetLoginLockCtx(ctxWithTrace, emailLockKey).Return(time.Duration(0), nil)
+		suspendedUser := &models.User{
+			UserID:   uuid.New(),
+			Email:    "email@gmail.com",
+			Password: string(hashPassword),
+		}
+
+		mockAuthRepo.EXPECT().FindByEmail(ctxWithTrace, gomock.Eq(user)).Return(suspendedUser, nil)
+		mockAuthRepo.EXPECT().UpdatePassword(ctxWithTrace, suspendedUser.UserID, gomock.Any()).Return(nil)
+		mockModerationUC.EXPECT().CheckAccount(ctxWithTrace, suspendedUser.UserID).Return(suspendedErr)
+
+		userWithToken, err := authUC.Login(ctx, user)
+		require.Equal(t, suspendedErr, err)
+		require.Nil(t, userWithToken)
+	})
+}
+
+func TestAuthUC_LoginThrottle(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+		LoginThrottle: config.LoginThrottle{
+			MaxEmailAttempts: 5,
+			MaxIPAttempts:    20,
+			AttemptsWindow:   900,
+			LockoutDuration:  600,
+			DelayAfter:       3,
+			BaseDelay:        2,
+			MaxDelay:         60,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockModerationUC := moderationMock.NewMockUseCase(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, mockModerationUC, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	ip := "10.0.0.1"
+	ctx := context.WithValue(context.Background(), utils.IPCtxKey{}, ip)
+	user := &models.User{Email: "email@gmail.com", Password: "wrong-password"}
+
+	emailLockKey := fmt.Sprintf("%s: email:%s", loginLockPrefix, user.Email)
+	emailFailuresKey := fmt.Sprintf("%s: email:%s", loginFailuresPrefix, user.Email)
+	ipLockKey := fmt.Sprintf("%s: ip:%s", loginLockPrefix, ip)
+	ipFailuresKey := fmt.Sprintf("%s: ip:%s", loginFailuresPrefix, ip)
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte("123456"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	mockUser := &models.User{Email: user.Email, Password: string(hashPassword)}
+
+	t.Run("Locked", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetLoginLockCtx(gomock.Any(), emailLockKey, ipLockKey).Return(90*time.Second, nil)
+
+		_, err := authUC.Login(ctx, user)
+		require.Error(t, err)
+
+		restErr, ok := err.(httpErrors.RetryAfterErr)
+		require.True(t, ok)
+		require.Equal(t, http.StatusTooManyRequests, restErr.Status())
+		require.Equal(t, 90, restErr.RetryAfter())
+	})
+
+	t.Run("Progressive delay", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetLoginLockCtx(gomock.Any(), emailLockKey, ipLockKey).Return(time.Duration(0), nil)
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Eq(user)).Return(mockUser, nil)
+		mockRedisRepo.EXPECT().IncrLoginFailuresCtx(gomock.Any(), emailFailuresKey, 900).Return(int64(4), nil)
+		mockRedisRepo.EXPECT().SetLoginLockCtx(gomock.Any(), emailLockKey, 4).Return(nil)
+		mockRedisRepo.EXPECT().IncrLoginFailuresCtx(gomock.Any(), ipFailuresKey, 900).Return(int64(4), nil)
+
+		_, err := authUC.Login(ctx, user)
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Lockout", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetLoginLockCtx(gomock.Any(), emailLockKey, ipLockKey).Return(time.Duration(0), nil)
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Eq(user)).Return(nil, sql.ErrNoRows)
+		mockRedisRepo.EXPECT().IncrLoginFailuresCtx(gomock.Any(), emailFailuresKey, 900).Return(int64(5), nil)
+		mockRedisRepo.EXPECT().SetLoginLockCtx(gomock.Any(), emailLockKey, 600).Return(nil)
+		mockRedisRepo.EXPECT().IncrLoginFailuresCtx(gomock.Any(), ipFailuresKey, 900).Return(int64(5), nil)
+
+		_, err := authUC.Login(ctx, user)
+		require.Error(t, err)
+
+		restErr, ok := err.(httpErrors.RetryAfterErr)
+		require.True(t, ok)
+		require.Equal(t, http.StatusTooManyRequests, restErr.Status())
+		require.Equal(t, 600, restErr.RetryAfter())
+	})
+}
+
+func TestAuthUC_UnlockLogin(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.UnlockLogin")
+	defer span.Finish()
+
+	mockUser := &models.User{UserID: uuid.New(), Email: "Email@gmail.com"}
+
+	mockAuthRepo.EXPECT().GetByID(ctxWithTrace, mockUser.UserID).Return(mockUser, nil)
+	mockRedisRepo.EXPECT().DeleteLoginFailuresCtx(
+		ctxWithTrace,
+		fmt.Sprintf("%s: email:%s", loginFailuresPrefix, "email@gmail.com"),
+		fmt.Sprintf("%s: email:%s", loginLockPrefix, "email@gmail.com"),
+	).Return(nil)
+
+	err := authUC.UnlockLogin(ctx, mockUser.UserID)
+	require.NoError(t, err)
 }
 
 func TestAuthUC_UploadAvatar(t *testing.T) {
@@ -343,14 +668,12 @@ func TestAuthUC_UploadAvatar(t *testing.T) {
 	mockAuthRepo := mock.NewMockRepository(ctrl)
 	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
 	mockAWSRepo := mock.NewMockAWSRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, mockAWSRepo, apiLogger)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, mockAWSRepo, nil, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
 
 	ctx := context.Background()
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.UploadAvatar")
 	defer span.Finish()
 
-	file := models.UploadInput{}
-	uploadInfo := &minio.UploadInfo{}
 	userUID := uuid.New()
 
 	user := &models.User{
@@ -359,11 +682,1029 @@ func TestAuthUC_UploadAvatar(t *testing.T) {
 		Email:    "email@gmail.com",
 	}
 
-	mockAWSRepo.EXPECT().PutObject(ctxWithTrace, gomock.Eq(file)).Return(uploadInfo, nil)
-	mockAuthRepo.EXPECT().Update(ctxWithTrace, gomock.Any()).Return(user, nil)
+	t.Run("Resize", func(t *testing.T) {
+		previous := &models.User{UserID: userUID, Avatars: models.Avatars{"256": "/minio/avatars/uid-avatar.png"}}
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, userUID).Return(previous, nil)
+
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 300, 200))))
+		file := models.UploadInput{File: buf, Name: "avatar.png", BucketName: "avatars"}
+
+		var objectNames []string
+		mockAWSRepo.EXPECT().PutObject(ctxWithTrace, gomock.Any()).Times(3).DoAndReturn(
+			func(ctx context.Context, input models.UploadInput) (string, error) {
+				require.Equal(t, "image/jpeg", input.ContentType)
+				require.Equal(t, "avatars", input.BucketName)
+				objectNames = append(objectNames, input.ObjectName)
+				return input.ObjectName, nil
+			},
+		)
+		mockAuthRepo.EXPECT().UpdateAvatars(ctxWithTrace, userUID, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, userID uuid.UUID, avatars models.Avatars) (*models.User, error) {
+				require.Len(t, avatars, 3)
+				require.Contains(t, avatars["256"], "/minio/avatars/"+userUID.String()+"/")
+				user.Avatars = avatars
+				return user, nil
+			},
+		)
+		mockAWSRepo.EXPECT().RemoveObject(ctxWithTrace, "avatars", "uid-avatar.png").Return(nil)
+		mockRedisRepo.EXPECT().DeleteUserCtx(ctxWithTrace, gomock.Any()).Return(nil)
+
+		updatedUser, err := authUC.UploadAvatar(ctx, userUID, file)
+		require.NoError(t, err)
+		require.NotNil(t, updatedUser)
+		require.Len(t, objectNames, 3)
+		require.True(t, strings.HasPrefix(objectNames[0], userUID.String()+"/"))
+		require.True(t, strings.HasSuffix(objectNames[0], "/64.jpg"))
+	})
+
+	t.Run("Jpeg", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, userUID).Return(user, nil)
+
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, jpeg.Encode(buf, image.NewRGBA(image.Rect(0, 0, 200, 300)), nil))
+		file := models.UploadInput{File: buf, Name: "avatar.jpg", BucketName: "avatars"}
+
+		mockAWSRepo.EXPECT().PutObject(ctxWithTrace, gomock.Any()).Times(3).DoAndReturn(
+			func(ctx context.Context, input models.UploadInput) (string, error) {
+				return input.ObjectName, nil
+			},
+		)
+		mockAuthRepo.EXPECT().UpdateAvatars(ctxWithTrace, userUID, gomock.Any()).Return(user, nil)
+		mockAWSRepo.EXPECT().RemoveObject(ctxWithTrace, "avatars", gomock.Any()).AnyTimes().Return(nil)
+		mockRedisRepo.EXPECT().DeleteUserCtx(ctxWithTrace, gomock.Any()).Return(nil)
+
+		updatedUser, err := authUC.UploadAvatar(ctx, userUID, file)
+		require.NoError(t, err)
+		require.NotNil(t, updatedUser)
+	})
+
+	t.Run("Invalid image", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, userUID).Return(user, nil)
+		file := models.UploadInput{File: bytes.NewReader([]byte("not an image")), BucketName: "avatars"}
+
+		updatedUser, err := authUC.UploadAvatar(ctx, userUID, file)
+		require.Error(t, err)
+		require.Nil(t, updatedUser)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestAuthUC_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey:       "secret",
+			RefreshTokenExpire: 60,
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockModerationUC := moderationMock.NewMockUseCase(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, mockModerationUC, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.RefreshToken")
+	defer span.Finish()
+
+	refreshToken := "refresh-token"
+	tokenHash := utils.HashToken(refreshToken)
+	tokenKey := fmt.Sprintf("%s: %s", refreshTokenPrefix, tokenHash)
+	usedKey := fmt.Sprintf("%s: %s", refreshTokenUsedPrefix, tokenHash)
+
+	user := &models.User{
+		UserID: uuid.New(),
+		Email:  "email@gmail.com",
+	}
+	storedToken := &models.RefreshToken{
+		FamilyID: uuid.New().String(),
+		UserID:   user.UserID,
+	}
+	familyKey := fmt.Sprintf("%s: %s", refreshTokenFamilyPrefix, storedToken.FamilyID)
+	userKey := fmt.Sprintf("%s: %s", basePrefix, user.UserID)
+
+	t.Run("Rotate", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetRefreshTokenCtx(ctxWithTrace, tokenKey).Return(storedToken, nil)
+		mockRedisRepo.EXPECT().MarkRefreshTokenUsedCtx(ctxWithTrace, usedKey, 60).Return(true, nil)
+		mockRedisRepo.EXPECT().GetByIDCtx(gomock.Any(), userKey).Return(user, nil)
+		mockModerationUC.EXPECT().CheckAccount(ctxWithTrace, gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().SetRefreshTokenCtx(ctxWithTrace, gomock.Any(), familyKey, gomock.Any(), 60, gomock.Any()).Return(nil)
+
+		userWithToken, err := authUC.RefreshToken(ctx, refreshToken)
+		require.NoError(t, err)
+		require.NotNil(t, userWithToken)
+		require.NotEqual(t, refreshToken, userWithToken.RefreshToken)
+	})
+
+	t.Run("Reuse revokes family", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetRefreshTokenCtx(ctxWithTrace, tokenKey).Return(storedToken, nil)
+		mockRedisRepo.EXPECT().MarkRefreshTokenUsedCtx(ctxWithTrace, usedKey, 60).Return(false, nil)
+		mockRedisRepo.EXPECT().DeleteRefreshFamilyCtx(ctxWithTrace, familyKey).Return(nil)
+
+		userWithToken, err := authUC.RefreshToken(ctx, refreshToken)
+		require.Error(t, err)
+		require.Nil(t, userWithToken)
+	})
+}
+
+func TestAuthUC_RevokeRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	updatedUser, err := authUC.UploadAvatar(ctx, userUID, file)
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.RevokeRefreshToken")
+	defer span.Finish()
+
+	refreshToken := "refresh-token"
+	tokenKey := fmt.Sprintf("%s: %s", refreshTokenPrefix, utils.HashToken(refreshToken))
+	storedToken := &models.RefreshToken{
+		FamilyID: uuid.New().String(),
+		UserID:   uuid.New(),
+	}
+	familyKey := fmt.Sprintf("%s: %s", refreshTokenFamilyPrefix, storedToken.FamilyID)
+
+	mockRedisRepo.EXPECT().GetRefreshTokenCtx(ctxWithTrace, tokenKey).Return(storedToken, nil)
+	mockRedisRepo.EXPECT().DeleteRefreshFamilyCtx(ctxWithTrace, familyKey).Return(nil)
+
+	err := authUC.RevokeRefreshToken(ctx, refreshToken)
 	require.NoError(t, err)
-	require.Nil(t, err)
-	require.NotNil(t, updatedUser)
+}
+
+func TestAuthUC_ForgotPassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Auth: config.Auth{
+			PasswordResetURL:    "https://localhost/reset-password",
+			PasswordResetExpire: 600,
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	memoryMailer := mailer.NewMemoryMailer()
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, memoryMailer, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.ForgotPassword")
+	defer span.Finish()
+
+	t.Run("Send reset link", func(t *testing.T) {
+		user := &models.User{
+			UserID: uuid.New(),
+			Email:  "email@gmail.com",
+		}
+
+		mockAuthRepo.EXPECT().FindByEmail(ctxWithTrace, gomock.Eq(&models.User{Email: user.Email})).Return(user, nil)
+		mockRedisRepo.EXPECT().SetPasswordResetCtx(ctxWithTrace, gomock.Any(), 600, user.UserID).Return(nil)
+
+		err := authUC.ForgotPassword(ctx, " Email@gmail.com ")
+		require.NoError(t, err)
+
+		message := memoryMailer.Last()
+		require.NotNil(t, message)
+		require.Equal(t, []string{user.Email}, message.To)
+		require.Contains(t, message.Body, "https://localhost/reset-password?token=")
+	})
+
+	t.Run("Unknown email", func(t *testing.T) {
+		memoryMailer.Reset()
+
+		mockAuthRepo.EXPECT().FindByEmail(ctxWithTrace, gomock.Any()).Return(nil, sql.ErrNoRows)
+
+		err := authUC.ForgotPassword(ctx, "unknown@gmail.com")
+		require.NoError(t, err)
+		require.Nil(t, memoryMailer.Last())
+	})
+}
+
+func TestAuthUC_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockSessUC := sessionMock.NewMockUCSession(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, mockSessUC, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.ResetPassword")
+	defer span.Finish()
+
+	token := "reset-token"
+	userID := uuid.New()
+	resetKey := fmt.Sprintf("%s: %s", passwordResetPrefix, utils.HashToken(token))
+	userKey := fmt.Sprintf("%s: %s", basePrefix, userID)
+
+	user := &models.User{
+		UserID:    userID,
+		FirstName: "Alexander",
+		Email:     "alexander@gmail.com",
+	}
+
+	t.Run("Policy violation", func(t *testing.T) {
+		for _, password := range []string{"short", "alexander2020"} {
+			mockRedisRepo.EXPECT().GetPasswordResetCtx(ctxWithTrace, resetKey).Return(userID, nil)
+			mockAuthRepo.EXPECT().GetByID(ctxWithTrace, userID).Return(user, nil)
+
+			err := authUC.ResetPassword(ctx, token, password)
+			require.Error(t, err)
+			require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		}
+	})
+
+	t.Run("Reset password", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetPasswordResetCtx(ctxWithTrace, resetKey).Return(userID, nil)
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, userID).Return(user, nil)
+		mockRedisRepo.EXPECT().PopPasswordResetCtx(ctxWithTrace, resetKey).Return(userID, nil)
+		mockAuthRepo.EXPECT().UpdatePassword(ctxWithTrace, userID, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, userID uuid.UUID, password string) error {
+				return passwords.Compare(password, "new password")
+			},
+		)
+		mockRedisRepo.EXPECT().DeleteUserCtx(ctxWithTrace, userKey).Return(nil)
+		mockSessUC.EXPECT().RevokeUserSessions(ctxWithTrace, userID, "").Return(nil)
+		mockRedisRepo.EXPECT().DeleteUserRefreshFamiliesCtx(gomock.Any(), fmt.Sprintf("%s: %s", refreshTokenUserPrefix, userID)).Return(nil)
+
+		err := authUC.ResetPassword(ctx, token, "new password")
+		require.NoError(t, err)
+	})
+}
+
+func TestAuthUC_VerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	user := &models.User{
+		UserID: uuid.New(),
+		Email:  "email@gmail.com",
+	}
+
+	token, err := utils.GenerateEmailVerificationToken(user, cfg, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.VerifyEmail")
+	defer span.Finish()
+
+	t.Run("Verify", func(t *testing.T) {
+		verifiedAt := time.Now()
+
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, user.UserID).Return(user, nil)
+		mockAuthRepo.EXPECT().VerifyEmail(ctxWithTrace, user.UserID).Return(&models.User{
+			UserID:          user.UserID,
+			Email:           user.Email,
+			EmailVerifiedAt: &verifiedAt,
+		}, nil)
+		mockRedisRepo.EXPECT().DeleteUserCtx(ctxWithTrace, fmt.Sprintf("%s: %s", basePrefix, user.UserID.String())).Return(nil)
+
+		verifiedUser, err := authUC.VerifyEmail(ctx, token)
+		require.NoError(t, err)
+		require.NotNil(t, verifiedUser.EmailVerifiedAt)
+	})
+
+	t.Run("Email changed", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, user.UserID).Return(&models.User{
+			UserID: user.UserID,
+			Email:  "changed@gmail.com",
+		}, nil)
+
+		verifiedUser, err := authUC.VerifyEmail(ctx, token)
+		require.Error(t, err)
+		require.Nil(t, verifiedUser)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		verifiedUser, err := authUC.VerifyEmail(ctx, "invalid")
+		require.Error(t, err)
+		require.Nil(t, verifiedUser)
+	})
+}
+
+func TestAuthUC_ResendVerificationEmail(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Auth: config.Auth{
+			EmailVerificationURL: "http://localhost/verify",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	memoryMailer := mailer.NewMemoryMailer()
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, memoryMailer, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	user := &models.User{
+		UserID: uuid.New(),
+		Email:  "email@gmail.com",
+	}
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.ResendVerificationEmail")
+	defer span.Finish()
+
+	t.Run("Resend", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, user.UserID).Return(user, nil)
+
+		err := authUC.ResendVerificationEmail(ctx, user.UserID)
+		require.NoError(t, err)
+		require.NotNil(t, memoryMailer.Last())
+		require.Contains(t, memoryMailer.Last().Body, cfg.Auth.EmailVerificationURL+"?token=")
+	})
+
+	t.Run("Already verified", func(t *testing.T) {
+		memoryMailer.Reset()
+		verifiedAt := time.Now()
+
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, user.UserID).Return(&models.User{
+			UserID:          user.UserID,
+			Email:           user.Email,
+			EmailVerifiedAt: &verifiedAt,
+		}, nil)
+
+		err := authUC.ResendVerificationEmail(ctx, user.UserID)
+		require.Error(t, err)
+		require.Nil(t, memoryMailer.Last())
+	})
+}
+
+func TestAuthUC_LoginTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockModerationUC := moderationMock.NewMockUseCase(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, mockModerationUC, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte("123456"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	enabledAt := time.Now()
+	mockUser := &models.User{
+		UserID:        uuid.New(),
+		Email:         "email@gmail.com",
+		Password:      string(hashPassword),
+		TOTPSecret:    &secret,
+		TOTPEnabledAt: &enabledAt,
+	}
+
+	ctx := context.Background()
+
+	t.Run("Login returns challenge", func(t *testing.T) {
+		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.Login")
+		defer span.Finish()
+
+		user := &models.User{Email: mockUser.Email, Password: "123456"}
+
+		mockRedisRepo.EXPECT().GetLoginLockCtx(ctxWithTrace, gomock.Any()).Return(time.Duration(0), nil)
+		mockAuthRepo.EXPECT().FindByEmail(ctxWithTrace, gomock.Eq(user)).Return(mockUser, nil)
+		mockAuthRepo.EXPECT().UpdatePassword(ctxWithTrace, mockUser.UserID, gomock.Any()).Return(nil)
+		mockModerationUC.EXPECT().CheckAccount(ctxWithTrace, gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().SetTwoFactorChallengeCtx(ctxWithTrace, gomock.Any(), defaultChallengeExpire, mockUser.UserID).Return(nil)
+
+		userWithToken, err := authUC.Login(ctx, user)
+		require.NoError(t, err)
+		require.NotEmpty(t, userWithToken.Challenge)
+		require.Empty(t, userWithToken.Token)
+		require.Nil(t, userWithToken.User)
+	})
+
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.LoginTwoFactor")
+	defer span.Finish()
+
+	challenge := "challenge"
+	challengeKey := fmt.Sprintf("%s: %s", twoFactorPrefix, utils.HashToken(challenge))
+	attemptsKey := fmt.Sprintf("%s: %s", twoFactorAttemptsPrefix, utils.HashToken(challenge))
+	stepKey := fmt.Sprintf("%s: %s", twoFactorStepPrefix, mockUser.UserID.String())
+	emailLockKey := fmt.Sprintf("%s: email:%s", loginLockPrefix, mockUser.Email)
+	emailFailuresKey := fmt.Sprintf("%s: email:%s", loginFailuresPrefix, mockUser.Email)
+
+	t.Run("TOTP code", func(t *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		require.NoError(t, err)
+
+		mockRedisRepo.EXPECT().GetTwoFactorChallengeCtx(ctxWithTrace, challengeKey).Return(mockUser.UserID, nil)
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, mockUser.UserID).Return(mockUser, nil)
+		mockRedisRepo.EXPECT().GetLoginLockCtx(ctxWithTrace, emailLockKey).Return(time.Duration(0), nil)
+		mockRedisRepo.EXPECT().SetTwoFactorStepCtx(ctxWithTrace, stepKey, time.Now().Unix()/30, totp.Window).Return(true, nil)
+		mockRedisRepo.EXPECT().DeleteTwoFactorChallengeCtx(ctxWithTrace, challengeKey, attemptsKey).Return(nil)
+		mockModerationUC.EXPECT().CheckAccount(ctxWithTrace, gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().DeleteLoginFailuresCtx(ctxWithTrace, emailFailuresKey, emailLockKey).Return(nil)
+		mockRedisRepo.EXPECT().SetRefreshTokenCtx(ctxWithTrace, gomock.Any(), gomock.Any(), gomock.Any(), defaultRefreshExpire, gomock.Any()).Return(nil)
+
+		userWithToken, err := authUC.LoginTwoFactor(ctx, challenge, code)
+		require.NoError(t, err)
+		require.NotEmpty(t, userWithToken.Token)
+		require.Equal(t, mockUser.UserID, userWithToken.User.UserID)
+	})
+
+	t.Run("Replayed TOTP code", func(t *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		require.NoError(t, err)
+
+		mockRedisRepo.EXPECT().GetTwoFactorChallengeCtx(ctxWithTrace, challengeKey).Return(mockUser.UserID, nil)
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, mockUser.UserID).Return(mockUser, nil)
+		mockRedisRepo.EXPECT().GetLoginLockCtx(ctxWithTrace, emailLockKey).Return(time.Duration(0), nil)
+		mockRedisRepo.EXPECT().SetTwoFactorStepCtx(ctxWithTrace, stepKey, gomock.Any(), totp.Window).Return(false, nil)
+		mockAuthRepo.EXPECT().UseRecoveryCode(ctxWithTrace, mockUser.UserID, gomock.Any()).Return(sql.ErrNoRows)
+		mockRedisRepo.EXPECT().IncrLoginFailuresCtx(ctxWithTrace, emailFailuresKey, defaultAttemptsWindow).Return(int64(1), nil)
+		mockRedisRepo.EXPECT().IncrTwoFactorAttemptsCtx(ctxWithTrace, attemptsKey, defaultChallengeExpire).Return(int64(1), nil)
+
+		userWithToken, err := authUC.LoginTwoFactor(ctx, challenge, code)
+		require.Error(t, err)
+		require.Nil(t, userWithToken)
+	})
+
+	t.Run("Recovery code", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetTwoFactorChallengeCtx(ctxWithTrace, challengeKey).Return(mockUser.UserID, nil)
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, mockUser.UserID).Return(mockUser, nil)
+		mockRedisRepo.EXPECT().GetLoginLockCtx(ctxWithTrace, emailLockKey).Return(time.Duration(0), nil)
+		mockAuthRepo.EXPECT().UseRecoveryCode(ctxWithTrace, mockUser.UserID, utils.HashToken("a1b2c3d4e5")).Return(nil)
+		mockRedisRepo.EXPECT().DeleteTwoFactorChallengeCtx(ctxWithTrace, challengeKey, attemptsKey).Return(nil)
+		mockModerationUC.EXPECT().CheckAccount(ctxWithTrace, gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().DeleteLoginFailuresCtx(ctxWithTrace, emailFailuresKey, emailLockKey).Return(nil)
+		mockRedisRepo.EXPECT().SetRefreshTokenCtx(ctxWithTrace, gomock.Any(), gomock.Any(), gomock.Any(), defaultRefreshExpire, gomock.Any()).Return(nil)
+
+		userWithToken, err := authUC.LoginTwoFactor(ctx, challenge, "A1B2C-3D4E5")
+		require.NoError(t, err)
+		require.NotEmpty(t, userWithToken.Token)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetTwoFactorChallengeCtx(ctxWithTrace, challengeKey).Return(mockUser.UserID, nil)
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, mockUser.UserID).Return(mockUser, nil)
+		mockRedisRepo.EXPECT().GetLoginLockCtx(ctxWithTrace, emailLockKey).Return(time.Duration(0), nil)
+		mockAuthRepo.EXPECT().UseRecoveryCode(ctxWithTrace, mockUser.UserID, gomock.Any()).Return(sql.ErrNoRows)
+		mockRedisRepo.EXPECT().IncrLoginFailuresCtx(ctxWithTrace, emailFailuresKey, defaultAttemptsWindow).Return(int64(1), nil)
+		mockRedisRepo.EXPECT().IncrTwoFactorAttemptsCtx(ctxWithTrace, attemptsKey, defaultChallengeExpire).Return(int64(maxTwoFactorAttempts), nil)
+		mockRedisRepo.EXPECT().DeleteTwoFactorChallengeCtx(ctxWithTrace, challengeKey, attemptsKey).Return(nil)
+
+		userWithToken, err := authUC.LoginTwoFactor(ctx, challenge, "000000x")
+		require.Error(t, err)
+		require.Nil(t, userWithToken)
+	})
+}
+
+func TestAuthUC_LoginTwoFactorLockout(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+		LoginThrottle: config.LoginThrottle{
+			MaxEmailAttempts: 5,
+			MaxIPAttempts:    20,
+			AttemptsWindow:   900,
+			LockoutDuration:  600,
+			DelayAfter:       10,
+			BaseDelay:        2,
+			MaxDelay:         60,
+		},
+	}
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	redisRepo := repository.NewAuthRedisRepo(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	mockModerationUC := moderationMock.NewMockUseCase(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, redisRepo, nil, nil, mockModerationUC, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte("123456"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	enabledAt := time.Now()
+	mockUser := &models.User{
+		UserID:        uuid.New(),
+		Email:         "email@gmail.com",
+		Password:      string(hashPassword),
+		TOTPSecret:    &secret,
+		TOTPEnabledAt: &enabledAt,
+	}
+
+	// Login sanitizes and rehashes found user, so every lookup returns fresh copy
+	mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *models.User) (*models.User, error) {
+		found := *mockUser
+		return &found, nil
+	}).AnyTimes()
+	mockAuthRepo.EXPECT().UpdatePassword(gomock.Any(), mockUser.UserID, gomock.Any()).Return(nil).AnyTimes()
+	mockAuthRepo.EXPECT().GetByID(gomock.Any(), mockUser.UserID).Return(mockUser, nil).AnyTimes()
+	mockAuthRepo.EXPECT().UseRecoveryCode(gomock.Any(), mockUser.UserID, gomock.Any()).Return(sql.ErrNoRows).AnyTimes()
+	mockModerationUC.EXPECT().CheckAccount(gomock.Any(), mockUser.UserID).Return(nil).AnyTimes()
+
+	t.Run("New challenges do not reset failed codes", func(t *testing.T) {
+		ctx := context.Background()
+
+		for i := 1; i < cfg.LoginThrottle.MaxEmailAttempts; i++ {
+			userWithToken, err := authUC.Login(ctx, &models.User{Email: mockUser.Email, Password: "123456"})
+			require.NoError(t, err)
+			require.NotEmpty(t, userWithToken.Challenge)
+
+			_, err = authUC.LoginTwoFactor(ctx, userWithToken.Challenge, "000000x")
+			require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+		}
+
+		userWithToken, err := authUC.Login(ctx, &models.User{Email: mockUser.Email, Password: "123456"})
+		require.NoError(t, err)
+
+		_, err = authUC.LoginTwoFactor(ctx, userWithToken.Challenge, "000000x")
+		require.Equal(t, http.StatusTooManyRequests, httpErrors.ParseErrors(err).Status())
+
+		_, err = authUC.Login(ctx, &models.User{Email: mockUser.Email, Password: "123456"})
+		require.Equal(t, http.StatusTooManyRequests, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestAuthUC_ConfirmTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	mockUser := &models.User{
+		UserID:     uuid.New(),
+		Email:      "email@gmail.com",
+		TOTPSecret: &secret,
+	}
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.ConfirmTwoFactor")
+	defer span.Finish()
+
+	t.Run("Confirm", func(t *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		require.NoError(t, err)
+
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, mockUser.UserID).Return(mockUser, nil)
+		mockRedisRepo.EXPECT().SetTwoFactorStepCtx(ctxWithTrace, gomock.Any(), gomock.Any(), totp.Window).Return(true, nil)
+		mockAuthRepo.EXPECT().EnableTOTP(ctxWithTrace, mockUser.UserID, gomock.Len(recoveryCodesCount)).Return(nil)
+		mockRedisRepo.EXPECT().DeleteUserCtx(ctxWithTrace, fmt.Sprintf("%s: %s", basePrefix, mockUser.UserID.String())).Return(nil)
+
+		recoveryCodes, err := authUC.ConfirmTwoFactor(ctx, mockUser.UserID, code)
+		require.NoError(t, err)
+		require.Len(t, recoveryCodes.Codes, recoveryCodesCount)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, mockUser.UserID).Return(mockUser, nil)
+
+		recoveryCodes, err := authUC.ConfirmTwoFactor(ctx, mockUser.UserID, "abcdef")
+		require.Error(t, err)
+		require.Nil(t, recoveryCodes)
+	})
+}
+
+func TestAuthUC_LoginExternal(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockModerationUC := moderationMock.NewMockUseCase(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, mockModerationUC, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.LoginExternal")
+	defer span.Finish()
+
+	t.Run("Tokens", func(t *testing.T) {
+		userID := uuid.New()
+
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, userID).Return(&models.User{UserID: userID}, nil)
+		mockModerationUC.EXPECT().CheckAccount(ctxWithTrace, gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().SetRefreshTokenCtx(ctxWithTrace, gomock.Any(), gomock.Any(), gomock.Any(), defaultRefreshExpire, gomock.Any()).Return(nil)
+
+		userWithToken, err := authUC.LoginExternal(ctx, userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, userWithToken.Token)
+	})
+
+	t.Run("Two factor challenge", func(t *testing.T) {
+		userID := uuid.New()
+		secret := "secret"
+		enabledAt := time.Now()
+
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, userID).Return(&models.User{
+			UserID:        userID,
+			TOTPSecret:    &secret,
+			TOTPEnabledAt: &enabledAt,
+		}, nil)
+		mockModerationUC.EXPECT().CheckAccount(ctxWithTrace, gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().SetTwoFactorChallengeCtx(ctxWithTrace, gomock.Any(), defaultChallengeExpire, userID).Return(nil)
+
+		userWithToken, err := authUC.LoginExternal(ctx, userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, userWithToken.Challenge)
+		require.Empty(t, userWithToken.Token)
+	})
+}
+
+func TestAuthUC_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+		PasswordPolicy: config.PasswordPolicy{
+			MinLength:    8,
+			RequireUpper: true,
+			RequireLower: true,
+			RequireDigit: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockSessUC := sessionMock.NewMockUCSession(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, mockSessUC, nil, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte("current password"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	user := &models.User{
+		UserID:    uuid.New(),
+		FirstName: "Alexander",
+		LastName:  "Bryksin",
+		Email:     "alexander@gmail.com",
+	}
+	foundUser := &models.User{
+		UserID:   user.UserID,
+		Email:    user.Email,
+		Password: string(hashPassword),
+	}
+
+	ctx := context.Background()
+
+	t.Run("Wrong current password", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), user).Return(foundUser, nil)
+
+		err := authUC.ChangePassword(ctx, user.UserID, "wrong password", "NewPassw0rd")
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Policy violation", func(t *testing.T) {
+		for _, password := range []string{"Sh0rt", "newpassw0rd", "NewPassword", "Alexander2020"} {
+			mockAuthRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+			mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), user).Return(foundUser, nil)
+
+			err := authUC.ChangePassword(ctx, user.UserID, "current password", password)
+			require.Error(t, err)
+			require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		}
+	})
+
+	t.Run("Change password", func(t *testing.T) {
+		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.ChangePassword")
+		defer span.Finish()
+
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, user.UserID).Return(user, nil)
+		mockAuthRepo.EXPECT().FindByEmail(ctxWithTrace, user).Return(foundUser, nil)
+		mockAuthRepo.EXPECT().UpdatePassword(ctxWithTrace, user.UserID, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, userID uuid.UUID, password string) error {
+				return passwords.Compare(password, "NewPassw0rd")
+			},
+		)
+		mockRedisRepo.EXPECT().DeleteUserCtx(ctxWithTrace, fmt.Sprintf("%s: %s", basePrefix, user.UserID)).Return(nil)
+		mockSessUC.EXPECT().RevokeUserSessions(ctxWithTrace, user.UserID, "").Return(nil)
+		mockRedisRepo.EXPECT().DeleteUserRefreshFamiliesCtx(gomock.Any(), fmt.Sprintf("%s: %s", refreshTokenUserPrefix, user.UserID)).Return(nil)
+
+		err := authUC.ChangePassword(ctx, user.UserID, "current password", "NewPassw0rd")
+		require.NoError(t, err)
+	})
+}
+
+func TestAuthUC_RefreshTokenAfterChangePassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	redisRepo := repository.NewAuthRedisRepo(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	mockSessUC := sessionMock.NewMockUCSession(ctrl)
+	mockModerationUC := moderationMock.NewMockUseCase(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, redisRepo, nil, mockSessUC, mockModerationUC, nil, nil, jwtkeys.NewSecretKeySet(cfg.Server.JwtSecretKey), apiLogger)
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte("current password"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	user := &models.User{
+		UserID: uuid.New(),
+		Email:  "alexander@gmail.com",
+	}
+	foundUser := &models.User{
+		UserID:   user.UserID,
+		Email:    user.Email,
+		Password: string(hashPassword),
+	}
+
+	ctx := context.Background()
+
+	// Two logins start two separate refresh token families
+	mockAuthRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(&models.User{UserID: user.UserID}, nil).Times(2)
+	mockModerationUC.EXPECT().CheckAccount(gomock.Any(), user.UserID).Return(nil).Times(2)
+	first, err := authUC.LoginExternal(ctx, user.UserID)
+	require.NoError(t, err)
+	second, err := authUC.LoginExternal(ctx, user.UserID)
+	require.NoError(t, err)
+
+	mockAuthRepo.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+	mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), user).Return(foundUser, nil)
+	mockAuthRepo.EXPECT().UpdatePassword(gomock.Any(), user.UserID, gomock.Any()).Return(nil)
+	mockSessUC.EXPECT().RevokeUserSessions(gomock.Any(), user.UserID, "").Return(nil)
+
+	err = authUC.ChangePassword(ctx, user.UserID, "current password", "new password")
+	require.NoError(t, err)
+
+	for _, userWithToken := range []*models.UserWithToken{first, second} {
+		refreshed, err := authUC.RefreshToken(ctx, userWithToken.RefreshToken)
+		require.Error(t, err)
+		require.Nil(t, refreshed)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+	}
+}
+
+func TestAuthUC_ProjectUsers(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, nil, nil, nil, nil, nil, nil, jwtkeys.NewSecretKeySet(""), apiLogger)
+
+	phone := "+100000000"
+	city := "Moscow"
+	birthday := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	owner := &models.User{
+		UserID:      uuid.New(),
+		FirstName:   "Alex",
+		LastName:    "Bryksin",
+		Email:       "owner@gmail.com",
+		PhoneNumber: &phone,
+		City:        &city,
+		Birthday:    &birthday,
+		LoginDate:   time.Now(),
+	}
+	other := &models.User{
+		UserID:      uuid.New(),
+		FirstName:   "Other",
+		LastName:    "User",
+		Email:       "other@gmail.com",
+		PhoneNumber: &phone,
+	}
+	privacy := map[uuid.UUID]*models.PrivacySettings{
+		owner.UserID: {ShowLocation: true},
+	}
+
+	ctx := context.Background()
+
+	t.Run("Anonymous", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetPrivacyByUserIDs(gomock.Any(), []uuid.UUID{owner.UserID, other.UserID}).Return(privacy, nil)
+
+		projected, err := authUC.ProjectUsers(ctx, nil, []*models.User{owner, other})
+		require.NoError(t, err)
+		require.Len(t, projected, 2)
+
+		ownerProfile, ok := projected[0].(*models.PublicUser)
+		require.True(t, ok)
+		require.Equal(t, &city, ownerProfile.City)
+		require.Empty(t, ownerProfile.Email)
+		require.Nil(t, ownerProfile.PhoneNumber)
+		require.Nil(t, ownerProfile.Birthday)
+
+		otherProfile, ok := projected[1].(*models.PublicUser)
+		require.True(t, ok)
+		require.Empty(t, otherProfile.Email)
+		require.Nil(t, otherProfile.PhoneNumber)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetPrivacyByUserIDs(gomock.Any(), gomock.Any()).Return(privacy, nil)
+
+		projected, err := authUC.ProjectUsers(ctx, owner, []*models.User{owner, other})
+		require.NoError(t, err)
+
+		self, ok := projected[0].(*models.SelfUser)
+		require.True(t, ok)
+		require.Equal(t, owner.Email, self.Email)
+		require.True(t, self.Privacy.ShowLocation)
+
+		_, ok = projected[1].(*models.PublicUser)
+		require.True(t, ok)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		adminCtx := context.WithValue(ctx, utils.PermissionsCtxKey{}, []string{models.PermissionUsersReadPrivate})
+		mockAuthRepo.EXPECT().GetPrivacyByUserIDs(gomock.Any(), gomock.Any()).Return(privacy, nil)
+
+		projected, err := authUC.ProjectUsers(adminCtx, owner, []*models.User{other})
+		require.NoError(t, err)
+
+		admin, ok := projected[0].(*models.AdminUser)
+		require.True(t, ok)
+		require.Equal(t, other.Email, admin.Email)
+		require.Equal(t, &phone, admin.PhoneNumber)
+		require.False(t, admin.Privacy.ShowEmail)
+	})
 }
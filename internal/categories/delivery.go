@@ -0,0 +1,12 @@
+package categories
+
+import "github.com/labstack/echo/v4"
+
+// Categories HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	GetCategories() echo.HandlerFunc
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/categories"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Categories handlers
+type categoriesHandlers struct {
+	cfg          *config.Config
+	categoriesUC categories.UseCase
+	logger       logger.Logger
+}
+
+// NewCategoriesHandlers Categories handlers constructor
+func NewCategoriesHandlers(cfg *config.Config, categoriesUC categories.UseCase, log logger.Logger) categories.Handlers {
+	return &categoriesHandlers{cfg: cfg, categoriesUC: categoriesUC, logger: log}
+}
+
+// Create godoc
+// @Summary Create category
+// @Description create news category, optionally under parent category, requires categories:manage permission
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Category
+// @Failure 400 {object} httpErrors.RestError
+// @Router /categories [post]
+func (h *categoriesHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "categoriesHandlers.Create")
+		defer span.Finish()
+
+		category := &models.Category{}
+		if err := utils.ReadRequest(c, category); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		createdCategory, err := h.categoriesUC.Create(ctx, category)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdCategory)
+	}
+}
+
+// Update godoc
+// @Summary Update category
+// @Description replace category slug, name, description and parent, omitted parent makes category top level, requires categories:manage permission
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Param category_id path string true "category_id"
+// @Success 200 {object} models.Category
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /categories/{category_id} [put]
+func (h *categoriesHandlers) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "categoriesHandlers.Update")
+		defer span.Finish()
+
+		categoryID, err := uuid.Parse(c.Param("category_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		category := &models.Category{}
+		if err = utils.ReadRequest(c, category); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		category.CategoryID = categoryID
+
+		updatedCategory, err := h.categoriesUC.Update(ctx, category)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, updatedCategory)
+	}
+}
+
+// Delete godoc
+// @Summary Delete category
+// @Description delete category without subcategories, its news become uncategorized, requires categories:manage permission
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Param category_id path string true "category_id"
+// @Success 200 {string} string	"ok"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /categories/{category_id} [delete]
+func (h *categoriesHandlers) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "categoriesHandlers.Delete")
+		defer span.Finish()
+
+		categoryID, err := uuid.Parse(c.Param("category_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.categoriesUC.Delete(ctx, categoryID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// GetByID godoc
+// @Summary Get category
+// @Description get category by id
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Param category_id path string true "category_id"
+// @Success 200 {object} models.Category
+// @Failure 404 {object} httpErrors.RestError
+// @Router /categories/{category_id} [get]
+func (h *categoriesHandlers) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "categoriesHandlers.GetByID")
+		defer span.Finish()
+
+		categoryID, err := uuid.Parse(c.Param("category_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		category, err := h.categoriesUC.GetByID(ctx, categoryID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, category)
+	}
+}
+
+// GetCategories godoc
+// @Summary Get categories
+// @Description get all categories ordered by name, hierarchy is given by parent_id
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Category
+// @Router /categories [get]
+func (h *categoriesHandlers) GetCategories() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "categoriesHandlers.GetCategories")
+		defer span.Finish()
+
+		categoriesList, err := h.categoriesUC.GetCategories(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, categoriesList)
+	}
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/categories"
+	"github.com/AleksK1NG/api-mc/internal/middleware"
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Map categories routes, reading is public and changes require categories:manage permission
+func MapCategoriesRoutes(categoriesGroup *echo.Group, h categories.Handlers, mw *middleware.MiddlewareManager) {
+	manage := mw.RequirePermission(models.PermissionCategoriesManage)
+	categoriesGroup.POST("", h.Create(), mw.AuthSessionMiddleware, manage, mw.CSRF)
+	categoriesGroup.PUT("/:category_id", h.Update(), mw.AuthSessionMiddleware, manage, mw.CSRF)
+	categoriesGroup.DELETE("/:category_id", h.Delete(), mw.AuthSessionMiddleware, manage, mw.CSRF)
+	categoriesGroup.GET("/:category_id", h.GetByID())
+	categoriesGroup.GET("", h.GetCategories())
+}
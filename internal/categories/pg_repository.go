@@ -0,0 +1,22 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package categories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Categories Repository
+type Repository interface {
+	Create(ctx context.Context, category *models.Category) (*models.Category, error)
+	Update(ctx context.Context, category *models.Category) (*models.Category, error)
+	Delete(ctx context.Context, categoryID uuid.UUID) error
+	GetByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Category, error)
+	GetCategories(ctx context.Context) ([]*models.Category, error)
+	GetSubtreeIDs(ctx context.Context, categoryID uuid.UUID) ([]uuid.UUID, error)
+	CountChildren(ctx context.Context, categoryID uuid.UUID) (int, error)
+}
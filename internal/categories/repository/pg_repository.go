@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/categories"
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Categories Repository
+type categoriesRepo struct {
+	db *sqlx.DB
+}
+
+// Categories Repository constructor
+func NewCategoriesRepository(db *sqlx.DB) categories.Repository {
+	return &categoriesRepo{db: db}
+}
+
+// Create category
+func (r *categoriesRepo) Create(ctx context.Context, category *models.Category) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesRepo.Create")
+	defer span.Finish()
+
+	created := &models.Category{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createCategoryQuery,
+		category.Slug,
+		category.Name,
+		category.Description,
+		category.ParentID,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "categoriesRepo.Create.StructScan")
+	}
+
+	return created, nil
+}
+
+// Update all category fields, nil parent makes category top level
+func (r *categoriesRepo) Update(ctx context.Context, category *models.Category) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesRepo.Update")
+	defer span.Finish()
+
+	updated := &models.Category{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		updateCategoryQuery,
+		category.Slug,
+		category.Name,
+		category.Description,
+		category.ParentID,
+		category.CategoryID,
+	).StructScan(updated); err != nil {
+		return nil, errors.Wrap(err, "categoriesRepo.Update.StructScan")
+	}
+
+	return updated, nil
+}
+
+// Delete category, its news become uncategorized
+func (r *categoriesRepo) Delete(ctx context.Context, categoryID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesRepo.Delete")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteCategoryQuery, categoryID)
+	if err != nil {
+		return errors.Wrap(err, "categoriesRepo.Delete.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "categoriesRepo.Delete.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "categoriesRepo.Delete.rowsAffected")
+	}
+
+	return nil
+}
+
+// Get category by id
+func (r *categoriesRepo) GetByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesRepo.GetByID")
+	defer span.Finish()
+
+	category := &models.Category{}
+	if err := r.db.GetContext(ctx, category, getCategoryByIDQuery, categoryID); err != nil {
+		return nil, errors.Wrap(err, "categoriesRepo.GetByID.GetContext")
+	}
+
+	return category, nil
+}
+
+// Get category by slug
+func (r *categoriesRepo) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesRepo.GetBySlug")
+	defer span.Finish()
+
+	category := &models.Category{}
+	if err := r.db.GetContext(ctx, category, getCategoryBySlugQuery, slug); err != nil {
+		return nil, errors.Wrap(err, "categoriesRepo.GetBySlug.GetContext")
+	}
+
+	return category, nil
+}
+
+// Get all categories ordered by name
+func (r *categoriesRepo) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesRepo.GetCategories")
+	defer span.Finish()
+
+	categoriesList := make([]*models.Category, 0)
+	if err := r.db.SelectContext(ctx, &categoriesList, getCategoriesQuery); err != nil {
+		return nil, errors.Wrap(err, "categoriesRepo.GetCategories.SelectContext")
+	}
+
+	return categoriesList, nil
+}
+
+// Get ids of category and all of its descendants
+func (r *categoriesRepo) GetSubtreeIDs(ctx context.Context, categoryID uuid.UUID) ([]uuid.UUID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesRepo.GetSubtreeIDs")
+	defer span.Finish()
+
+	ids := make([]uuid.UUID, 0)
+	if err := r.db.SelectContext(ctx, &ids, getSubtreeIDsQuery, categoryID); err != nil {
+		return nil, errors.Wrap(err, "categoriesRepo.GetSubtreeIDs.SelectContext")
+	}
+
+	return ids, nil
+}
+
+// Count direct subcategories of category
+func (r *categoriesRepo) CountChildren(ctx context.Context, categoryID uuid.UUID) (int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesRepo.CountChildren")
+	defer span.Finish()
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, countChildrenQuery, categoryID); err != nil {
+		return 0, errors.Wrap(err, "categoriesRepo.CountChildren.GetContext")
+	}
+
+	return count, nil
+}
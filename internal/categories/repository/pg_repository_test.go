@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+func TestCategoriesRepo_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	categoriesRepo := NewCategoriesRepository(sqlxDB)
+
+	t.Run("Create", func(t *testing.T) {
+		parentID := uuid.New()
+		category := &models.Category{Slug: "football", Name: "Football", ParentID: &parentID}
+
+		rows := sqlmock.NewRows([]string{"category_id", "slug", "name", "description", "parent_id", "created_at", "updated_at"}).
+			AddRow(uuid.New(), category.Slug, category.Name, nil, parentID, time.Now(), time.Now())
+
+		mock.ExpectQuery(createCategoryQuery).WithArgs(category.Slug, category.Name, category.Description, parentID).WillReturnRows(rows)
+
+		created, err := categoriesRepo.Create(context.Background(), category)
+		require.NoError(t, err)
+		require.Equal(t, category.Slug, created.Slug)
+		require.Equal(t, parentID, *created.ParentID)
+		require.Nil(t, created.Description)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCategoriesRepo_GetSubtreeIDs(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	categoriesRepo := NewCategoriesRepository(sqlxDB)
+
+	t.Run("GetSubtreeIDs", func(t *testing.T) {
+		categoryID := uuid.New()
+		childID := uuid.New()
+
+		rows := sqlmock.NewRows([]string{"category_id"}).AddRow(categoryID).AddRow(childID)
+		mock.ExpectQuery(getSubtreeIDsQuery).WithArgs(categoryID).WillReturnRows(rows)
+
+		ids, err := categoriesRepo.GetSubtreeIDs(context.Background(), categoryID)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{categoryID, childID}, ids)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,36 @@
+package repository
+
+const (
+	createCategoryQuery = `INSERT INTO categories (slug, name, description, parent_id, created_at, updated_at) 
+						VALUES ($1, $2, $3, $4, now(), now()) 
+						RETURNING category_id, slug, name, description, parent_id, created_at, updated_at`
+
+	updateCategoryQuery = `UPDATE categories 
+						SET slug = $1, name = $2, description = $3, parent_id = $4, updated_at = now() 
+						WHERE category_id = $5 
+						RETURNING category_id, slug, name, description, parent_id, created_at, updated_at`
+
+	deleteCategoryQuery = `DELETE FROM categories WHERE category_id = $1`
+
+	getCategoryByIDQuery = `SELECT category_id, slug, name, description, parent_id, created_at, updated_at 
+						FROM categories 
+						WHERE category_id = $1`
+
+	getCategoryBySlugQuery = `SELECT category_id, slug, name, description, parent_id, created_at, updated_at 
+						FROM categories 
+						WHERE slug = $1`
+
+	getCategoriesQuery = `SELECT category_id, slug, name, description, parent_id, created_at, updated_at 
+						FROM categories 
+						ORDER BY name, slug`
+
+	// UNION stops on already visited rows
+	getSubtreeIDsQuery = `WITH RECURSIVE subtree AS (
+							SELECT category_id FROM categories WHERE category_id = $1
+							UNION
+							SELECT c.category_id FROM categories c JOIN subtree s ON c.parent_id = s.category_id
+						)
+						SELECT category_id FROM subtree`
+
+	countChildrenQuery = `SELECT COUNT(category_id) FROM categories WHERE parent_id = $1`
+)
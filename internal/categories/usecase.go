@@ -0,0 +1,19 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package categories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Categories UseCase
+type UseCase interface {
+	Create(ctx context.Context, category *models.Category) (*models.Category, error)
+	Update(ctx context.Context, category *models.Category) (*models.Category, error)
+	Delete(ctx context.Context, categoryID uuid.UUID) error
+	GetByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error)
+	GetCategories(ctx context.Context) ([]*models.Category, error)
+}
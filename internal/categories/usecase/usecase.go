@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/categories"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Categories UseCase
+type categoriesUC struct {
+	cfg            *config.Config
+	categoriesRepo categories.Repository
+	logger         logger.Logger
+}
+
+// Categories UseCase constructor
+func NewCategoriesUseCase(cfg *config.Config, categoriesRepo categories.Repository, log logger.Logger) categories.UseCase {
+	return &categoriesUC{cfg: cfg, categoriesRepo: categoriesRepo, logger: log}
+}
+
+// Create category, slug is unique and parent must exist
+func (u *categoriesUC) Create(ctx context.Context, category *models.Category) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesUC.Create")
+	defer span.Finish()
+
+	if err := u.prepare(ctx, category); err != nil {
+		return nil, err
+	}
+
+	if category.ParentID != nil {
+		if _, err := u.categoriesRepo.GetByID(ctx, *category.ParentID); err != nil {
+			if errors.Cause(err) == sql.ErrNoRows {
+				return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidCategoryParent.Error(), err)
+			}
+			return nil, err
+		}
+	}
+
+	return u.categoriesRepo.Create(ctx, category)
+}
+
+// Update category, parent can not be the category itself or any of its descendants
+func (u *categoriesUC) Update(ctx context.Context, category *models.Category) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesUC.Update")
+	defer span.Finish()
+
+	if _, err := u.categoriesRepo.GetByID(ctx, category.CategoryID); err != nil {
+		return nil, err
+	}
+
+	if err := u.prepare(ctx, category); err != nil {
+		return nil, err
+	}
+
+	if category.ParentID != nil {
+		subtree, err := u.categoriesRepo.GetSubtreeIDs(ctx, category.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range subtree {
+			if id == *category.ParentID {
+				return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidCategoryParent.Error(), nil)
+			}
+		}
+
+		if _, err = u.categoriesRepo.GetByID(ctx, *category.ParentID); err != nil {
+			if errors.Cause(err) == sql.ErrNoRows {
+				return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidCategoryParent.Error(), err)
+			}
+			return nil, err
+		}
+	}
+
+	return u.categoriesRepo.Update(ctx, category)
+}
+
+// Delete category without subcategories, its news become uncategorized
+func (u *categoriesUC) Delete(ctx context.Context, categoryID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesUC.Delete")
+	defer span.Finish()
+
+	children, err := u.categoriesRepo.CountChildren(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.CategoryHasChildren.Error(), nil)
+	}
+
+	return u.categoriesRepo.Delete(ctx, categoryID)
+}
+
+// Get category by id
+func (u *categoriesUC) GetByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesUC.GetByID")
+	defer span.Finish()
+
+	return u.categoriesRepo.GetByID(ctx, categoryID)
+}
+
+// Get all categories, hierarchy is given by parent ids
+func (u *categoriesUC) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoriesUC.GetCategories")
+	defer span.Finish()
+
+	return u.categoriesRepo.GetCategories(ctx)
+}
+
+// Normalize and validate slug, it must not be taken by another category
+func (u *categoriesUC) prepare(ctx context.Context, category *models.Category) error {
+	category.Slug = strings.ToLower(strings.TrimSpace(category.Slug))
+	category.Name = strings.TrimSpace(category.Name)
+
+	if !slugRegexp.MatchString(category.Slug) {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidCategorySlug.Error(), nil)
+	}
+	if category.Name == "" {
+		return httpErrors.NewBadRequestError(errors.New("categoriesUC.prepare category name is empty"))
+	}
+
+	existing, err := u.categoriesRepo.GetBySlug(ctx, category.Slug)
+	if err == nil && existing.CategoryID != category.CategoryID {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.CategoryExists.Error(), nil)
+	}
+	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/categories/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+func TestCategoriesUC_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockCategoriesRepo := mock.NewMockRepository(ctrl)
+	categoriesUC := NewCategoriesUseCase(cfg, mockCategoriesRepo, apiLogger)
+
+	ctx := context.Background()
+
+	t.Run("Create", func(t *testing.T) {
+		parentID := uuid.New()
+		mockCategoriesRepo.EXPECT().GetBySlug(gomock.Any(), "football").Return(nil, sql.ErrNoRows)
+		mockCategoriesRepo.EXPECT().GetByID(gomock.Any(), parentID).Return(&models.Category{CategoryID: parentID}, nil)
+		mockCategoriesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, category *models.Category) (*models.Category, error) {
+				return category, nil
+			},
+		)
+
+		created, err := categoriesUC.Create(ctx, &models.Category{Slug: " Football ", Name: " Football ", ParentID: &parentID})
+		require.NoError(t, err)
+		require.Equal(t, "football", created.Slug)
+		require.Equal(t, "Football", created.Name)
+	})
+
+	t.Run("Invalid slug", func(t *testing.T) {
+		created, err := categoriesUC.Create(ctx, &models.Category{Slug: "foot--ball", Name: "Football"})
+		require.Nil(t, created)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.InvalidCategorySlug.Error())
+	})
+
+	t.Run("Slug exists", func(t *testing.T) {
+		mockCategoriesRepo.EXPECT().GetBySlug(gomock.Any(), "sports").Return(&models.Category{CategoryID: uuid.New()}, nil)
+
+		created, err := categoriesUC.Create(ctx, &models.Category{Slug: "sports", Name: "Sports"})
+		require.Nil(t, created)
+		require.Contains(t, err.Error(), httpErrors.CategoryExists.Error())
+	})
+
+	t.Run("Unknown parent", func(t *testing.T) {
+		parentID := uuid.New()
+		mockCategoriesRepo.EXPECT().GetBySlug(gomock.Any(), "tennis").Return(nil, sql.ErrNoRows)
+		mockCategoriesRepo.EXPECT().GetByID(gomock.Any(), parentID).Return(nil, sql.ErrNoRows)
+
+		created, err := categoriesUC.Create(ctx, &models.Category{Slug: "tennis", Name: "Tennis", ParentID: &parentID})
+		require.Nil(t, created)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.InvalidCategoryParent.Error())
+	})
+}
+
+func TestCategoriesUC_Update(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockCategoriesRepo := mock.NewMockRepository(ctrl)
+	categoriesUC := NewCategoriesUseCase(cfg, mockCategoriesRepo, apiLogger)
+
+	ctx := context.Background()
+	categoryID := uuid.New()
+
+	t.Run("Keeps own slug", func(t *testing.T) {
+		mockCategoriesRepo.EXPECT().GetByID(gomock.Any(), categoryID).Return(&models.Category{CategoryID: categoryID}, nil)
+		mockCategoriesRepo.EXPECT().GetBySlug(gomock.Any(), "sports").Return(&models.Category{CategoryID: categoryID}, nil)
+		mockCategoriesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, category *models.Category) (*models.Category, error) {
+				return category, nil
+			},
+		)
+
+		updated, err := categoriesUC.Update(ctx, &models.Category{CategoryID: categoryID, Slug: "sports", Name: "All sports"})
+		require.NoError(t, err)
+		require.Equal(t, "All sports", updated.Name)
+		require.Nil(t, updated.ParentID)
+	})
+
+	t.Run("Parent is descendant", func(t *testing.T) {
+		descendantID := uuid.New()
+		mockCategoriesRepo.EXPECT().GetByID(gomock.Any(), categoryID).Return(&models.Category{CategoryID: categoryID}, nil)
+		mockCategoriesRepo.EXPECT().GetBySlug(gomock.Any(), "sports").Return(&models.Category{CategoryID: categoryID}, nil)
+		mockCategoriesRepo.EXPECT().GetSubtreeIDs(gomock.Any(), categoryID).Return([]uuid.UUID{categoryID, uuid.New(), descendantID}, nil)
+
+		updated, err := categoriesUC.Update(ctx, &models.Category{CategoryID: categoryID, Slug: "sports", Name: "Sports", ParentID: &descendantID})
+		require.Nil(t, updated)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.InvalidCategoryParent.Error())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		missingID := uuid.New()
+		mockCategoriesRepo.EXPECT().GetByID(gomock.Any(), missingID).Return(nil, sql.ErrNoRows)
+
+		updated, err := categoriesUC.Update(ctx, &models.Category{CategoryID: missingID, Slug: "sports", Name: "Sports"})
+		require.Nil(t, updated)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestCategoriesUC_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockCategoriesRepo := mock.NewMockRepository(ctrl)
+	categoriesUC := NewCategoriesUseCase(cfg, mockCategoriesRepo, apiLogger)
+
+	ctx := context.Background()
+
+	t.Run("Delete", func(t *testing.T) {
+		categoryID := uuid.New()
+		mockCategoriesRepo.EXPECT().CountChildren(gomock.Any(), categoryID).Return(0, nil)
+		mockCategoriesRepo.EXPECT().Delete(gomock.Any(), categoryID).Return(nil)
+
+		require.NoError(t, categoriesUC.Delete(ctx, categoryID))
+	})
+
+	t.Run("Has subcategories", func(t *testing.T) {
+		categoryID := uuid.New()
+		mockCategoriesRepo.EXPECT().CountChildren(gomock.Any(), categoryID).Return(2, nil)
+
+		err := categoriesUC.Delete(ctx, categoryID)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.CategoryHasChildren.Error())
+	})
+}
@@ -7,14 +7,14 @@ const (
 
 	deleteComment = `DELETE FROM comments WHERE comment_id = $1`
 
-	getCommentByID = `SELECT concat(u.first_name, ' ', u.last_name) as author, u.avatar as avatar_url, c.message, c.likes, c.updated_at, c.author_id, c.comment_id	
+	getCommentByID = `SELECT concat(u.first_name, ' ', u.last_name) as author, u.avatars ->> '64' as avatar_url, c.message, c.likes, c.updated_at, c.author_id, c.comment_id	
 						FROM comments c
         				LEFT JOIN users u on c.author_id = u.user_id
 						WHERE c.comment_id = $1`
 
 	getTotalCountByNewsID = `SELECT COUNT(comment_id) FROM comments WHERE news_id = $1`
 
-	getCommentsByNewsID = `SELECT concat(u.first_name, ' ', u.last_name) as author, u.avatar as avatar_url, c.message, c.likes, c.updated_at, c.author_id, c.comment_id
+	getCommentsByNewsID = `SELECT concat(u.first_name, ' ', u.last_name) as author, u.avatars ->> '64' as avatar_url, c.message, c.likes, c.updated_at, c.author_id, c.comment_id
 							FROM comments c
         					LEFT JOIN users u on c.author_id = u.user_id
         					WHERE c.news_id = $1 
@@ -45,7 +45,7 @@ func (u *commentsUC) Update(ctx context.Context, comment *models.Comment) (*mode
 		return nil, err
 	}
 
-	if err = utils.ValidateIsOwner(ctx, comm.AuthorID.String(), u.logger); err != nil {
+	if err = utils.ValidateIsOwner(ctx, comm.AuthorID.String(), u.logger); err != nil && !utils.HasPermission(ctx, models.PermissionCommentsUpdateAny) {
 		return nil, httpErrors.NewRestError(http.StatusForbidden, "Forbidden", errors.Wrap(err, "commentsUC.Update.ValidateIsOwner"))
 	}
 
@@ -67,7 +67,7 @@ func (u *commentsUC) Delete(ctx context.Context, commentID uuid.UUID) error {
 		return err
 	}
 
-	if err = utils.ValidateIsOwner(ctx, comm.AuthorID.String(), u.logger); err != nil {
+	if err = utils.ValidateIsOwner(ctx, comm.AuthorID.String(), u.logger); err != nil && !utils.HasPermission(ctx, models.PermissionCommentsDeleteAny) {
 		return httpErrors.NewRestError(http.StatusForbidden, "Forbidden", errors.Wrap(err, "commentsUC.Delete.ValidateIsOwner"))
 	}
 
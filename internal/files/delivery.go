@@ -0,0 +1,8 @@
+package files
+
+import "github.com/labstack/echo/v4"
+
+// Files HTTP Handlers interface
+type Handlers interface {
+	GetFile() echo.HandlerFunc
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/files"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+const (
+	// Object names are never reused with other content, so clients may cache them for long
+	filesCacheControl = "public, max-age=86400"
+	// Uploaded content is never executed in API origin
+	filesContentSecurityPolicy = "default-src 'none'; sandbox"
+)
+
+// Files handlers
+type filesHandlers struct {
+	cfg        *config.Config
+	fileServer blobstore.FileServer
+	logger     logger.Logger
+}
+
+// NewFilesHandlers Files handlers constructor
+func NewFilesHandlers(cfg *config.Config, fileServer blobstore.FileServer, log logger.Logger) files.Handlers {
+	return &filesHandlers{cfg: cfg, fileServer: fileServer, logger: log}
+}
+
+// GetFile godoc
+// @Summary Get stored file
+// @Description serve public file of filesystem store backend, supports conditional and range requests
+// @Tags Files
+// @Produce octet-stream
+// @Param bucket path string true "bucket"
+// @Param object path string true "object name"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Success 304 {string} string "not modified"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /files/{bucket}/{object} [get]
+func (h *filesHandlers) GetFile() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, _ := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "filesHandlers.GetFile")
+		defer span.Finish()
+
+		file, err := h.fileServer.Open(c.Param("bucket"), c.Param("*"))
+		if err != nil {
+			if errors.Cause(err) == blobstore.ErrNotFound {
+				return c.JSON(httpErrors.ErrorResponse(httpErrors.NewNotFoundError(err)))
+			}
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		defer file.Close()
+
+		header := c.Response().Header()
+		if file.ContentType != "" {
+			header.Set(echo.HeaderContentType, file.ContentType)
+		}
+		if file.ETag != "" {
+			header.Set("ETag", file.ETag)
+		}
+		header.Set("Cache-Control", filesCacheControl)
+		header.Set(echo.HeaderContentSecurityPolicy, filesContentSecurityPolicy)
+
+		// Handles If-None-Match, If-Modified-Since, Range and If-Range using headers set above
+		http.ServeContent(c.Response(), c.Request(), "", file.ModTime, file)
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+func TestFilesHandlers_GetFile(t *testing.T) {
+	t.Parallel()
+
+	root, err := ioutil.TempDir("", "files")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	store, err := blobstore.NewFilesystemStore(root)
+	require.NoError(t, err)
+
+	content := "0123456789"
+	ctx := context.Background()
+	err = store.Put(ctx, "avatars", "uid/1/64.jpg", strings.NewReader(content), int64(len(content)), blobstore.PutOptions{ContentType: "image/jpeg", Public: true})
+	require.NoError(t, err)
+	err = store.Put(ctx, "user-exports", "uid/export.zip", strings.NewReader(content), int64(len(content)), blobstore.PutOptions{ContentType: "application/zip"})
+	require.NoError(t, err)
+
+	apiLogger := logger.NewApiLogger(nil)
+	filesHandlers := NewFilesHandlers(nil, store.(blobstore.FileServer), apiLogger)
+
+	e := echo.New()
+	MapFilesRoutes(e.Group("/api/v1/files"), filesHandlers)
+
+	request := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		res := httptest.NewRecorder()
+		e.ServeHTTP(res, req)
+		return res
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		res := request("/api/v1/files/avatars/uid/1/64.jpg", nil)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, content, res.Body.String())
+		require.Equal(t, "image/jpeg", res.Header().Get(echo.HeaderContentType))
+		require.NotEmpty(t, res.Header().Get("ETag"))
+		require.Equal(t, "bytes", res.Header().Get("Accept-Ranges"))
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		res := request("/api/v1/files/avatars/uid/1/64.jpg", map[string]string{"Range": "bytes=2-5"})
+		require.Equal(t, http.StatusPartialContent, res.Code)
+		require.Equal(t, "2345", res.Body.String())
+		require.Equal(t, "bytes 2-5/10", res.Header().Get("Content-Range"))
+	})
+
+	t.Run("Not modified", func(t *testing.T) {
+		etag := request("/api/v1/files/avatars/uid/1/64.jpg", nil).Header().Get("ETag")
+
+		res := request("/api/v1/files/avatars/uid/1/64.jpg", map[string]string{"If-None-Match": etag})
+		require.Equal(t, http.StatusNotModified, res.Code)
+	})
+
+	t.Run("Private", func(t *testing.T) {
+		res := request("/api/v1/files/user-exports/uid/export.zip", nil)
+		require.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("Path traversal", func(t *testing.T) {
+		res := request("/api/v1/files/avatars/uid/../../.meta/avatars/uid/1/64.jpg.json", nil)
+		require.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		res := request("/api/v1/files/avatars/uid/2/64.jpg", nil)
+		require.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
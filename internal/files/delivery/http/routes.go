@@ -0,0 +1,13 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/files"
+)
+
+// Map files routes
+func MapFilesRoutes(filesGroup *echo.Group, h files.Handlers) {
+	filesGroup.GET("/:bucket/*", h.GetFile())
+	filesGroup.HEAD("/:bucket/*", h.GetFile())
+}
@@ -0,0 +1,12 @@
+package follows
+
+import "github.com/labstack/echo/v4"
+
+// Follows HTTP Handlers interface
+type Handlers interface {
+	Follow() echo.HandlerFunc
+	Unfollow() echo.HandlerFunc
+	GetFollowers() echo.HandlerFunc
+	GetFollowing() echo.HandlerFunc
+	GetCounts() echo.HandlerFunc
+}
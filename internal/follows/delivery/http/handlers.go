@@ -0,0 +1,210 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/follows"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Follows handlers
+type followsHandlers struct {
+	cfg       *config.Config
+	followsUC follows.UseCase
+	logger    logger.Logger
+}
+
+// NewFollowsHandlers Follows handlers constructor
+func NewFollowsHandlers(cfg *config.Config, followsUC follows.UseCase, log logger.Logger) follows.Handlers {
+	return &followsHandlers{cfg: cfg, followsUC: followsUC, logger: log}
+}
+
+// Follow godoc
+// @Summary Follow user
+// @Description follow user, following again is a no-op
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Success 200 {string} string	"ok"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /follows/{user_id} [post]
+func (h *followsHandlers) Follow() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "followsHandlers.Follow")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		followeeID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.followsUC.Follow(ctx, user.UserID, followeeID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// Unfollow godoc
+// @Summary Unfollow user
+// @Description unfollow user
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /follows/{user_id} [delete]
+func (h *followsHandlers) Unfollow() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "followsHandlers.Unfollow")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		followeeID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.followsUC.Unfollow(ctx, user.UserID, followeeID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// GetFollowers godoc
+// @Summary Get followers
+// @Description get followers of user, newest first
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.FollowsList
+// @Failure 400 {object} httpErrors.RestError
+// @Router /follows/{user_id}/followers [get]
+func (h *followsHandlers) GetFollowers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "followsHandlers.GetFollowers")
+		defer span.Finish()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		followersList, err := h.followsUC.GetFollowers(ctx, userID, paginationQuery)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, followersList)
+	}
+}
+
+// GetFollowing godoc
+// @Summary Get following
+// @Description get users followed by user, newest first
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.FollowsList
+// @Failure 400 {object} httpErrors.RestError
+// @Router /follows/{user_id}/following [get]
+func (h *followsHandlers) GetFollowing() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "followsHandlers.GetFollowing")
+		defer span.Finish()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		followingList, err := h.followsUC.GetFollowing(ctx, userID, paginationQuery)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, followingList)
+	}
+}
+
+// GetCounts godoc
+// @Summary Get follow counts
+// @Description get followers and following counts of user
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Success 200 {object} models.FollowCounts
+// @Failure 400 {object} httpErrors.RestError
+// @Router /follows/{user_id}/counts [get]
+func (h *followsHandlers) GetCounts() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "followsHandlers.GetCounts")
+		defer span.Finish()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		counts, err := h.followsUC.GetCounts(ctx, userID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, counts)
+	}
+}
@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/follows"
+	"github.com/AleksK1NG/api-mc/internal/middleware"
+)
+
+// Map follows routes
+func MapFollowsRoutes(followsGroup *echo.Group, h follows.Handlers, mw *middleware.MiddlewareManager) {
+	followsGroup.GET("/:user_id/followers", h.GetFollowers())
+	followsGroup.GET("/:user_id/following", h.GetFollowing())
+	followsGroup.GET("/:user_id/counts", h.GetCounts())
+	followsGroup.POST("/:user_id", h.Follow(), mw.AuthSessionMiddleware, mw.CSRF)
+	followsGroup.DELETE("/:user_id", h.Unfollow(), mw.AuthSessionMiddleware, mw.CSRF)
+}
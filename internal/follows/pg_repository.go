@@ -0,0 +1,20 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package follows
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Follows Repository
+type Repository interface {
+	Follow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error
+	Unfollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error
+	GetFollowers(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error)
+	GetFollowing(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error)
+	GetCounts(ctx context.Context, userID uuid.UUID) (*models.FollowCounts, error)
+}
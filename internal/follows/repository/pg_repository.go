@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/follows"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Follows Repository
+type followsRepo struct {
+	db *sqlx.DB
+}
+
+// Follows Repository constructor
+func NewFollowsRepository(db *sqlx.DB) follows.Repository {
+	return &followsRepo{db: db}
+}
+
+// Follow user, following again is a no-op
+func (r *followsRepo) Follow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followsRepo.Follow")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, followQuery, followerID, followeeID); err != nil {
+		return errors.Wrap(err, "followsRepo.Follow.ExecContext")
+	}
+
+	return nil
+}
+
+// Unfollow user
+func (r *followsRepo) Unfollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followsRepo.Unfollow")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, unfollowQuery, followerID, followeeID)
+	if err != nil {
+		return errors.Wrap(err, "followsRepo.Unfollow.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "followsRepo.Unfollow.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "followsRepo.Unfollow.rowsAffected")
+	}
+
+	return nil
+}
+
+// Get followers of user, newest first
+func (r *followsRepo) GetFollowers(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followsRepo.GetFollowers")
+	defer span.Finish()
+
+	return r.getFollowsList(ctx, getTotalFollowersQuery, getFollowersQuery, userID, pq)
+}
+
+// Get users followed by user, newest first
+func (r *followsRepo) GetFollowing(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followsRepo.GetFollowing")
+	defer span.Finish()
+
+	return r.getFollowsList(ctx, getTotalFollowingQuery, getFollowingQuery, userID, pq)
+}
+
+// Get followers and following counts of user
+func (r *followsRepo) GetCounts(ctx context.Context, userID uuid.UUID) (*models.FollowCounts, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followsRepo.GetCounts")
+	defer span.Finish()
+
+	counts := &models.FollowCounts{}
+	if err := r.db.GetContext(ctx, counts, getCountsQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "followsRepo.GetCounts.GetContext")
+	}
+
+	return counts, nil
+}
+
+func (r *followsRepo) getFollowsList(
+	ctx context.Context,
+	totalQuery string,
+	listQuery string,
+	userID uuid.UUID,
+	pq *utils.PaginationQuery,
+) (*models.FollowsList, error) {
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, totalQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "followsRepo.getFollowsList.GetContext.totalCount")
+	}
+
+	if totalCount == 0 {
+		return &models.FollowsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Users:      make([]*models.Follow, 0),
+		}, nil
+	}
+
+	var usersList = make([]*models.Follow, 0, pq.GetSize())
+	if err := r.db.SelectContext(ctx, &usersList, listQuery, userID, pq.GetOffset(), pq.GetLimit()); err != nil {
+		return nil, errors.Wrap(err, "followsRepo.getFollowsList.SelectContext")
+	}
+
+	return &models.FollowsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Users:      usersList,
+	}, nil
+}
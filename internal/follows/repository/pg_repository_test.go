@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+func TestFollowsRepo_Unfollow(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	followsRepo := NewFollowsRepository(sqlxDB)
+
+	followerID := uuid.New()
+	followeeID := uuid.New()
+
+	t.Run("Unfollow", func(t *testing.T) {
+		mock.ExpectExec(unfollowQuery).WithArgs(followerID, followeeID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := followsRepo.Unfollow(context.Background(), followerID, followeeID)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not following", func(t *testing.T) {
+		mock.ExpectExec(unfollowQuery).WithArgs(followerID, followeeID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := followsRepo.Unfollow(context.Background(), followerID, followeeID)
+		require.Error(t, err)
+		require.Equal(t, sql.ErrNoRows, errors.Cause(err))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestFollowsRepo_GetFollowers(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	followsRepo := NewFollowsRepository(sqlxDB)
+
+	t.Run("GetFollowers", func(t *testing.T) {
+		userID := uuid.New()
+		followerID := uuid.New()
+		pq := &utils.PaginationQuery{Size: 10, Page: 1}
+
+		mock.ExpectQuery(getTotalFollowersQuery).WithArgs(userID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		rows := sqlmock.NewRows([]string{"user_id", "first_name", "last_name", "avatars", "followed_at"}).
+			AddRow(followerID, "Alex", "Bryksin", nil, time.Now())
+		mock.ExpectQuery(getFollowersQuery).WithArgs(userID, 0, 10).WillReturnRows(rows)
+
+		followers, err := followsRepo.GetFollowers(context.Background(), userID, pq)
+		require.NoError(t, err)
+		require.Equal(t, 1, followers.TotalCount)
+		require.Len(t, followers.Users, 1)
+		require.Equal(t, followerID, followers.Users[0].UserID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestFollowsRepo_GetCounts(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	followsRepo := NewFollowsRepository(sqlxDB)
+
+	t.Run("GetCounts", func(t *testing.T) {
+		userID := uuid.New()
+
+		rows := sqlmock.NewRows([]string{"user_id", "followers", "following"}).AddRow(userID, 3, 7)
+		mock.ExpectQuery(getCountsQuery).WithArgs(userID).WillReturnRows(rows)
+
+		counts, err := followsRepo.GetCounts(context.Background(), userID)
+		require.NoError(t, err)
+		require.Equal(t, 3, counts.Followers)
+		require.Equal(t, 7, counts.Following)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,31 @@
+package repository
+
+const (
+	followQuery = `INSERT INTO follows (follower_id, followee_id)
+					VALUES ($1, $2)
+					ON CONFLICT (follower_id, followee_id) DO NOTHING`
+
+	unfollowQuery = `DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2`
+
+	getTotalFollowersQuery = `SELECT COUNT(*) FROM follows WHERE followee_id = $1`
+
+	getFollowersQuery = `SELECT u.user_id, u.first_name, u.last_name, u.avatars, f.created_at AS followed_at
+					FROM follows f
+							 JOIN users u ON u.user_id = f.follower_id
+					WHERE f.followee_id = $1
+					ORDER BY f.created_at DESC, f.follower_id
+					OFFSET $2 LIMIT $3`
+
+	getTotalFollowingQuery = `SELECT COUNT(*) FROM follows WHERE follower_id = $1`
+
+	getFollowingQuery = `SELECT u.user_id, u.first_name, u.last_name, u.avatars, f.created_at AS followed_at
+					FROM follows f
+							 JOIN users u ON u.user_id = f.followee_id
+					WHERE f.follower_id = $1
+					ORDER BY f.created_at DESC, f.followee_id
+					OFFSET $2 LIMIT $3`
+
+	getCountsQuery = `SELECT $1::uuid AS user_id,
+						(SELECT COUNT(*) FROM follows WHERE followee_id = $1) AS followers,
+						(SELECT COUNT(*) FROM follows WHERE follower_id = $1) AS following`
+)
@@ -0,0 +1,20 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package follows
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Follows UseCase
+type UseCase interface {
+	Follow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error
+	Unfollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error
+	GetFollowers(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error)
+	GetFollowing(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error)
+	GetCounts(ctx context.Context, userID uuid.UUID) (*models.FollowCounts, error)
+}
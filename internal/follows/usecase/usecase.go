@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/auth"
+	"github.com/AleksK1NG/api-mc/internal/follows"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Follows UseCase
+type followsUC struct {
+	cfg         *config.Config
+	followsRepo follows.Repository
+	authRepo    auth.Repository
+	logger      logger.Logger
+}
+
+// Follows UseCase constructor
+func NewFollowsUseCase(cfg *config.Config, followsRepo follows.Repository, authRepo auth.Repository, log logger.Logger) follows.UseCase {
+	return &followsUC{cfg: cfg, followsRepo: followsRepo, authRepo: authRepo, logger: log}
+}
+
+// Follow user
+func (u *followsUC) Follow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followsUC.Follow")
+	defer span.Finish()
+
+	if followerID == followeeID {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.FollowSelf.Error(), nil)
+	}
+
+	if _, err := u.authRepo.GetByID(ctx, followeeID); err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return httpErrors.NewRestError(http.StatusNotFound, httpErrors.ErrNoSuchUser, err)
+		}
+		return err
+	}
+
+	return u.followsRepo.Follow(ctx, followerID, followeeID)
+}
+
+// Unfollow user
+func (u *followsUC) Unfollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followsUC.Unfollow")
+	defer span.Finish()
+
+	if err := u.followsRepo.Unfollow(ctx, followerID, followeeID); err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFollowing.Error(), err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Get followers of user
+func (u *followsUC) GetFollowers(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followsUC.GetFollowers")
+	defer span.Finish()
+
+	return u.followsRepo.GetFollowers(ctx, userID, pq)
+}
+
+// Get users followed by user
+func (u *followsUC) GetFollowing(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followsUC.GetFollowing")
+	defer span.Finish()
+
+	return u.followsRepo.GetFollowing(ctx, userID, pq)
+}
+
+// Get followers and following counts of user
+func (u *followsUC) GetCounts(ctx context.Context, userID uuid.UUID) (*models.FollowCounts, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followsUC.GetCounts")
+	defer span.Finish()
+
+	return u.followsRepo.GetCounts(ctx, userID)
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	authMock "github.com/AleksK1NG/api-mc/internal/auth/mock"
+	"github.com/AleksK1NG/api-mc/internal/follows/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+func TestFollowsUC_Follow(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockFollowsRepo := mock.NewMockRepository(ctrl)
+	mockAuthRepo := authMock.NewMockRepository(ctrl)
+	followsUC := NewFollowsUseCase(cfg, mockFollowsRepo, mockAuthRepo, apiLogger)
+
+	ctx := context.Background()
+	followerID := uuid.New()
+	followeeID := uuid.New()
+
+	t.Run("Follow", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), followeeID).Return(&models.User{UserID: followeeID}, nil)
+		mockFollowsRepo.EXPECT().Follow(gomock.Any(), followerID, followeeID).Return(nil)
+
+		err := followsUC.Follow(ctx, followerID, followeeID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Follow self", func(t *testing.T) {
+		err := followsUC.Follow(ctx, followerID, followerID)
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.FollowSelf.Error())
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), followeeID).Return(nil, errors.Wrap(sql.ErrNoRows, "authRepo.GetByID"))
+
+		err := followsUC.Follow(ctx, followerID, followeeID)
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestFollowsUC_Unfollow(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockFollowsRepo := mock.NewMockRepository(ctrl)
+	followsUC := NewFollowsUseCase(cfg, mockFollowsRepo, nil, apiLogger)
+
+	ctx := context.Background()
+	followerID := uuid.New()
+	followeeID := uuid.New()
+
+	t.Run("Not following", func(t *testing.T) {
+		mockFollowsRepo.EXPECT().Unfollow(gomock.Any(), followerID, followeeID).Return(errors.Wrap(sql.ErrNoRows, "followsRepo.Unfollow"))
+
+		err := followsUC.Unfollow(ctx, followerID, followeeID)
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.NotFollowing.Error())
+	})
+}
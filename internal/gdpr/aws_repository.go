@@ -0,0 +1,14 @@
+//go:generate mockgen -source aws_repository.go -destination mock/aws_repository_mock.go -package mock
+package gdpr
+
+import (
+	"context"
+	"io"
+)
+
+// GDPR AWS S3 interface, export archives are private objects
+type AWSRepository interface {
+	PutArchive(ctx context.Context, bucket string, objectName string, archive io.Reader, size int64) error
+	GetObject(ctx context.Context, bucket string, objectName string) (io.ReadCloser, error)
+	RemoveObject(ctx context.Context, bucket string, objectName string) error
+}
@@ -0,0 +1,11 @@
+package gdpr
+
+import "github.com/labstack/echo/v4"
+
+// GDPR HTTP Handlers interface
+type Handlers interface {
+	StartExport() echo.HandlerFunc
+	GetExport() echo.HandlerFunc
+	DownloadExport() echo.HandlerFunc
+	Erase() echo.HandlerFunc
+}
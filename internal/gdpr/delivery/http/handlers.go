@@ -0,0 +1,164 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/gdpr"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// GDPR handlers
+type gdprHandlers struct {
+	cfg    *config.Config
+	gdprUC gdpr.UseCase
+	logger logger.Logger
+}
+
+// NewGDPRHandlers GDPR handlers constructor
+func NewGDPRHandlers(cfg *config.Config, gdprUC gdpr.UseCase, log logger.Logger) gdpr.Handlers {
+	return &gdprHandlers{cfg: cfg, gdprUC: gdprUC, logger: log}
+}
+
+// StartExport godoc
+// @Summary Start data export
+// @Description start building zip archive with profile, news, comments and avatar of current user, required auth session cookie
+// @Tags GDPR
+// @Accept json
+// @Produce json
+// @Success 202 {object} models.ExportJob
+// @Failure 401 {object} httpErrors.RestError
+// @Router /gdpr/export [post]
+func (h *gdprHandlers) StartExport() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "gdprHandlers.StartExport")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		job, err := h.gdprUC.StartExport(ctx, user.UserID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusAccepted, job)
+	}
+}
+
+// GetExport godoc
+// @Summary Get data export
+// @Description get data export job status of current user, required auth session cookie
+// @Tags GDPR
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.ExportJob
+// @Failure 404 {object} httpErrors.RestError
+// @Router /gdpr/export [get]
+func (h *gdprHandlers) GetExport() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "gdprHandlers.GetExport")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		job, err := h.gdprUC.GetExport(ctx, user.UserID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, job)
+	}
+}
+
+// DownloadExport godoc
+// @Summary Download data export
+// @Description download ready data export zip archive of current user, required auth session cookie
+// @Tags GDPR
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 409 {object} httpErrors.RestError
+// @Router /gdpr/export/download [get]
+func (h *gdprHandlers) DownloadExport() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "gdprHandlers.DownloadExport")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		archive, err := h.gdprUC.DownloadExport(ctx, user.UserID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		defer archive.Close()
+
+		fileName := fmt.Sprintf("export-%s.zip", time.Now().UTC().Format("20060102"))
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+
+		return c.Stream(http.StatusOK, "application/zip", archive)
+	}
+}
+
+// Erase godoc
+// @Summary Erase account
+// @Description delete current user after password confirmation, authored news and comments are anonymized or deleted with mode "delete"
+// @Tags GDPR
+// @Accept json
+// @Produce json
+// @Success 200 {string} string	"ok"
+// @Failure 401 {object} httpErrors.RestError
+// @Router /gdpr/account [delete]
+func (h *gdprHandlers) Erase() echo.HandlerFunc {
+	type Erase struct {
+		Password string `json:"password" validate:"required"`
+		Mode     string `json:"mode" validate:"omitempty,oneof=anonymize delete"`
+	}
+
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "gdprHandlers.Erase")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		request := &Erase{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.gdprUC.Erase(ctx, user.UserID, request.Password, request.Mode); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		utils.DeleteSessionCookie(c, h.cfg.Session.Name)
+
+		return c.NoContent(http.StatusOK)
+	}
+}
@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/gdpr"
+	"github.com/AleksK1NG/api-mc/internal/middleware"
+)
+
+// Map GDPR routes, available only with own session, not while impersonating
+func MapGDPRRoutes(gdprGroup *echo.Group, h gdpr.Handlers, mw *middleware.MiddlewareManager) {
+	gdprGroup.Use(mw.AuthSessionMiddleware, mw.DenyImpersonation)
+	gdprGroup.POST("/export", h.StartExport(), mw.CSRF)
+	gdprGroup.GET("/export", h.GetExport())
+	gdprGroup.GET("/export/download", h.DownloadExport())
+	gdprGroup.DELETE("/account", h.Erase(), mw.CSRF)
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package gdpr
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// GDPR Repository, reads and removes content authored by user
+type Repository interface {
+	GetNewsByAuthorID(ctx context.Context, userID uuid.UUID) ([]*models.News, error)
+	GetCommentsByAuthorID(ctx context.Context, userID uuid.UUID) ([]*models.Comment, error)
+	DeleteContentByAuthorID(ctx context.Context, userID uuid.UUID) error
+}
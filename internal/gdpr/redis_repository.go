@@ -0,0 +1,15 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository_mock.go -package mock
+package gdpr
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// GDPR redis repository, stores data export jobs
+type RedisRepository interface {
+	GetExportJobCtx(ctx context.Context, key string) (*models.ExportJob, error)
+	SetExportJobCtx(ctx context.Context, key string, seconds int, job *models.ExportJob) error
+	DeleteExportJobCtx(ctx context.Context, key string) error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"io"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/gdpr"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+)
+
+const archiveContentType = "application/zip"
+
+// GDPR AWS S3 repository
+type gdprAWSRepository struct {
+	store blobstore.BlobStore
+}
+
+// GDPR AWS S3 repository constructor
+func NewGDPRAWSRepository(blobStore blobstore.BlobStore) gdpr.AWSRepository {
+	return &gdprAWSRepository{store: blobStore}
+}
+
+// Upload private export archive, bucket is created on first use
+func (aws *gdprAWSRepository) PutArchive(ctx context.Context, bucket string, objectName string, archive io.Reader, size int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprAWSRepository.PutArchive")
+	defer span.Finish()
+
+	options := blobstore.PutOptions{ContentType: archiveContentType}
+	if err := aws.store.Put(ctx, bucket, objectName, archive, size, options); err != nil {
+		return errors.Wrap(err, "gdprAWSRepository.PutArchive.Put")
+	}
+
+	return nil
+}
+
+// Download object
+func (aws *gdprAWSRepository) GetObject(ctx context.Context, bucket string, objectName string) (io.ReadCloser, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprAWSRepository.GetObject")
+	defer span.Finish()
+
+	object, err := aws.store.Get(ctx, bucket, objectName)
+	if err != nil {
+		return nil, errors.Wrap(err, "gdprAWSRepository.GetObject")
+	}
+
+	return object, nil
+}
+
+// Delete object
+func (aws *gdprAWSRepository) RemoveObject(ctx context.Context, bucket string, objectName string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprAWSRepository.RemoveObject")
+	defer span.Finish()
+
+	if err := aws.store.Remove(ctx, bucket, objectName); err != nil {
+		return errors.Wrap(err, "gdprAWSRepository.RemoveObject")
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/gdpr"
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// GDPR Repository
+type gdprRepo struct {
+	db *sqlx.DB
+}
+
+// GDPR Repository constructor
+func NewGDPRRepository(db *sqlx.DB) gdpr.Repository {
+	return &gdprRepo{db: db}
+}
+
+// Get all news authored by user
+func (r *gdprRepo) GetNewsByAuthorID(ctx context.Context, userID uuid.UUID) ([]*models.News, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprRepo.GetNewsByAuthorID")
+	defer span.Finish()
+
+	news := make([]*models.News, 0)
+	if err := r.db.SelectContext(ctx, &news, getNewsByAuthorIDQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "gdprRepo.GetNewsByAuthorID.SelectContext")
+	}
+
+	return news, nil
+}
+
+// Get all comments authored by user
+func (r *gdprRepo) GetCommentsByAuthorID(ctx context.Context, userID uuid.UUID) ([]*models.Comment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprRepo.GetCommentsByAuthorID")
+	defer span.Finish()
+
+	comments := make([]*models.Comment, 0)
+	if err := r.db.SelectContext(ctx, &comments, getCommentsByAuthorIDQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "gdprRepo.GetCommentsByAuthorID.SelectContext")
+	}
+
+	return comments, nil
+}
+
+// Delete news and comments authored by user, comments of other users under deleted news are removed by cascade
+func (r *gdprRepo) DeleteContentByAuthorID(ctx context.Context, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprRepo.DeleteContentByAuthorID")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "gdprRepo.DeleteContentByAuthorID.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, deleteCommentsByAuthorIDQuery, userID); err != nil {
+		return errors.Wrap(err, "gdprRepo.DeleteContentByAuthorID.deleteComments")
+	}
+
+	if _, err = tx.ExecContext(ctx, deleteNewsByAuthorIDQuery, userID); err != nil {
+		return errors.Wrap(err, "gdprRepo.DeleteContentByAuthorID.deleteNews")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "gdprRepo.DeleteContentByAuthorID.Commit")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGDPRRepo_GetNewsByAuthorID(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	gdprRepo := NewGDPRRepository(sqlxDB)
+
+	t.Run("GetNewsByAuthorID", func(t *testing.T) {
+		userID := uuid.New()
+		rows := sqlmock.NewRows([]string{"news_id", "author_id", "title", "content", "image_url", "category_id", "created_at", "updated_at"}).
+			AddRow(uuid.New(), userID, "Title of news", "Content of news", nil, nil, time.Now(), time.Now())
+
+		mock.ExpectQuery(getNewsByAuthorIDQuery).WithArgs(userID).WillReturnRows(rows)
+
+		news, err := gdprRepo.GetNewsByAuthorID(context.Background(), userID)
+		require.NoError(t, err)
+		require.Len(t, news, 1)
+		require.Equal(t, userID, news[0].AuthorID)
+	})
+}
+
+func TestGDPRRepo_DeleteContentByAuthorID(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	gdprRepo := NewGDPRRepository(sqlxDB)
+
+	t.Run("DeleteContentByAuthorID", func(t *testing.T) {
+		userID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteCommentsByAuthorIDQuery).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(deleteNewsByAuthorIDQuery).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := gdprRepo.DeleteContentByAuthorID(context.Background(), userID)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
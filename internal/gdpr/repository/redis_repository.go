@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/gdpr"
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// GDPR redis repository
+type gdprRedisRepo struct {
+	redisClient *redis.Client
+}
+
+// GDPR redis repository constructor
+func NewGDPRRedisRepo(redisClient *redis.Client) gdpr.RedisRepository {
+	return &gdprRedisRepo{redisClient: redisClient}
+}
+
+// Get data export job
+func (r *gdprRedisRepo) GetExportJobCtx(ctx context.Context, key string) (*models.ExportJob, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprRedisRepo.GetExportJobCtx")
+	defer span.Finish()
+
+	jobBytes, err := r.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "gdprRedisRepo.GetExportJobCtx.redisClient.Get")
+	}
+	job := &models.ExportJob{}
+	if err = json.Unmarshal(jobBytes, job); err != nil {
+		return nil, errors.Wrap(err, "gdprRedisRepo.GetExportJobCtx.json.Unmarshal")
+	}
+
+	return job, nil
+}
+
+// Store data export job with duration in seconds
+func (r *gdprRedisRepo) SetExportJobCtx(ctx context.Context, key string, seconds int, job *models.ExportJob) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprRedisRepo.SetExportJobCtx")
+	defer span.Finish()
+
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		return errors.Wrap(err, "gdprRedisRepo.SetExportJobCtx.json.Marshal")
+	}
+	if err = r.redisClient.Set(ctx, key, jobBytes, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "gdprRedisRepo.SetExportJobCtx.redisClient.Set")
+	}
+
+	return nil
+}
+
+// Delete data export job
+func (r *gdprRedisRepo) DeleteExportJobCtx(ctx context.Context, key string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprRedisRepo.DeleteExportJobCtx")
+	defer span.Finish()
+
+	if err := r.redisClient.Del(ctx, key).Err(); err != nil {
+		return errors.Wrap(err, "gdprRedisRepo.DeleteExportJobCtx.redisClient.Del")
+	}
+
+	return nil
+}
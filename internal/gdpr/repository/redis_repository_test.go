@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/gdpr"
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+func SetupRedis() gdpr.RedisRepository {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	gdprRedisRepo := NewGDPRRedisRepo(client)
+	return gdprRedisRepo
+}
+
+func TestGDPRRedisRepo_ExportJobCtx(t *testing.T) {
+	t.Parallel()
+
+	gdprRedisRepo := SetupRedis()
+
+	t.Run("Set, get and delete", func(t *testing.T) {
+		key := "key"
+		job := &models.ExportJob{
+			JobID:     uuid.New(),
+			UserID:    uuid.New(),
+			Status:    models.ExportPending,
+			CreatedAt: time.Now().UTC().Truncate(time.Second),
+		}
+
+		err := gdprRedisRepo.SetExportJobCtx(context.Background(), key, 10, job)
+		require.NoError(t, err)
+
+		storedJob, err := gdprRedisRepo.GetExportJobCtx(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, job.JobID, storedJob.JobID)
+		require.Equal(t, models.ExportPending, storedJob.Status)
+
+		err = gdprRedisRepo.DeleteExportJobCtx(context.Background(), key)
+		require.NoError(t, err)
+
+		_, err = gdprRedisRepo.GetExportJobCtx(context.Background(), key)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,17 @@
+package repository
+
+const (
+	getNewsByAuthorIDQuery = `SELECT news_id, author_id, title, content, image_url, category_id, created_at, updated_at 
+						FROM news 
+						WHERE author_id = $1 
+						ORDER BY created_at`
+
+	getCommentsByAuthorIDQuery = `SELECT comment_id, author_id, news_id, message, likes, created_at, updated_at 
+						FROM comments 
+						WHERE author_id = $1 
+						ORDER BY created_at`
+
+	deleteCommentsByAuthorIDQuery = `DELETE FROM comments WHERE author_id = $1`
+
+	deleteNewsByAuthorIDQuery = `DELETE FROM news WHERE author_id = $1`
+)
@@ -0,0 +1,19 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package gdpr
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// GDPR UseCase
+type UseCase interface {
+	StartExport(ctx context.Context, userID uuid.UUID) (*models.ExportJob, error)
+	GetExport(ctx context.Context, userID uuid.UUID) (*models.ExportJob, error)
+	DownloadExport(ctx context.Context, userID uuid.UUID) (io.ReadCloser, error)
+	Erase(ctx context.Context, userID uuid.UUID, password string, mode string) error
+}
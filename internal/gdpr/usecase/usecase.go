@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/auth"
+	"github.com/AleksK1NG/api-mc/internal/gdpr"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+const (
+	exportPrefix        = "api-gdpr-export:"
+	defaultExportBucket = "user-exports"
+	defaultExportExpire = 86400
+	// New export is not started while previous one is pending or younger than interval
+	exportInterval = time.Hour
+	exportTimeout  = 5 * time.Minute
+)
+
+// GDPR UseCase
+type gdprUC struct {
+	cfg       *config.Config
+	gdprRepo  gdpr.Repository
+	redisRepo gdpr.RedisRepository
+	awsRepo   gdpr.AWSRepository
+	authRepo  auth.Repository
+	authUC    auth.UseCase
+	logger    logger.Logger
+}
+
+// GDPR UseCase constructor
+func NewGDPRUseCase(
+	cfg *config.Config,
+	gdprRepo gdpr.Repository,
+	redisRepo gdpr.RedisRepository,
+	awsRepo gdpr.AWSRepository,
+	authRepo auth.Repository,
+	authUC auth.UseCase,
+	log logger.Logger,
+) gdpr.UseCase {
+	return &gdprUC{
+		cfg:       cfg,
+		gdprRepo:  gdprRepo,
+		redisRepo: redisRepo,
+		awsRepo:   awsRepo,
+		authRepo:  authRepo,
+		authUC:    authUC,
+		logger:    log,
+	}
+}
+
+// Start building data export archive in background
+func (u *gdprUC) StartExport(ctx context.Context, userID uuid.UUID) (*models.ExportJob, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprUC.StartExport")
+	defer span.Finish()
+
+	existingJob, err := u.redisRepo.GetExportJobCtx(ctx, u.getKeyWithPrefix(userID.String()))
+	if err != nil {
+		u.logger.Errorf("gdprUC.StartExport.GetExportJobCtx: %v", err)
+	}
+	if existingJob != nil {
+		if existingJob.Status == models.ExportPending || time.Since(existingJob.CreatedAt) < exportInterval {
+			return existingJob, nil
+		}
+		if err = u.awsRepo.RemoveObject(ctx, u.getExportBucket(), u.getObjectName(existingJob)); err != nil {
+			u.logger.Errorf("gdprUC.StartExport.RemoveObject: %s", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	job := &models.ExportJob{
+		JobID:     uuid.New(),
+		UserID:    userID,
+		Status:    models.ExportPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Duration(u.getExportExpire()) * time.Second),
+	}
+	if err = u.redisRepo.SetExportJobCtx(ctx, u.getKeyWithPrefix(userID.String()), u.getExportExpire(), job); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "gdprUC.StartExport.SetExportJobCtx"))
+	}
+
+	pendingJob := *job
+	go func() {
+		exportCtx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+		u.runExport(exportCtx, &pendingJob)
+	}()
+
+	return job, nil
+}
+
+// Get data export job of user
+func (u *gdprUC) GetExport(ctx context.Context, userID uuid.UUID) (*models.ExportJob, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprUC.GetExport")
+	defer span.Finish()
+
+	job, err := u.redisRepo.GetExportJobCtx(ctx, u.getKeyWithPrefix(userID.String()))
+	if err != nil {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), errors.Wrap(err, "gdprUC.GetExport.GetExportJobCtx"))
+	}
+
+	return job, nil
+}
+
+// Open ready data export archive
+func (u *gdprUC) DownloadExport(ctx context.Context, userID uuid.UUID) (io.ReadCloser, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprUC.DownloadExport")
+	defer span.Finish()
+
+	job, err := u.GetExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != models.ExportReady {
+		return nil, httpErrors.NewRestError(http.StatusConflict, httpErrors.ExportNotReady.Error(), nil)
+	}
+
+	archive, err := u.awsRepo.GetObject(ctx, u.getExportBucket(), u.getObjectName(job))
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "gdprUC.DownloadExport.GetObject"))
+	}
+
+	return archive, nil
+}
+
+// Erase account after password confirmation, authored content is anonymized or deleted depending on mode
+func (u *gdprUC) Erase(ctx context.Context, userID uuid.UUID, password string, mode string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprUC.Erase")
+	defer span.Finish()
+
+	if mode == "" {
+		mode = models.EraseAnonymize
+	}
+	if mode != models.EraseAnonymize && mode != models.EraseDelete {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidEraseMode.Error(), nil)
+	}
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	foundUser, err := u.authRepo.FindByEmail(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if err = foundUser.ComparePasswords(password); err != nil {
+		return httpErrors.NewUnauthorizedError(errors.Wrap(err, "gdprUC.Erase.ComparePasswords"))
+	}
+
+	if mode == models.EraseDelete {
+		if err = u.gdprRepo.DeleteContentByAuthorID(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	if err = u.authUC.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	job, err := u.redisRepo.GetExportJobCtx(ctx, u.getKeyWithPrefix(userID.String()))
+	if err == nil {
+		if err = u.awsRepo.RemoveObject(ctx, u.getExportBucket(), u.getObjectName(job)); err != nil {
+			u.logger.Errorf("gdprUC.Erase.RemoveObject export: %s", err)
+		}
+		if err = u.redisRepo.DeleteExportJobCtx(ctx, u.getKeyWithPrefix(userID.String())); err != nil {
+			u.logger.Errorf("gdprUC.Erase.DeleteExportJobCtx: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// Build archive and store job result, errors are only logged because nobody waits for the job
+func (u *gdprUC) runExport(ctx context.Context, job *models.ExportJob) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gdprUC.runExport")
+	defer span.Finish()
+
+	job.Status = models.ExportFailed
+
+	archive, err := u.buildArchive(ctx, job.UserID)
+	if err != nil {
+		u.logger.Errorf("gdprUC.runExport.buildArchive UserID: %s, Error: %s", job.UserID, err)
+	} else if err = u.awsRepo.PutArchive(ctx, u.getExportBucket(), u.getObjectName(job), bytes.NewReader(archive), int64(len(archive))); err != nil {
+		u.logger.Errorf("gdprUC.runExport.PutArchive UserID: %s, Error: %s", job.UserID, err)
+	} else {
+		job.Status = models.ExportReady
+	}
+
+	completedAt := time.Now().UTC()
+	job.CompletedAt = &completedAt
+
+	seconds := int(time.Until(job.ExpiresAt).Seconds())
+	if seconds <= 0 {
+		return
+	}
+	if err = u.redisRepo.SetExportJobCtx(ctx, u.getKeyWithPrefix(job.UserID.String()), seconds, job); err != nil {
+		u.logger.Errorf("gdprUC.runExport.SetExportJobCtx: %s", err)
+	}
+}
+
+// Bundle profile, news and comments as json together with avatar object into zip archive
+func (u *gdprUC) buildArchive(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "gdprUC.buildArchive.GetByID")
+	}
+	user.SanitizePassword()
+
+	news, err := u.gdprRepo.GetNewsByAuthorID(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "gdprUC.buildArchive.GetNewsByAuthorID")
+	}
+
+	comments, err := u.gdprRepo.GetCommentsByAuthorID(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "gdprUC.buildArchive.GetCommentsByAuthorID")
+	}
+
+	export := &models.UserExport{
+		Profile:    user,
+		News:       news,
+		Comments:   comments,
+		ExportedAt: time.Now().UTC(),
+	}
+
+	buf := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buf)
+
+	if err = writeJSON(zipWriter, "export.json", export); err != nil {
+		return nil, err
+	}
+
+	for _, object := range u.avatarObjects(user.Avatars) {
+		if err = u.writeObject(ctx, zipWriter, "avatar/"+path.Base(object.name), object.bucket, object.name); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = zipWriter.Close(); err != nil {
+		return nil, errors.Wrap(err, "gdprUC.buildArchive.zipWriter.Close")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (u *gdprUC) writeObject(ctx context.Context, zipWriter *zip.Writer, name string, bucket string, objectName string) error {
+	object, err := u.awsRepo.GetObject(ctx, bucket, objectName)
+	if err != nil {
+		return errors.Wrap(err, "gdprUC.writeObject.GetObject")
+	}
+	defer object.Close()
+
+	data, err := ioutil.ReadAll(object)
+	if err != nil {
+		return errors.Wrap(err, "gdprUC.writeObject.ReadAll")
+	}
+
+	w, err := zipWriter.Create(name)
+	if err != nil {
+		return errors.Wrap(err, "gdprUC.writeObject.zipWriter.Create")
+	}
+	if _, err = w.Write(data); err != nil {
+		return errors.Wrap(err, "gdprUC.writeObject.Write")
+	}
+
+	return nil
+}
+
+func writeJSON(zipWriter *zip.Writer, name string, data interface{}) error {
+	w, err := zipWriter.Create(name)
+	if err != nil {
+		return errors.Wrap(err, "gdprUC.writeJSON.zipWriter.Create")
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(data); err != nil {
+		return errors.Wrap(err, "gdprUC.writeJSON.Encode")
+	}
+
+	return nil
+}
+
+type avatarObject struct {
+	bucket string
+	name   string
+}
+
+// Stored objects of all avatar sizes
+func (u *gdprUC) avatarObjects(avatars models.Avatars) []avatarObject {
+	objects := make([]avatarObject, 0, len(avatars))
+	for _, url := range avatars.URLs() {
+		if bucket, objectName, ok := blobstore.ParseObjectURL(u.cfg, url); ok {
+			objects = append(objects, avatarObject{bucket: bucket, name: objectName})
+		}
+	}
+
+	return objects
+}
+
+func (u *gdprUC) getObjectName(job *models.ExportJob) string {
+	return fmt.Sprintf("%s/%s.zip", job.UserID.String(), job.JobID.String())
+}
+
+func (u *gdprUC) getKeyWithPrefix(userID string) string {
+	return fmt.Sprintf("%s: %s", exportPrefix, userID)
+}
+
+func (u *gdprUC) getExportBucket() string {
+	if u.cfg.GDPR.ExportBucket == "" {
+		return defaultExportBucket
+	}
+	return u.cfg.GDPR.ExportBucket
+}
+
+func (u *gdprUC) getExportExpire() int {
+	if u.cfg.GDPR.ExportExpire <= 0 {
+		return defaultExportExpire
+	}
+	return u.cfg.GDPR.ExportExpire
+}
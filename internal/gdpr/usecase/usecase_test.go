@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	mockAuth "github.com/AleksK1NG/api-mc/internal/auth/mock"
+	"github.com/AleksK1NG/api-mc/internal/gdpr/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/passwords"
+)
+
+func TestGDPRUC_Erase(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AWS: config.AWS{
+			MinioEndpoint: "http://127.0.0.1:9000",
+		},
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockGDPRRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAWSRepo := mock.NewMockAWSRepository(ctrl)
+	mockAuthRepo := mockAuth.NewMockRepository(ctrl)
+	mockAuthUC := mockAuth.NewMockUseCase(ctrl)
+	gdprUC := NewGDPRUseCase(cfg, mockGDPRRepo, mockRedisRepo, mockAWSRepo, mockAuthRepo, mockAuthUC, apiLogger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	avatar := "http://127.0.0.1:9000/minio/avatars/uid-avatar.png"
+	avatars := models.Avatars{"64": avatar, "256": avatar, "512": avatar}
+	user := &models.User{UserID: userID, Email: "email@gmail.com", Avatars: avatars}
+
+	hashed := &models.User{Password: "Password123"}
+	require.NoError(t, hashed.HashPassword(passwords.NewHasher(passwords.Params{})))
+	userWithPassword := &models.User{UserID: userID, Email: user.Email, Password: hashed.Password}
+
+	t.Run("Anonymize", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), user).Return(userWithPassword, nil)
+		mockAuthUC.EXPECT().Delete(gomock.Any(), userID).Return(nil)
+		mockRedisRepo.EXPECT().GetExportJobCtx(gomock.Any(), gomock.Any()).Return(nil, io.EOF)
+
+		err := gdprUC.Erase(ctx, userID, "Password123", "")
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete content", func(t *testing.T) {
+		job := &models.ExportJob{JobID: uuid.New(), UserID: userID, Status: models.ExportReady}
+
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), user).Return(userWithPassword, nil)
+		mockGDPRRepo.EXPECT().DeleteContentByAuthorID(gomock.Any(), userID).Return(nil)
+		mockAuthUC.EXPECT().Delete(gomock.Any(), userID).Return(nil)
+		mockRedisRepo.EXPECT().GetExportJobCtx(gomock.Any(), gomock.Any()).Return(job, nil)
+		mockAWSRepo.EXPECT().RemoveObject(gomock.Any(), defaultExportBucket, userID.String()+"/"+job.JobID.String()+".zip").Return(nil)
+		mockRedisRepo.EXPECT().DeleteExportJobCtx(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := gdprUC.Erase(ctx, userID, "Password123", models.EraseDelete)
+		require.NoError(t, err)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), user).Return(userWithPassword, nil)
+
+		err := gdprUC.Erase(ctx, userID, "wrong", models.EraseAnonymize)
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Unknown mode", func(t *testing.T) {
+		err := gdprUC.Erase(ctx, userID, "Password123", "truncate")
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestGDPRUC_runExport(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AWS: config.AWS{
+			MinioEndpoint: "http://127.0.0.1:9000",
+		},
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockGDPRRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAWSRepo := mock.NewMockAWSRepository(ctrl)
+	mockAuthRepo := mockAuth.NewMockRepository(ctrl)
+	gdprUC := &gdprUC{
+		cfg:       cfg,
+		gdprRepo:  mockGDPRRepo,
+		redisRepo: mockRedisRepo,
+		awsRepo:   mockAWSRepo,
+		authRepo:  mockAuthRepo,
+		logger:    apiLogger,
+	}
+
+	userID := uuid.New()
+	avatars := models.Avatars{
+		"64":  "http://127.0.0.1:9000/minio/avatars/" + userID.String() + "/1/64.jpg",
+		"512": "http://127.0.0.1:9000/minio/avatars/" + userID.String() + "/1/512.jpg",
+	}
+	job := &models.ExportJob{
+		JobID:     uuid.New(),
+		UserID:    userID,
+		Status:    models.ExportPending,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+
+	var archive []byte
+	mockAuthRepo.EXPECT().GetByID(gomock.Any(), userID).Return(&models.User{UserID: userID, Password: "hash", Avatars: avatars}, nil)
+	mockGDPRRepo.EXPECT().GetNewsByAuthorID(gomock.Any(), userID).Return([]*models.News{{AuthorID: userID, Title: "Title"}}, nil)
+	mockGDPRRepo.EXPECT().GetCommentsByAuthorID(gomock.Any(), userID).Return([]*models.Comment{}, nil)
+	mockAWSRepo.EXPECT().GetObject(gomock.Any(), "avatars", userID.String()+"/1/512.jpg").Return(ioutil.NopCloser(bytes.NewReader([]byte("jpg"))), nil)
+	mockAWSRepo.EXPECT().GetObject(gomock.Any(), "avatars", userID.String()+"/1/64.jpg").Return(ioutil.NopCloser(bytes.NewReader([]byte("jpg"))), nil)
+	mockAWSRepo.EXPECT().PutArchive(gomock.Any(), defaultExportBucket, userID.String()+"/"+job.JobID.String()+".zip", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, bucket string, objectName string, r io.Reader, size int64) error {
+			data, err := ioutil.ReadAll(r)
+			archive = data
+			return err
+		},
+	)
+	mockRedisRepo.EXPECT().SetExportJobCtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	gdprUC.runExport(context.Background(), job)
+	require.Equal(t, models.ExportReady, job.Status)
+	require.NotNil(t, job.CompletedAt)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	files := make(map[string]*zip.File)
+	for _, f := range zipReader.File {
+		files[f.Name] = f
+	}
+	require.Contains(t, files, "avatar/64.jpg")
+	require.Contains(t, files, "avatar/512.jpg")
+	require.Contains(t, files, "export.json")
+
+	exportFile, err := files["export.json"].Open()
+	require.NoError(t, err)
+	defer exportFile.Close()
+
+	export := &models.UserExport{}
+	require.NoError(t, json.NewDecoder(exportFile).Decode(export))
+	require.Equal(t, userID, export.Profile.UserID)
+	require.Empty(t, export.Profile.Password)
+	require.Len(t, export.News, 1)
+}
+
+func TestGDPRUC_DownloadExport(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	gdprUC := NewGDPRUseCase(cfg, nil, mockRedisRepo, nil, nil, nil, apiLogger)
+
+	userID := uuid.New()
+
+	t.Run("Not ready", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetExportJobCtx(gomock.Any(), gomock.Any()).Return(&models.ExportJob{Status: models.ExportPending}, nil)
+
+		_, err := gdprUC.DownloadExport(context.Background(), userID)
+		require.Error(t, err)
+		require.Equal(t, http.StatusConflict, httpErrors.ParseErrors(err).Status())
+	})
+}
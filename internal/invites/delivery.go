@@ -0,0 +1,10 @@
+package invites
+
+import "github.com/labstack/echo/v4"
+
+// Invites HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	GetInvites() echo.HandlerFunc
+	Revoke() echo.HandlerFunc
+}
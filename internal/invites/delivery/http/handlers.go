@@ -0,0 +1,139 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/invites"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Invites handlers
+type invitesHandlers struct {
+	cfg       *config.Config
+	invitesUC invites.UseCase
+	logger    logger.Logger
+}
+
+// NewInvitesHandlers Invites handlers constructor
+func NewInvitesHandlers(cfg *config.Config, invitesUC invites.UseCase, log logger.Logger) invites.Handlers {
+	return &invitesHandlers{cfg: cfg, invitesUC: invitesUC, logger: log}
+}
+
+// Create godoc
+// @Summary Create invite
+// @Description create registration invite with max uses, expiration and optional role, code is returned only once, requires invites:manage permission and every permission of invite role
+// @Tags Invites
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.InviteWithCode
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Router /invites [post]
+func (h *invitesHandlers) Create() echo.HandlerFunc {
+	type Create struct {
+		MaxUses   int       `json:"max_uses" validate:"required,gte=1,lte=10000"`
+		ExpiresAt time.Time `json:"expires_at" validate:"required"`
+		Role      *string   `json:"role" validate:"omitempty,lte=32"`
+	}
+
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "invitesHandlers.Create")
+		defer span.Finish()
+
+		admin, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		request := &Create{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		invite, err := h.invitesUC.Create(ctx, &models.Invite{
+			MaxUses:   request.MaxUses,
+			Role:      request.Role,
+			ExpiresAt: request.ExpiresAt,
+			CreatedBy: &admin.UserID,
+		})
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, invite)
+	}
+}
+
+// GetInvites godoc
+// @Summary Get invites
+// @Description get registration invites, newest first, requires invites:manage permission
+// @Tags Invites
+// @Accept json
+// @Produce json
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.InvitesList
+// @Failure 400 {object} httpErrors.RestError
+// @Router /invites [get]
+func (h *invitesHandlers) GetInvites() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "invitesHandlers.GetInvites")
+		defer span.Finish()
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		invitesList, err := h.invitesUC.GetInvites(ctx, paginationQuery)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, invitesList)
+	}
+}
+
+// Revoke godoc
+// @Summary Revoke invite
+// @Description revoke registration invite, users registered with it are not affected, requires invites:manage permission
+// @Tags Invites
+// @Accept json
+// @Produce json
+// @Param invite_id path string true "invite_id"
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /invites/{invite_id} [delete]
+func (h *invitesHandlers) Revoke() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "invitesHandlers.Revoke")
+		defer span.Finish()
+
+		inviteID, err := uuid.Parse(c.Param("invite_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.invitesUC.Revoke(ctx, inviteID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/invites"
+	"github.com/AleksK1NG/api-mc/internal/middleware"
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Map invites admin routes
+func MapInvitesRoutes(invitesGroup *echo.Group, h invites.Handlers, mw *middleware.MiddlewareManager) {
+	invitesGroup.Use(mw.AuthSessionMiddleware, mw.DenyImpersonation, mw.RequirePermission(models.PermissionInvitesManage))
+	invitesGroup.POST("", h.Create(), mw.CSRF)
+	invitesGroup.GET("", h.GetInvites())
+	invitesGroup.DELETE("/:invite_id", h.Revoke(), mw.CSRF)
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package invites
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Invites Repository
+type Repository interface {
+	Create(ctx context.Context, invite *models.Invite) (*models.Invite, error)
+	GetInvites(ctx context.Context, pq *utils.PaginationQuery) (*models.InvitesList, error)
+	Revoke(ctx context.Context, inviteID uuid.UUID) error
+	Reserve(ctx context.Context, codeHash string) (*models.Invite, error)
+	Release(ctx context.Context, inviteID uuid.UUID) error
+	CreateRedemption(ctx context.Context, inviteID uuid.UUID, userID uuid.UUID) error
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/invites"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Invites Repository
+type invitesRepo struct {
+	db *sqlx.DB
+}
+
+// Invites Repository constructor
+func NewInvitesRepository(db *sqlx.DB) invites.Repository {
+	return &invitesRepo{db: db}
+}
+
+// Create invite
+func (r *invitesRepo) Create(ctx context.Context, invite *models.Invite) (*models.Invite, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesRepo.Create")
+	defer span.Finish()
+
+	created := &models.Invite{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createInviteQuery,
+		invite.CodeHash,
+		invite.MaxUses,
+		invite.Role,
+		invite.ExpiresAt,
+		invite.CreatedBy,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "invitesRepo.Create.StructScan")
+	}
+
+	return created, nil
+}
+
+// Get invites, newest first
+func (r *invitesRepo) GetInvites(ctx context.Context, pq *utils.PaginationQuery) (*models.InvitesList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesRepo.GetInvites")
+	defer span.Finish()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getTotalInvitesQuery); err != nil {
+		return nil, errors.Wrap(err, "invitesRepo.GetInvites.GetContext.totalCount")
+	}
+
+	if totalCount == 0 {
+		return &models.InvitesList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Invites:    make([]*models.Invite, 0),
+		}, nil
+	}
+
+	var invitesList = make([]*models.Invite, 0, pq.GetSize())
+	if err := r.db.SelectContext(ctx, &invitesList, getInvitesQuery, pq.GetOffset(), pq.GetLimit()); err != nil {
+		return nil, errors.Wrap(err, "invitesRepo.GetInvites.SelectContext")
+	}
+
+	return &models.InvitesList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Invites:    invitesList,
+	}, nil
+}
+
+// Revoke invite, revoked invites are kept with their redemptions
+func (r *invitesRepo) Revoke(ctx context.Context, inviteID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesRepo.Revoke")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, revokeInviteQuery, inviteID)
+	if err != nil {
+		return errors.Wrap(err, "invitesRepo.Revoke.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "invitesRepo.Revoke.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "invitesRepo.Revoke.rowsAffected")
+	}
+
+	return nil
+}
+
+// Take one use of usable invite by code hash, concurrent registrations can not exceed max uses
+func (r *invitesRepo) Reserve(ctx context.Context, codeHash string) (*models.Invite, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesRepo.Reserve")
+	defer span.Finish()
+
+	invite := &models.Invite{}
+	if err := r.db.QueryRowxContext(ctx, reserveInviteQuery, codeHash).StructScan(invite); err != nil {
+		return nil, errors.Wrap(err, "invitesRepo.Reserve.StructScan")
+	}
+
+	return invite, nil
+}
+
+// Give back reserved use of invite
+func (r *invitesRepo) Release(ctx context.Context, inviteID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesRepo.Release")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, releaseInviteQuery, inviteID); err != nil {
+		return errors.Wrap(err, "invitesRepo.Release.ExecContext")
+	}
+
+	return nil
+}
+
+// Record user registered with invite
+func (r *invitesRepo) CreateRedemption(ctx context.Context, inviteID uuid.UUID, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesRepo.CreateRedemption")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, createRedemptionQuery, inviteID, userID); err != nil {
+		return errors.Wrap(err, "invitesRepo.CreateRedemption.ExecContext")
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+var inviteColumns = []string{
+	"invite_id", "code_hash", "max_uses", "uses", "role", "expires_at", "created_by", "created_at", "revoked_at",
+}
+
+func TestInvitesRepo_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	invitesRepo := NewInvitesRepository(sqlxDB)
+
+	t.Run("Create", func(t *testing.T) {
+		adminID := uuid.New()
+		role := "moderator"
+		invite := &models.Invite{
+			CodeHash:  utils.HashToken("code"),
+			MaxUses:   5,
+			Role:      &role,
+			ExpiresAt: time.Now().Add(time.Hour),
+			CreatedBy: &adminID,
+		}
+
+		rows := sqlmock.NewRows(inviteColumns).AddRow(
+			uuid.New(), invite.CodeHash, invite.MaxUses, 0, role, invite.ExpiresAt, adminID, time.Now(), nil,
+		)
+
+		mock.ExpectQuery(createInviteQuery).WithArgs(
+			invite.CodeHash,
+			invite.MaxUses,
+			invite.Role,
+			invite.ExpiresAt,
+			invite.CreatedBy,
+		).WillReturnRows(rows)
+
+		created, err := invitesRepo.Create(context.Background(), invite)
+		require.NoError(t, err)
+		require.Equal(t, 5, created.MaxUses)
+		require.Equal(t, 0, created.Uses)
+		require.Equal(t, role, *created.Role)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInvitesRepo_Reserve(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	invitesRepo := NewInvitesRepository(sqlxDB)
+
+	t.Run("Reserve", func(t *testing.T) {
+		codeHash := utils.HashToken("code")
+		rows := sqlmock.NewRows(inviteColumns).AddRow(
+			uuid.New(), codeHash, 5, 1, nil, time.Now().Add(time.Hour), nil, time.Now(), nil,
+		)
+
+		mock.ExpectQuery(reserveInviteQuery).WithArgs(codeHash).WillReturnRows(rows)
+
+		invite, err := invitesRepo.Reserve(context.Background(), codeHash)
+		require.NoError(t, err)
+		require.Equal(t, 1, invite.Uses)
+		require.Nil(t, invite.Role)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Used up", func(t *testing.T) {
+		codeHash := utils.HashToken("used")
+
+		mock.ExpectQuery(reserveInviteQuery).WithArgs(codeHash).WillReturnRows(sqlmock.NewRows(inviteColumns))
+
+		invite, err := invitesRepo.Reserve(context.Background(), codeHash)
+		require.Nil(t, invite)
+		require.Equal(t, sql.ErrNoRows, errors.Cause(err))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInvitesRepo_Revoke(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	invitesRepo := NewInvitesRepository(sqlxDB)
+
+	t.Run("Revoke", func(t *testing.T) {
+		inviteID := uuid.New()
+
+		mock.ExpectExec(revokeInviteQuery).WithArgs(inviteID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, invitesRepo.Revoke(context.Background(), inviteID))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already revoked", func(t *testing.T) {
+		inviteID := uuid.New()
+
+		mock.ExpectExec(revokeInviteQuery).WithArgs(inviteID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := invitesRepo.Revoke(context.Background(), inviteID)
+		require.Equal(t, sql.ErrNoRows, errors.Cause(err))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInvitesRepo_GetInvites(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	invitesRepo := NewInvitesRepository(sqlxDB)
+
+	t.Run("GetInvites", func(t *testing.T) {
+		pq := &utils.PaginationQuery{Size: 10, Page: 1}
+
+		mock.ExpectQuery(getTotalInvitesQuery).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(getInvitesQuery).WithArgs(pq.GetOffset(), pq.GetLimit()).WillReturnRows(
+			sqlmock.NewRows(inviteColumns).AddRow(
+				uuid.New(), utils.HashToken("code"), 5, 2, nil, time.Now().Add(time.Hour), nil, time.Now(), nil,
+			),
+		)
+
+		list, err := invitesRepo.GetInvites(context.Background(), pq)
+		require.NoError(t, err)
+		require.Equal(t, 1, list.TotalCount)
+		require.Len(t, list.Invites, 1)
+		require.Equal(t, 2, list.Invites[0].Uses)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
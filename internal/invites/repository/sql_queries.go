@@ -0,0 +1,24 @@
+package repository
+
+const (
+	createInviteQuery = `INSERT INTO invites (code_hash, max_uses, role, expires_at, created_by, created_at) 
+						VALUES ($1, $2, $3, $4, $5, now()) 
+						RETURNING invite_id, code_hash, max_uses, uses, role, expires_at, created_by, created_at, revoked_at`
+
+	getTotalInvitesQuery = `SELECT COUNT(invite_id) FROM invites`
+
+	getInvitesQuery = `SELECT invite_id, code_hash, max_uses, uses, role, expires_at, created_by, created_at, revoked_at 
+						FROM invites 
+						ORDER BY created_at DESC 
+						OFFSET $1 LIMIT $2`
+
+	revokeInviteQuery = `UPDATE invites SET revoked_at = now() WHERE invite_id = $1 AND revoked_at IS NULL`
+
+	reserveInviteQuery = `UPDATE invites SET uses = uses + 1 
+						WHERE code_hash = $1 AND revoked_at IS NULL AND expires_at > now() AND uses < max_uses 
+						RETURNING invite_id, code_hash, max_uses, uses, role, expires_at, created_by, created_at, revoked_at`
+
+	releaseInviteQuery = `UPDATE invites SET uses = uses - 1 WHERE invite_id = $1 AND uses > 0`
+
+	createRedemptionQuery = `INSERT INTO invite_redemptions (invite_id, user_id, redeemed_at) VALUES ($1, $2, now())`
+)
@@ -0,0 +1,21 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package invites
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Invites UseCase
+type UseCase interface {
+	Create(ctx context.Context, invite *models.Invite) (*models.InviteWithCode, error)
+	GetInvites(ctx context.Context, pq *utils.PaginationQuery) (*models.InvitesList, error)
+	Revoke(ctx context.Context, inviteID uuid.UUID) error
+	Redeem(ctx context.Context, code string) (*models.Invite, error)
+	Release(ctx context.Context, inviteID uuid.UUID) error
+	RecordRedemption(ctx context.Context, inviteID uuid.UUID, userID uuid.UUID) error
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/invites"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/rbac"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+const inviteCodeBytes = 16
+
+// Invites UseCase
+type invitesUC struct {
+	cfg         *config.Config
+	invitesRepo invites.Repository
+	rbacRepo    rbac.Repository
+	logger      logger.Logger
+}
+
+// Invites UseCase constructor
+func NewInvitesUseCase(cfg *config.Config, invitesRepo invites.Repository, rbacRepo rbac.Repository, log logger.Logger) invites.UseCase {
+	return &invitesUC{cfg: cfg, invitesRepo: invitesRepo, rbacRepo: rbacRepo, logger: log}
+}
+
+// Create invite, plain code is returned only here, invite role can not have permissions creator does not hold
+func (u *invitesUC) Create(ctx context.Context, invite *models.Invite) (*models.InviteWithCode, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesUC.Create")
+	defer span.Finish()
+
+	if !invite.ExpiresAt.After(time.Now()) {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidInviteExpiry.Error(), nil)
+	}
+
+	if invite.Role != nil {
+		roleName := strings.ToLower(strings.TrimSpace(*invite.Role))
+		role, err := u.rbacRepo.GetRoleByName(ctx, roleName)
+		if err != nil {
+			if errors.Cause(err) == sql.ErrNoRows {
+				return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.UnknownRole.Error(), err)
+			}
+			return nil, err
+		}
+
+		// Invite must not grant more than its creator holds, otherwise invites could be used to escalate privileges
+		permissions, err := u.rbacRepo.GetRolePermissions(ctx, role.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, permission := range permissions {
+			if !utils.HasPermission(ctx, permission) {
+				return nil, httpErrors.NewRestError(http.StatusForbidden, httpErrors.InvitePrivilegedRole.Error(), nil)
+			}
+		}
+		invite.Role = &role.Name
+	}
+
+	code, err := utils.GenerateRandomToken(inviteCodeBytes)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "invitesUC.Create.GenerateRandomToken"))
+	}
+
+	createdInvite, err := u.invitesRepo.Create(ctx, &models.Invite{
+		CodeHash:  utils.HashToken(code),
+		MaxUses:   invite.MaxUses,
+		Role:      invite.Role,
+		ExpiresAt: invite.ExpiresAt,
+		CreatedBy: invite.CreatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.InviteWithCode{Invite: createdInvite, Code: code}, nil
+}
+
+// Get invites
+func (u *invitesUC) GetInvites(ctx context.Context, pq *utils.PaginationQuery) (*models.InvitesList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesUC.GetInvites")
+	defer span.Finish()
+
+	return u.invitesRepo.GetInvites(ctx, pq)
+}
+
+// Revoke invite, users already registered with it are not affected
+func (u *invitesUC) Revoke(ctx context.Context, inviteID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesUC.Revoke")
+	defer span.Finish()
+
+	if err := u.invitesRepo.Revoke(ctx, inviteID); err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return httpErrors.NewNotFoundError(err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Take one use of invite by plain code, must be released if registration fails
+func (u *invitesUC) Redeem(ctx context.Context, code string) (*models.Invite, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesUC.Redeem")
+	defer span.Finish()
+
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, httpErrors.NewRestError(http.StatusForbidden, httpErrors.InviteRequired.Error(), nil)
+	}
+
+	invite, err := u.invitesRepo.Reserve(ctx, utils.HashToken(code))
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, httpErrors.NewRestError(http.StatusForbidden, httpErrors.InvalidInvite.Error(), nil)
+		}
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+// Give back use of invite taken by Redeem
+func (u *invitesUC) Release(ctx context.Context, inviteID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesUC.Release")
+	defer span.Finish()
+
+	return u.invitesRepo.Release(ctx, inviteID)
+}
+
+// Record which invite user registered with
+func (u *invitesUC) RecordRedemption(ctx context.Context, inviteID uuid.UUID, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "invitesUC.RecordRedemption")
+	defer span.Finish()
+
+	return u.invitesRepo.CreateRedemption(ctx, inviteID, userID)
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/invites/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	rbacMock "github.com/AleksK1NG/api-mc/internal/rbac/mock"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+func TestInvitesUC_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockInvitesRepo := mock.NewMockRepository(ctrl)
+	mockRBACRepo := rbacMock.NewMockRepository(ctrl)
+	invitesUC := NewInvitesUseCase(cfg, mockInvitesRepo, mockRBACRepo, apiLogger)
+
+	ctx := context.WithValue(context.Background(), utils.PermissionsCtxKey{}, []string{models.PermissionInvitesManage})
+	adminID := uuid.New()
+
+	t.Run("Create", func(t *testing.T) {
+		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "invitesUC.Create")
+		defer span.Finish()
+
+		role := " Moderator "
+		mockRBACRepo.EXPECT().GetRoleByName(ctxWithTrace, "moderator").Return(&models.Role{Name: "moderator"}, nil)
+		mockRBACRepo.EXPECT().GetRolePermissions(ctxWithTrace, "moderator").Return([]string{models.PermissionInvitesManage}, nil)
+
+		var stored *models.Invite
+		mockInvitesRepo.EXPECT().Create(ctxWithTrace, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, invite *models.Invite) (*models.Invite, error) {
+				stored = invite
+				return invite, nil
+			},
+		)
+
+		created, err := invitesUC.Create(ctx, &models.Invite{
+			MaxUses:   3,
+			Role:      &role,
+			ExpiresAt: time.Now().Add(time.Hour),
+			CreatedBy: &adminID,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, created.Code)
+		require.Equal(t, utils.HashToken(created.Code), stored.CodeHash)
+		require.Equal(t, "moderator", *stored.Role)
+		require.Equal(t, 3, stored.MaxUses)
+	})
+
+	t.Run("Privileged role", func(t *testing.T) {
+		role := models.RoleAdmin
+		mockRBACRepo.EXPECT().GetRoleByName(gomock.Any(), models.RoleAdmin).Return(&models.Role{Name: models.RoleAdmin}, nil)
+		mockRBACRepo.EXPECT().GetRolePermissions(gomock.Any(), models.RoleAdmin).
+			Return([]string{models.PermissionInvitesManage, models.PermissionRolesManage}, nil)
+
+		created, err := invitesUC.Create(ctx, &models.Invite{
+			MaxUses:   1,
+			Role:      &role,
+			ExpiresAt: time.Now().Add(time.Hour),
+			CreatedBy: &adminID,
+		})
+		require.Nil(t, created)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		role := "owner"
+		mockRBACRepo.EXPECT().GetRoleByName(gomock.Any(), "owner").Return(nil, sql.ErrNoRows)
+
+		created, err := invitesUC.Create(ctx, &models.Invite{
+			MaxUses:   1,
+			Role:      &role,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		require.Nil(t, created)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.UnknownRole.Error())
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		created, err := invitesUC.Create(ctx, &models.Invite{
+			MaxUses:   1,
+			ExpiresAt: time.Now().Add(-time.Hour),
+		})
+		require.Nil(t, created)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestInvitesUC_Redeem(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockInvitesRepo := mock.NewMockRepository(ctrl)
+	invitesUC := NewInvitesUseCase(cfg, mockInvitesRepo, nil, apiLogger)
+
+	ctx := context.Background()
+
+	t.Run("Redeem", func(t *testing.T) {
+		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "invitesUC.Redeem")
+		defer span.Finish()
+
+		invite := &models.Invite{InviteID: uuid.New(), MaxUses: 2, Uses: 1}
+		mockInvitesRepo.EXPECT().Reserve(ctxWithTrace, utils.HashToken("code")).Return(invite, nil)
+
+		redeemed, err := invitesUC.Redeem(ctx, " code ")
+		require.NoError(t, err)
+		require.Equal(t, invite.InviteID, redeemed.InviteID)
+	})
+
+	t.Run("Missing code", func(t *testing.T) {
+		redeemed, err := invitesUC.Redeem(ctx, "")
+		require.Nil(t, redeemed)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.InviteRequired.Error())
+	})
+
+	t.Run("Used up", func(t *testing.T) {
+		mockInvitesRepo.EXPECT().Reserve(gomock.Any(), utils.HashToken("used")).Return(nil, sql.ErrNoRows)
+
+		redeemed, err := invitesUC.Redeem(ctx, "used")
+		require.Nil(t, redeemed)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.InvalidInvite.Error())
+	})
+}
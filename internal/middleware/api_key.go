@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+const apiKeyAuthScheme = "ApiKey "
+
+// Authenticate with "Authorization: ApiKey <key>" header having required scope, falls back to session cookie
+func (mw *MiddlewareManager) AuthSessionOrAPIKeyMiddleware(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		sessionNext := mw.AuthSessionMiddleware(next)
+
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if !strings.HasPrefix(header, apiKeyAuthScheme) {
+				return sessionNext(c)
+			}
+
+			apiKey, err := mw.apiKeysUC.Authenticate(c.Request().Context(), strings.TrimPrefix(header, apiKeyAuthScheme))
+			if err != nil {
+				mw.logger.Errorf("AuthSessionOrAPIKeyMiddleware RequestID: %s, Error: %s",
+					utils.GetRequestID(c),
+					err.Error(),
+				)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+
+			if !apiKey.Scopes.Has(scope) {
+				mw.logger.Errorf("AuthSessionOrAPIKeyMiddleware RequestID: %s, KeyID: %s, Error: missing scope %s",
+					utils.GetRequestID(c),
+					apiKey.KeyID.String(),
+					scope,
+				)
+				return c.JSON(http.StatusForbidden, httpErrors.NewRestError(http.StatusForbidden, httpErrors.MissingAPIKeyScope.Error(), nil))
+			}
+
+			user, err := mw.authUC.GetByID(c.Request().Context(), apiKey.UserID)
+			if err != nil {
+				mw.logger.Errorf("GetByID RequestID: %s, Error: %s",
+					utils.GetRequestID(c),
+					err.Error(),
+				)
+				return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			}
+
+			if err = mw.moderationUC.CheckAccount(c.Request().Context(), user.UserID); err != nil {
+				mw.logger.Errorf("CheckAccount RequestID: %s, UserID: %s, Error: %s",
+					utils.GetRequestID(c),
+					user.UserID.String(),
+					err.Error(),
+				)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+
+			c.Set("api_key", apiKey)
+			c.Set("user", user)
+
+			ctx := context.WithValue(c.Request().Context(), utils.UserCtxKey{}, user)
+			c.SetRequest(c.Request().WithContext(ctx))
+			mw.setPermissions(c, user)
+
+			return next(c)
+		}
+	}
+}
+
+// Requests authenticated by API key do not carry browser cookies, so they are not exposed to CSRF
+func isAPIKeyRequest(c echo.Context) bool {
+	_, ok := c.Get("api_key").(*models.APIKey)
+	return ok
+}
@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -55,12 +54,29 @@ func (mw *MiddlewareManager) AuthSessionMiddleware(next echo.HandlerFunc) echo.H
 			return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
 		}
 
+		if err = mw.moderationUC.CheckAccount(c.Request().Context(), user.UserID); err != nil {
+			mw.logger.Errorf("CheckAccount RequestID: %s, UserID: %s, Error: %s",
+				utils.GetRequestID(c),
+				user.UserID.String(),
+				err.Error(),
+			)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = mw.sessUC.TouchSession(c.Request().Context(), sid, sess, c.RealIP()); err != nil {
+			mw.logger.Errorf("TouchSession RequestID: %s, Error: %s",
+				utils.GetRequestID(c),
+				err.Error(),
+			)
+		}
+
 		c.Set("sid", sid)
 		c.Set("uid", sess.SessionID)
 		c.Set("user", user)
 
 		ctx := context.WithValue(c.Request().Context(), utils.UserCtxKey{}, user)
 		c.SetRequest(c.Request().WithContext(ctx))
+		mw.setPermissions(c, user)
 
 		mw.logger.Info(
 			"SessionMiddleware, RequestID: %s,  IP: %s, UserID: %s, CookieSessionID: %s",
@@ -70,6 +86,56 @@ func (mw *MiddlewareManager) AuthSessionMiddleware(next echo.HandlerFunc) echo.H
 			cookie.Value,
 		)
 
+		if sess.ImpersonatorID != nil {
+			c.Set("impersonator_id", *sess.ImpersonatorID)
+			err = next(c)
+			mw.auditImpersonatedRequest(c, sess, err)
+			return err
+		}
+
+		return next(c)
+	}
+}
+
+// Auth sessions middleware for public routes, sets session user when valid session cookie is present
+// and passes anonymous requests and requests with invalid sessions through without user
+func (mw *MiddlewareManager) OptionalAuthSessionMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cookie, err := c.Cookie(mw.cfg.Session.Name)
+		if err != nil {
+			return next(c)
+		}
+
+		sess, err := mw.sessUC.GetSessionByID(c.Request().Context(), cookie.Value)
+		if err != nil {
+			return next(c)
+		}
+
+		user, err := mw.authUC.GetByID(c.Request().Context(), sess.UserID)
+		if err != nil {
+			mw.logger.Errorf("GetByID RequestID: %s, Error: %s", utils.GetRequestID(c), err.Error())
+			return next(c)
+		}
+
+		if err = mw.moderationUC.CheckAccount(c.Request().Context(), user.UserID); err != nil {
+			return next(c)
+		}
+
+		c.Set("sid", cookie.Value)
+		c.Set("uid", sess.SessionID)
+		c.Set("user", user)
+
+		ctx := context.WithValue(c.Request().Context(), utils.UserCtxKey{}, user)
+		c.SetRequest(c.Request().WithContext(ctx))
+		mw.setPermissions(c, user)
+
+		if sess.ImpersonatorID != nil {
+			c.Set("impersonator_id", *sess.ImpersonatorID)
+			err = next(c)
+			mw.auditImpersonatedRequest(c, sess, err)
+			return err
+		}
+
 		return next(c)
 	}
 }
@@ -114,18 +180,28 @@ func (mw *MiddlewareManager) AuthJWTMiddleware(authUC auth.UseCase, cfg *config.
 	}
 }
 
-// Admin role
-func (mw *MiddlewareManager) AdminMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+// Reject users with unconfirmed email when verification is required in config, using ctx user
+func (mw *MiddlewareManager) VerifiedEmailMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		if !mw.cfg.Auth.RequireEmailVerification {
+			return next(c)
+		}
+
 		user, ok := c.Get("user").(*models.User)
-		if !ok || *user.Role != "admin" {
-			return c.JSON(http.StatusForbidden, httpErrors.NewUnauthorizedError(httpErrors.PermissionDenied))
+		if !ok {
+			mw.logger.Errorf("Error c.Get(user) RequestID: %s, ERROR: %s,", utils.GetRequestID(c), "invalid user ctx")
+			return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		if user.EmailVerifiedAt == nil {
+			return c.JSON(http.StatusForbidden, httpErrors.NewRestError(http.StatusForbidden, httpErrors.EmailNotVerified.Error(), nil))
 		}
+
 		return next(c)
 	}
 }
 
-// Role based auth middleware, using ctx user
+// Allow access to own user or with users:update:any permission, using ctx user
 func (mw *MiddlewareManager) OwnerOrAdminMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -135,7 +211,7 @@ func (mw *MiddlewareManager) OwnerOrAdminMiddleware() echo.MiddlewareFunc {
 				return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
 			}
 
-			if *user.Role == "admin" {
+			if utils.HasPermission(c.Request().Context(), models.PermissionUsersUpdateAny) {
 				return next(c)
 			}
 
@@ -153,49 +229,13 @@ func (mw *MiddlewareManager) OwnerOrAdminMiddleware() echo.MiddlewareFunc {
 	}
 }
 
-// Role based auth middleware, using ctx user
-func (mw *MiddlewareManager) RoleBasedAuthMiddleware(roles []string) echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			user, ok := c.Get("user").(*models.User)
-			if !ok {
-				mw.logger.Errorf("Error c.Get(user) RequestID: %s, UserID: %s, ERROR: %s,",
-					utils.GetRequestID(c),
-					user.UserID.String(),
-					"invalid user ctx",
-				)
-				return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
-			}
-
-			for _, role := range roles {
-				if role == *user.Role {
-					return next(c)
-				}
-			}
-
-			mw.logger.Errorf("Error c.Get(user) RequestID: %s, UserID: %s, ERROR: %s,",
-				utils.GetRequestID(c),
-				user.UserID.String(),
-				"invalid user ctx",
-			)
-
-			return c.JSON(http.StatusForbidden, httpErrors.NewForbiddenError(httpErrors.PermissionDenied))
-		}
-	}
-}
-
 func (mw *MiddlewareManager) validateJWTToken(tokenString string, authUC auth.UseCase, c echo.Context, cfg *config.Config) error {
 	if tokenString == "" {
 		return httpErrors.InvalidJWTToken
 	}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signin method %v", token.Header["alg"])
-		}
-		secret := []byte(cfg.Server.JwtSecretKey)
-		return secret, nil
-	})
+	// Verification key is picked by kid header, so tokens signed with rotated out keys stay valid until removed
+	token, err := jwt.Parse(tokenString, mw.jwtKeys.Keyfunc)
 	if err != nil {
 		return err
 	}
@@ -205,6 +245,11 @@ func (mw *MiddlewareManager) validateJWTToken(tokenString string, authUC auth.Us
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		// Same keys sign other tokens with user id, like email verification links
+		if !claims.VerifyAudience(utils.AccessTokenAudience, true) {
+			return httpErrors.InvalidJWTClaims
+		}
+
 		userID, ok := claims["id"].(string)
 		if !ok {
 			return httpErrors.InvalidJWTClaims
@@ -220,6 +265,10 @@ func (mw *MiddlewareManager) validateJWTToken(tokenString string, authUC auth.Us
 			return err
 		}
 
+		if err = mw.moderationUC.CheckAccount(c.Request().Context(), u.UserID); err != nil {
+			return err
+		}
+
 		c.Set("user", u)
 
 		ctx := context.WithValue(c.Request().Context(), utils.UserCtxKey{}, u)
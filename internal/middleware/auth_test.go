@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	authMock "github.com/AleksK1NG/api-mc/internal/auth/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	moderationMock "github.com/AleksK1NG/api-mc/internal/moderation/mock"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+func TestMiddlewareManager_AuthJWTMiddleware(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			JwtSecretKey: "secret",
+		},
+		Logger: config.Logger{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAuthUC := authMock.NewMockUseCase(ctrl)
+	mockModerationUC := moderationMock.NewMockUseCase(ctrl)
+	jwtKeys, err := utils.NewJWTKeySet(cfg)
+	require.NoError(t, err)
+
+	mw := NewMiddlewareManager(nil, mockAuthUC, nil, nil, mockModerationUC, nil, jwtKeys, cfg, []string{"*"}, apiLogger)
+	handler := mw.AuthJWTMiddleware(mockAuthUC, cfg)(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	user := &models.User{UserID: uuid.New(), Email: "alex@gmail.com"}
+
+	serve := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/me", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(echo.New().NewContext(req, rec)))
+		return rec.Code
+	}
+
+	t.Run("Access token", func(t *testing.T) {
+		token, err := utils.GenerateJWTToken(user, cfg, jwtKeys)
+		require.NoError(t, err)
+
+		mockAuthUC.EXPECT().GetByID(gomock.Any(), user.UserID).Return(user, nil)
+		mockModerationUC.EXPECT().CheckAccount(gomock.Any(), user.UserID).Return(nil)
+
+		require.Equal(t, http.StatusOK, serve(token))
+	})
+
+	t.Run("Email verification token", func(t *testing.T) {
+		token, err := utils.GenerateEmailVerificationToken(user, cfg, jwtKeys)
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusUnauthorized, serve(token))
+	})
+}
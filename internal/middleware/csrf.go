@@ -13,7 +13,7 @@ import (
 // CSRF Middleware
 func (mw *MiddlewareManager) CSRF(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(ctx echo.Context) error {
-		if !mw.cfg.Server.CSRF {
+		if !mw.cfg.Server.CSRF || isAPIKeyRequest(ctx) {
 			return next(ctx)
 		}
 
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+const maxAuditPathLength = 512
+
+// Reject sensitive actions for impersonation sessions, must run after auth middleware
+func (mw *MiddlewareManager) DenyImpersonation(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if impersonatorID, ok := c.Get("impersonator_id").(uuid.UUID); ok {
+			mw.logger.Errorf("DenyImpersonation RequestID: %s, ImpersonatorID: %s, Path: %s",
+				utils.GetRequestID(c),
+				impersonatorID.String(),
+				c.Request().URL.Path,
+			)
+			return c.JSON(http.StatusForbidden, httpErrors.NewRestError(http.StatusForbidden, httpErrors.ImpersonationDenied.Error(), nil))
+		}
+
+		return next(c)
+	}
+}
+
+// Write request made with impersonation session to audit log, failures are only logged as response is already sent
+func (mw *MiddlewareManager) auditImpersonatedRequest(c echo.Context, sess *models.Session, handlerErr error) {
+	status := c.Response().Status
+	if handlerErr != nil && !c.Response().Committed {
+		status = http.StatusInternalServerError
+		if he, ok := handlerErr.(*echo.HTTPError); ok {
+			status = he.Code
+		}
+	}
+
+	path := c.Request().URL.Path
+	if len(path) > maxAuditPathLength {
+		path = path[:maxAuditPathLength]
+	}
+
+	if err := mw.auditUC.Record(c.Request().Context(), &models.AuditEntry{
+		ActorID:   sess.ImpersonatorID,
+		UserID:    &sess.UserID,
+		Action:    models.AuditImpersonationRequest,
+		Method:    c.Request().Method,
+		Path:      path,
+		Status:    status,
+		IP:        c.RealIP(),
+		RequestID: utils.GetRequestID(c),
+	}); err != nil {
+		mw.logger.Errorf("auditImpersonatedRequest RequestID: %s, ImpersonatorID: %s, Error: %s",
+			utils.GetRequestID(c),
+			sess.ImpersonatorID.String(),
+			err.Error(),
+		)
+	}
+}
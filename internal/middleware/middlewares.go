@@ -2,21 +2,53 @@ package middleware
 
 import (
 	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/apikeys"
+	"github.com/AleksK1NG/api-mc/internal/audit"
 	"github.com/AleksK1NG/api-mc/internal/auth"
+	"github.com/AleksK1NG/api-mc/internal/moderation"
+	"github.com/AleksK1NG/api-mc/internal/rbac"
 	"github.com/AleksK1NG/api-mc/internal/session"
+	"github.com/AleksK1NG/api-mc/pkg/jwtkeys"
 	"github.com/AleksK1NG/api-mc/pkg/logger"
 )
 
 // Middleware manager
 type MiddlewareManager struct {
-	sessUC  session.UCSession
-	authUC  auth.UseCase
-	cfg     *config.Config
-	origins []string
-	logger  logger.Logger
+	sessUC       session.UCSession
+	authUC       auth.UseCase
+	apiKeysUC    apikeys.UseCase
+	rbacUC       rbac.UseCase
+	moderationUC moderation.UseCase
+	auditUC      audit.UseCase
+	jwtKeys      *jwtkeys.KeySet
+	cfg          *config.Config
+	origins      []string
+	logger       logger.Logger
 }
 
 // Middleware manager constructor
-func NewMiddlewareManager(sessUC session.UCSession, authUC auth.UseCase, cfg *config.Config, origins []string, logger logger.Logger) *MiddlewareManager {
-	return &MiddlewareManager{sessUC: sessUC, authUC: authUC, cfg: cfg, origins: origins, logger: logger}
+func NewMiddlewareManager(
+	sessUC session.UCSession,
+	authUC auth.UseCase,
+	apiKeysUC apikeys.UseCase,
+	rbacUC rbac.UseCase,
+	moderationUC moderation.UseCase,
+	auditUC audit.UseCase,
+	jwtKeys *jwtkeys.KeySet,
+	cfg *config.Config,
+	origins []string,
+	logger logger.Logger,
+) *MiddlewareManager {
+	return &MiddlewareManager{
+		sessUC:       sessUC,
+		authUC:       authUC,
+		apiKeysUC:    apiKeysUC,
+		rbacUC:       rbacUC,
+		moderationUC: moderationUC,
+		auditUC:      auditUC,
+		jwtKeys:      jwtKeys,
+		cfg:          cfg,
+		origins:      origins,
+		logger:       logger,
+	}
 }
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Require permission granted to ctx user role, must run after auth middleware
+func (mw *MiddlewareManager) RequirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := c.Get("user").(*models.User)
+			if !ok {
+				mw.logger.Errorf("Error c.Get(user) RequestID: %s, ERROR: %s,", utils.GetRequestID(c), "invalid user ctx")
+				return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			}
+
+			if !utils.HasPermission(c.Request().Context(), permission) {
+				mw.logger.Errorf("RequirePermission RequestID: %s, UserID: %s, ERROR: missing permission %s",
+					utils.GetRequestID(c),
+					user.UserID.String(),
+					permission,
+				)
+				return c.JSON(http.StatusForbidden, httpErrors.NewForbiddenError(httpErrors.PermissionDenied))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Put permissions of user role to request ctx, failed lookup leaves user without permissions
+func (mw *MiddlewareManager) setPermissions(c echo.Context, user *models.User) {
+	if user.Role == nil {
+		return
+	}
+
+	permissions, err := mw.rbacUC.GetRolePermissions(c.Request().Context(), *user.Role)
+	if err != nil {
+		mw.logger.Errorf("GetRolePermissions RequestID: %s, Role: %s, Error: %s",
+			utils.GetRequestID(c),
+			*user.Role,
+			err.Error(),
+		)
+		return
+	}
+
+	ctx := context.WithValue(c.Request().Context(), utils.PermissionsCtxKey{}, permissions)
+	c.SetRequest(c.Request().WithContext(ctx))
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// API key scopes
+const (
+	ScopeNewsWrite     = "news:write"
+	ScopeCommentsWrite = "comments:write"
+)
+
+// All scopes that can be granted to API key
+var APIKeyScopes = Scopes{ScopeNewsWrite, ScopeCommentsWrite}
+
+// API key scopes list, stored as postgres text array converted to space separated string in queries
+type Scopes []string
+
+// Scan implements sql.Scanner
+func (s *Scopes) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*s = Scopes{}
+	case string:
+		*s = strings.Fields(v)
+	case []byte:
+		*s = strings.Fields(string(v))
+	default:
+		return fmt.Errorf("models.Scopes.Scan: unsupported type %T", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s, " "), nil
+}
+
+// Check scope is granted
+func (s Scopes) Has(scope string) bool {
+	for _, granted := range s {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Personal API key, secret is stored only as hash
+type APIKey struct {
+	KeyID      uuid.UUID  `json:"key_id" db:"key_id"`
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name" validate:"required,lte=64"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	KeyHash    string     `json:"-" db:"key_hash"`
+	Scopes     Scopes     `json:"scopes" db:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Check API key expiration
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// Created API key with plain key, returned only once
+type APIKeyWithSecret struct {
+	*APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit actions
+const (
+	AuditImpersonationStart   = "impersonation.start"
+	AuditImpersonationRequest = "impersonation.request"
+)
+
+// Audit log entry, actor acts on behalf of user
+type AuditEntry struct {
+	EntryID   uuid.UUID  `json:"entry_id" db:"entry_id"`
+	ActorID   *uuid.UUID `json:"actor_id,omitempty" db:"actor_id"`
+	UserID    *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Action    string     `json:"action" db:"action"`
+	Method    string     `json:"method" db:"method"`
+	Path      string     `json:"path" db:"path"`
+	Status    int        `json:"status" db:"status"`
+	IP        string     `json:"ip" db:"ip"`
+	RequestID string     `json:"request_id" db:"request_id"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Audit log filter, empty fields match all entries
+type AuditFilter struct {
+	ActorID *uuid.UUID
+	UserID  *uuid.UUID
+}
+
+// All audit log entries response
+type AuditList struct {
+	TotalCount int           `json:"total_count"`
+	TotalPages int           `json:"total_pages"`
+	Page       int           `json:"page"`
+	Size       int           `json:"size"`
+	HasMore    bool          `json:"has_more"`
+	Entries    []*AuditEntry `json:"entries"`
+}
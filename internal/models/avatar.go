@@ -0,0 +1,50 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// Avatar urls by size in pixels
+type Avatars map[string]string
+
+// Distinct avatar urls, avatars uploaded before resizing share one url for every size
+func (a Avatars) URLs() []string {
+	urls := make([]string, 0, len(a))
+	seen := make(map[string]bool, len(a))
+	for _, url := range a {
+		if seen[url] {
+			continue
+		}
+		seen[url] = true
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+
+	return urls
+}
+
+// Store avatars as jsonb, empty avatars are stored as NULL
+func (a Avatars) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan avatars from jsonb
+func (a *Avatars) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return errors.New("unsupported avatars type")
+	}
+}
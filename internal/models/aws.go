@@ -4,8 +4,10 @@ import "io"
 
 // AWS Upload Input
 type UploadInput struct {
-	File        io.Reader
-	Name        string
+	File io.Reader
+	Name string
+	// Object key, generated from Name when empty
+	ObjectName  string
 	Size        int64
 	ContentType string
 	BucketName  string
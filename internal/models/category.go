@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// News category, categories without parent are top level
+type Category struct {
+	CategoryID  uuid.UUID  `json:"category_id" db:"category_id"`
+	Slug        string     `json:"slug" db:"slug" validate:"required,lte=64"`
+	Name        string     `json:"name" db:"name" validate:"required,lte=64"`
+	Description *string    `json:"description,omitempty" db:"description" validate:"omitempty,lte=250"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty" db:"parent_id"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Follower or followed user, only public profile fields
+type Follow struct {
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	FirstName  string    `json:"first_name" db:"first_name"`
+	LastName   string    `json:"last_name" db:"last_name"`
+	Avatars    Avatars   `json:"avatars,omitempty" db:"avatars"`
+	FollowedAt time.Time `json:"followed_at" db:"followed_at"`
+}
+
+// Followers or following response
+type FollowsList struct {
+	TotalCount int       `json:"total_count"`
+	TotalPages int       `json:"total_pages"`
+	Page       int       `json:"page"`
+	Size       int       `json:"size"`
+	HasMore    bool      `json:"has_more"`
+	Users      []*Follow `json:"users"`
+}
+
+// Followers and following counts of user
+type FollowCounts struct {
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Followers int       `json:"followers" db:"followers"`
+	Following int       `json:"following" db:"following"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Data export job statuses
+const (
+	ExportPending = "pending"
+	ExportReady   = "ready"
+	ExportFailed  = "failed"
+)
+
+// Account erasure modes
+const (
+	// Authored news and comments are kept without author
+	EraseAnonymize = "anonymize"
+	// Authored news and comments are deleted with account
+	EraseDelete = "delete"
+)
+
+// User data export job, stored in redis until archive expires
+type ExportJob struct {
+	JobID       uuid.UUID  `json:"job_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+}
+
+// User data bundled into export archive
+type UserExport struct {
+	Profile    *User      `json:"profile"`
+	News       []*News    `json:"news"`
+	Comments   []*Comment `json:"comments"`
+	ExportedAt time.Time  `json:"exported_at"`
+}
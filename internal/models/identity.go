@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// External identity provider account linked to user
+type UserIdentity struct {
+	IdentityID uuid.UUID `json:"identity_id" db:"identity_id"`
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	Provider   string    `json:"provider" db:"provider"`
+	Subject    string    `json:"subject" db:"subject"`
+	Email      *string   `json:"email,omitempty" db:"email"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Pending authorization request stored until provider callback
+type OIDCState struct {
+	Provider     string     `json:"provider"`
+	Nonce        string     `json:"nonce"`
+	CodeVerifier string     `json:"code_verifier"`
+	LinkUserID   *uuid.UUID `json:"link_user_id,omitempty"`
+}
+
+// Provider callback result, Linked is set when identity was attached to logged in user
+type OIDCLoginResult struct {
+	*UserWithToken
+	Identity *UserIdentity `json:"identity"`
+	Linked   bool          `json:"linked"`
+}
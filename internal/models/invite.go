@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Registration invite, plain code is never stored
+type Invite struct {
+	InviteID  uuid.UUID  `json:"invite_id" db:"invite_id"`
+	CodeHash  string     `json:"-" db:"code_hash"`
+	MaxUses   int        `json:"max_uses" db:"max_uses"`
+	Uses      int        `json:"uses" db:"uses"`
+	Role      *string    `json:"role,omitempty" db:"role"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// Created invite with plain code, returned only once
+type InviteWithCode struct {
+	*Invite
+	Code string `json:"code"`
+}
+
+// All invites response
+type InvitesList struct {
+	TotalCount int       `json:"total_count"`
+	TotalPages int       `json:"total_pages"`
+	Page       int       `json:"page"`
+	Size       int       `json:"size"`
+	HasMore    bool      `json:"has_more"`
+	Invites    []*Invite `json:"invites"`
+}
@@ -8,14 +8,17 @@ import (
 
 // News base model
 type News struct {
-	NewsID    uuid.UUID `json:"news_id" db:"news_id" validate:"omitempty,uuid"`
-	AuthorID  uuid.UUID `json:"author_id,omitempty" db:"author_id" validate:"required"`
-	Title     string    `json:"title" db:"title" validate:"required,gte=10"`
-	Content   string    `json:"content" db:"content" validate:"required,gte=20"`
-	ImageURL  *string   `json:"image_url,omitempty" db:"image_url" validate:"omitempty,lte=512,url"`
-	Category  *string   `json:"category,omitempty" db:"category" validate:"omitempty,lte=10"`
-	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	NewsID     uuid.UUID  `json:"news_id" db:"news_id" validate:"omitempty,uuid"`
+	AuthorID   uuid.UUID  `json:"author_id,omitempty" db:"author_id" validate:"required"`
+	Title      string     `json:"title" db:"title" validate:"required,gte=10"`
+	Content    string     `json:"content" db:"content" validate:"required,gte=20"`
+	ImageURL   *string    `json:"image_url,omitempty" db:"image_url" validate:"omitempty,lte=512,url"`
+	CategoryID *uuid.UUID `json:"category_id,omitempty" db:"category_id"`
+	// Omitted category is kept on update, so removing it has to be requested explicitly
+	ClearCategory bool      `json:"clear_category,omitempty" db:"-"`
+	Tags          []string  `json:"tags,omitempty" db:"-"`
+	CreatedAt     time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at,omitempty" db:"updated_at"`
 }
 
 // All News response
@@ -28,14 +31,47 @@ type NewsList struct {
 	News       []*News `json:"news"`
 }
 
+// Control characters marking matched words in highlights read from database, news text never contains them
+const (
+	HighlightStartSel = "\x02"
+	HighlightStopSel  = "\x03"
+)
+
+// News full text search hit, only title highlight and snippet are html escaped with matched words in <mark> tags, embedded news fields are plain text as in other news responses
+type NewsSearchHit struct {
+	News
+	Rank           float64 `json:"rank" db:"rank"`
+	TitleHighlight string  `json:"title_highlight" db:"title_highlight"`
+	Snippet        string  `json:"snippet" db:"snippet"`
+}
+
+// News search response, most relevant first
+type NewsSearchList struct {
+	TotalCount int              `json:"total_count"`
+	TotalPages int              `json:"total_pages"`
+	Page       int              `json:"page"`
+	Size       int              `json:"size"`
+	HasMore    bool             `json:"has_more"`
+	News       []*NewsSearchHit `json:"news"`
+}
+
 // News base
 type NewsBase struct {
-	NewsID    uuid.UUID `json:"news_id" db:"news_id" validate:"omitempty,uuid"`
-	AuthorID  uuid.UUID `json:"author_id" db:"author_id" validate:"omitempty,uuid"`
-	Title     string    `json:"title" db:"title" validate:"required,gte=10"`
-	Content   string    `json:"content" db:"content" validate:"required,gte=20"`
-	ImageURL  *string   `json:"image_url,omitempty" db:"image_url" validate:"omitempty,lte=512,url"`
-	Category  *string   `json:"category,omitempty" db:"category" validate:"omitempty,lte=10"`
-	Author    string    `json:"author" db:"author"`
-	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	NewsID     uuid.UUID  `json:"news_id" db:"news_id" validate:"omitempty,uuid"`
+	AuthorID   uuid.UUID  `json:"author_id" db:"author_id" validate:"omitempty,uuid"`
+	Title      string     `json:"title" db:"title" validate:"required,gte=10"`
+	Content    string     `json:"content" db:"content" validate:"required,gte=20"`
+	ImageURL   *string    `json:"image_url,omitempty" db:"image_url" validate:"omitempty,lte=512,url"`
+	CategoryID *uuid.UUID `json:"category_id,omitempty" db:"category_id"`
+	Tags       []string   `json:"tags,omitempty" db:"-"`
+	Author     string     `json:"author" db:"author"`
+	UpdatedAt  time.Time  `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// News feed response, next page is requested with next_cursor
+type NewsFeed struct {
+	Size       int     `json:"size"`
+	HasMore    bool    `json:"has_more"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	News       []*News `json:"news"`
 }
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Built-in roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Permissions checked by the api, granted to roles in role_permissions
+const (
+	PermissionNewsUpdateAny     = "news:update:any"
+	PermissionNewsDeleteAny     = "news:delete:any"
+	PermissionCommentsUpdateAny = "comments:update:any"
+	PermissionCommentsDeleteAny = "comments:delete:any"
+	PermissionUsersUpdateAny    = "users:update:any"
+	PermissionUsersReadPrivate  = "users:read:private"
+	PermissionUsersDelete       = "users:delete"
+	PermissionUsersUnlock       = "users:unlock"
+	PermissionUsersSuspend      = "users:suspend"
+	PermissionUsersImpersonate  = "users:impersonate"
+	PermissionAuditRead         = "audit:read"
+	PermissionInvitesManage     = "invites:manage"
+	PermissionRolesManage       = "roles:manage"
+	PermissionCategoriesManage  = "categories:manage"
+)
+
+// Role model
+type Role struct {
+	RoleID      uuid.UUID `json:"role_id" db:"role_id"`
+	Name        string    `json:"name" db:"name" validate:"required,lte=32"`
+	Description *string   `json:"description,omitempty" db:"description" validate:"omitempty,lte=250"`
+	Permissions []string  `json:"permissions" db:"-"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Permission model
+type Permission struct {
+	PermissionID uuid.UUID `json:"permission_id" db:"permission_id"`
+	Name         string    `json:"name" db:"name" validate:"required,lte=64"`
+	Description  *string   `json:"description,omitempty" db:"description" validate:"omitempty,lte=250"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
@@ -1,9 +1,20 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // Session model
 type Session struct {
 	SessionID string    `json:"session_id" redis:"session_id"`
 	UserID    uuid.UUID `json:"user_id" redis:"user_id"`
+	CreatedAt time.Time `json:"created_at" redis:"created_at"`
+	LastSeen  time.Time `json:"last_seen" redis:"last_seen"`
+	IP        string    `json:"ip" redis:"ip"`
+	UserAgent string    `json:"user_agent" redis:"user_agent"`
+	// Admin signed in as user, set only for impersonation sessions
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty" redis:"impersonator_id"`
+	Current        bool       `json:"current,omitempty" redis:"-"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Object stored in bucket
+type StoredObject struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+	ContentType  string    `json:"content_type,omitempty"`
+}
+
+// Bucket reconciliation result, in dry run orphaned objects are reported but kept
+type ReconcileReport struct {
+	Bucket        string          `json:"bucket"`
+	DryRun        bool            `json:"dry_run"`
+	GracePeriod   string          `json:"grace_period"`
+	Scanned       int             `json:"scanned"`
+	Referenced    int             `json:"referenced"`
+	InGracePeriod int             `json:"in_grace_period"`
+	Orphaned      []*StoredObject `json:"orphaned"`
+	OrphanedBytes int64           `json:"orphaned_bytes"`
+	Removed       int             `json:"removed"`
+	Failed        int             `json:"failed"`
+}
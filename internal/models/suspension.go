@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Suspension kinds
+const (
+	SuspensionSuspend = "suspend"
+	SuspensionBan     = "ban"
+)
+
+// Account suspension or ban, expired or lifted suspensions are kept as history
+type Suspension struct {
+	SuspensionID uuid.UUID  `json:"suspension_id" db:"suspension_id"`
+	UserID       uuid.UUID  `json:"user_id" db:"user_id"`
+	Kind         string     `json:"kind" db:"kind"`
+	Reason       string     `json:"reason" db:"reason"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedBy    *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	LiftedAt     *time.Time `json:"lifted_at,omitempty" db:"lifted_at"`
+	LiftedBy     *uuid.UUID `json:"lifted_by,omitempty" db:"lifted_by"`
+}
+
+// Check suspension is neither lifted nor expired
+func (s *Suspension) Active(now time.Time) bool {
+	return s.LiftedAt == nil && (s.ExpiresAt == nil || now.Before(*s.ExpiresAt))
+}
+
+// Suspended user with active suspension
+type SuspendedUser struct {
+	Suspension
+	FirstName string `json:"first_name" db:"first_name"`
+	LastName  string `json:"last_name" db:"last_name"`
+	Email     string `json:"email" db:"email"`
+}
+
+// All suspended users response
+type SuspendedUsersList struct {
+	TotalCount int              `json:"total_count"`
+	TotalPages int              `json:"total_pages"`
+	Page       int              `json:"page"`
+	Size       int              `json:"size"`
+	HasMore    bool             `json:"has_more"`
+	Users      []*SuspendedUser `json:"users"`
+}
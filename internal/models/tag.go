@@ -0,0 +1,13 @@
+package models
+
+// Tag with number of news tagged by it
+type Tag struct {
+	Name      string `json:"name" db:"name"`
+	NewsCount int    `json:"news_count" db:"news_count"`
+}
+
+// How news are matched by several tags
+const (
+	TagsMatchAll = "all"
+	TagsMatchAny = "any"
+)
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Refresh token model, stored in redis by token hash
+type RefreshToken struct {
+	FamilyID  string    `json:"family_id" redis:"family_id"`
+	UserID    uuid.UUID `json:"user_id" redis:"user_id"`
+	ExpiresAt time.Time `json:"expires_at" redis:"expires_at"`
+}
+
+// Refresh token request
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// Two factor enrollment response, secret is shown only until confirmation
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// Recovery codes response, plain codes are shown only once
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// Second login step request
+type TwoFactorLoginRequest struct {
+	Challenge string `json:"challenge" validate:"required"`
+	Code      string `json:"code" validate:"required,lte=32"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Direct upload targets
+const (
+	UploadAvatar    = "avatar"
+	UploadNewsImage = "news_image"
+)
+
+// Presigned upload request, size is the largest object client is going to upload
+type UploadTicketRequest struct {
+	Target      string     `json:"target" validate:"required,oneof=avatar news_image"`
+	NewsID      *uuid.UUID `json:"news_id,omitempty"`
+	ContentType string     `json:"content_type" validate:"required,oneof=image/jpeg image/png"`
+	Size        int64      `json:"size" validate:"required,gt=0"`
+}
+
+// Direct upload ticket, stored in redis until it is completed or expires.
+// Client sends multipart POST request to upload url with form data fields, Content-Type field set to
+// ticket content type and file field last.
+type UploadTicket struct {
+	TicketID    uuid.UUID         `json:"ticket_id"`
+	UserID      uuid.UUID         `json:"user_id"`
+	Target      string            `json:"target"`
+	NewsID      *uuid.UUID        `json:"news_id,omitempty"`
+	Bucket      string            `json:"bucket"`
+	ObjectName  string            `json:"object_name"`
+	ContentType string            `json:"content_type"`
+	MaxSize     int64             `json:"max_size"`
+	UploadURL   string            `json:"upload_url"`
+	FormData    map[string]string `json:"form_data"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+}
+
+// Completed upload, avatars are set for avatar target and image url for news image target
+type UploadResult struct {
+	TicketID uuid.UUID  `json:"ticket_id"`
+	Target   string     `json:"target"`
+	NewsID   *uuid.UUID `json:"news_id,omitempty"`
+	ImageURL string     `json:"image_url,omitempty"`
+	Avatars  Avatars    `json:"avatars,omitempty"`
+}
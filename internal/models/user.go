@@ -5,7 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
+
+	"github.com/AleksK1NG/api-mc/pkg/passwords"
 )
 
 // User full model
@@ -15,37 +16,43 @@ type User struct {
 	LastName    string     `json:"last_name" db:"last_name" redis:"last_name" validate:"required,lte=30"`
 	Email       string     `json:"email,omitempty" db:"email" redis:"email" validate:"omitempty,lte=60,email"`
 	Password    string     `json:"password,omitempty" db:"password" redis:"password" validate:"omitempty,required,gte=6"`
-	Role        *string    `json:"role,omitempty" db:"role" redis:"role" validate:"omitempty,lte=10"`
+	Role        *string    `json:"role,omitempty" db:"role" redis:"role" validate:"omitempty,lte=32"`
 	About       *string    `json:"about,omitempty" db:"about" redis:"about" validate:"omitempty,lte=1024"`
-	Avatar      *string    `json:"avatar,omitempty" db:"avatar" redis:"avatar" validate:"omitempty,lte=512,url"`
+	Avatars     Avatars    `json:"avatars,omitempty" db:"avatars" redis:"avatars"`
 	PhoneNumber *string    `json:"phone_number,omitempty" db:"phone_number" redis:"phone_number" validate:"omitempty,lte=20"`
 	Address     *string    `json:"address,omitempty" db:"address" redis:"address" validate:"omitempty,lte=250"`
 	City        *string    `json:"city,omitempty" db:"city" redis:"city" validate:"omitempty,lte=24"`
 	Country     *string    `json:"country,omitempty" db:"country" redis:"country" validate:"omitempty,lte=24"`
-	Gender      *string    `json:"gender,omitempty" db:"gender" redis:"gender" validate:"omitempty,lte=10"`
+	Gender      *string    `json:"gender,omitempty" db:"gender" redis:"gender" validate:"omitempty,lte=32"`
 	Postcode    *int       `json:"postcode,omitempty" db:"postcode" redis:"postcode" validate:"omitempty"`
-	Birthday    *time.Time `json:"birthday,omitempty" db:"birthday" redis:"birthday" validate:"omitempty,lte=10"`
+	Birthday    *time.Time `json:"birthday,omitempty" db:"birthday" redis:"birthday" validate:"omitempty,lte=32"`
 	CreatedAt   time.Time  `json:"created_at,omitempty" db:"created_at" redis:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at,omitempty" db:"updated_at" redis:"updated_at"`
 	LoginDate   time.Time  `json:"login_date" db:"login_date" redis:"login_date"`
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at" redis:"email_verified_at"`
+	TOTPSecret      *string    `json:"-" db:"totp_secret" redis:"-"`
+	TOTPEnabledAt   *time.Time `json:"totp_enabled_at,omitempty" db:"totp_enabled_at" redis:"totp_enabled_at"`
 }
 
-// Hash user password with bcrypt
-func (u *User) HashPassword() error {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+// Check if user has confirmed two factor authentication
+func (u *User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+// Hash user password with configured algorithm
+func (u *User) HashPassword(hasher *passwords.Hasher) error {
+	hashedPassword, err := hasher.Hash(u.Password)
 	if err != nil {
 		return err
 	}
-	u.Password = string(hashedPassword)
+	u.Password = hashedPassword
 	return nil
 }
 
-// Compare user password and payload
+// Compare user password hash of any supported algorithm and payload
 func (u *User) ComparePasswords(password string) error {
-	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)); err != nil {
-		return err
-	}
-	return nil
+	return passwords.Compare(u.Password, password)
 }
 
 // Sanitize user password
@@ -54,20 +61,19 @@ func (u *User) SanitizePassword() {
 }
 
 // Prepare user for register
-func (u *User) PrepareCreate() error {
+func (u *User) PrepareCreate(hasher *passwords.Hasher) error {
 	u.Email = strings.ToLower(strings.TrimSpace(u.Email))
 	u.Password = strings.TrimSpace(u.Password)
 
-	if err := u.HashPassword(); err != nil {
+	if err := u.HashPassword(hasher); err != nil {
 		return err
 	}
 
 	if u.PhoneNumber != nil {
 		*u.PhoneNumber = strings.TrimSpace(*u.PhoneNumber)
 	}
-	if u.Role != nil {
-		*u.Role = strings.ToLower(strings.TrimSpace(*u.Role))
-	}
+	// Role is assigned only by administrators, never from client payload
+	u.Role = nil
 	return nil
 }
 
@@ -78,9 +84,8 @@ func (u *User) PrepareUpdate() error {
 	if u.PhoneNumber != nil {
 		*u.PhoneNumber = strings.TrimSpace(*u.PhoneNumber)
 	}
-	if u.Role != nil {
-		*u.Role = strings.ToLower(strings.TrimSpace(*u.Role))
-	}
+	// Role is assigned only by administrators, never from client payload
+	u.Role = nil
 	return nil
 }
 
@@ -96,6 +101,8 @@ type UsersList struct {
 
 // Find user query
 type UserWithToken struct {
-	User  *User  `json:"user"`
-	Token string `json:"token"`
+	User         *User  `json:"user,omitempty"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Challenge    string `json:"challenge,omitempty"`
 }
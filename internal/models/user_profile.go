@@ -0,0 +1,146 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Privacy settings of user, optional profile fields are hidden from public profile unless enabled
+type PrivacySettings struct {
+	ShowEmail       bool `json:"show_email" db:"show_email"`
+	ShowPhoneNumber bool `json:"show_phone_number" db:"show_phone_number"`
+	ShowAddress     bool `json:"show_address" db:"show_address"`
+	ShowLocation    bool `json:"show_location" db:"show_location"`
+	ShowGender      bool `json:"show_gender" db:"show_gender"`
+	ShowBirthday    bool `json:"show_birthday" db:"show_birthday"`
+}
+
+// Public profile of user, visible to anyone
+type PublicUser struct {
+	UserID      uuid.UUID  `json:"user_id"`
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	About       *string    `json:"about,omitempty"`
+	Avatars     Avatars    `json:"avatars,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	PhoneNumber *string    `json:"phone_number,omitempty"`
+	Address     *string    `json:"address,omitempty"`
+	Postcode    *int       `json:"postcode,omitempty"`
+	City        *string    `json:"city,omitempty"`
+	Country     *string    `json:"country,omitempty"`
+	Gender      *string    `json:"gender,omitempty"`
+	Birthday    *time.Time `json:"birthday,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Own profile of user with private fields and privacy settings
+type SelfUser struct {
+	UserID          uuid.UUID        `json:"user_id"`
+	FirstName       string           `json:"first_name"`
+	LastName        string           `json:"last_name"`
+	Email           string           `json:"email"`
+	Role            *string          `json:"role,omitempty"`
+	About           *string          `json:"about,omitempty"`
+	Avatars         Avatars          `json:"avatars,omitempty"`
+	PhoneNumber     *string          `json:"phone_number,omitempty"`
+	Address         *string          `json:"address,omitempty"`
+	City            *string          `json:"city,omitempty"`
+	Country         *string          `json:"country,omitempty"`
+	Gender          *string          `json:"gender,omitempty"`
+	Postcode        *int             `json:"postcode,omitempty"`
+	Birthday        *time.Time       `json:"birthday,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	EmailVerifiedAt *time.Time       `json:"email_verified_at,omitempty"`
+	TOTPEnabledAt   *time.Time       `json:"totp_enabled_at,omitempty"`
+	Privacy         *PrivacySettings `json:"privacy"`
+}
+
+// Profile of user for administrators, includes account activity
+type AdminUser struct {
+	SelfUser
+	LoginDate time.Time `json:"login_date"`
+}
+
+// Public projection of user, optional fields follow privacy settings
+func (u *User) Public(privacy *PrivacySettings) *PublicUser {
+	p := &PublicUser{
+		UserID:    u.UserID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		About:     u.About,
+		Avatars:   u.Avatars,
+		CreatedAt: u.CreatedAt,
+	}
+	if privacy == nil {
+		return p
+	}
+
+	if privacy.ShowEmail {
+		p.Email = u.Email
+	}
+	if privacy.ShowPhoneNumber {
+		p.PhoneNumber = u.PhoneNumber
+	}
+	if privacy.ShowAddress {
+		p.Address = u.Address
+		p.Postcode = u.Postcode
+	}
+	if privacy.ShowLocation {
+		p.City = u.City
+		p.Country = u.Country
+	}
+	if privacy.ShowGender {
+		p.Gender = u.Gender
+	}
+	if privacy.ShowBirthday {
+		p.Birthday = u.Birthday
+	}
+
+	return p
+}
+
+// Own projection of user
+func (u *User) Self(privacy *PrivacySettings) *SelfUser {
+	if privacy == nil {
+		privacy = &PrivacySettings{}
+	}
+
+	return &SelfUser{
+		UserID:          u.UserID,
+		FirstName:       u.FirstName,
+		LastName:        u.LastName,
+		Email:           u.Email,
+		Role:            u.Role,
+		About:           u.About,
+		Avatars:         u.Avatars,
+		PhoneNumber:     u.PhoneNumber,
+		Address:         u.Address,
+		City:            u.City,
+		Country:         u.Country,
+		Gender:          u.Gender,
+		Postcode:        u.Postcode,
+		Birthday:        u.Birthday,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
+		EmailVerifiedAt: u.EmailVerifiedAt,
+		TOTPEnabledAt:   u.TOTPEnabledAt,
+		Privacy:         privacy,
+	}
+}
+
+// Administrator projection of user
+func (u *User) Admin(privacy *PrivacySettings) *AdminUser {
+	return &AdminUser{SelfUser: *u.Self(privacy), LoginDate: u.LoginDate}
+}
+
+// Users response with projection chosen per caller
+type UserProfilesList struct {
+	TotalCount int           `json:"total_count"`
+	TotalPages int           `json:"total_pages"`
+	Page       int           `json:"page"`
+	Size       int           `json:"size"`
+	HasMore    bool          `json:"has_more"`
+	Users      []interface{} `json:"users"`
+}
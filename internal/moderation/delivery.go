@@ -0,0 +1,12 @@
+package moderation
+
+import "github.com/labstack/echo/v4"
+
+// Moderation HTTP Handlers interface
+type Handlers interface {
+	Suspend() echo.HandlerFunc
+	Ban() echo.HandlerFunc
+	Lift() echo.HandlerFunc
+	GetActiveSuspension() echo.HandlerFunc
+	GetSuspendedUsers() echo.HandlerFunc
+}
@@ -0,0 +1,203 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/moderation"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Moderation handlers
+type moderationHandlers struct {
+	cfg          *config.Config
+	moderationUC moderation.UseCase
+	logger       logger.Logger
+}
+
+// NewModerationHandlers Moderation handlers constructor
+func NewModerationHandlers(cfg *config.Config, moderationUC moderation.UseCase, log logger.Logger) moderation.Handlers {
+	return &moderationHandlers{cfg: cfg, moderationUC: moderationUC, logger: log}
+}
+
+// Suspend godoc
+// @Summary Suspend user
+// @Description suspend user with reason and optional expiration, requires users:suspend permission
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Success 201 {object} models.Suspension
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Router /moderation/users/{user_id}/suspend [post]
+func (h *moderationHandlers) Suspend() echo.HandlerFunc {
+	return h.suspend("moderationHandlers.Suspend", models.SuspensionSuspend)
+}
+
+// Ban godoc
+// @Summary Ban user
+// @Description ban user with reason and optional expiration, requires users:suspend permission
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Success 201 {object} models.Suspension
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Router /moderation/users/{user_id}/ban [post]
+func (h *moderationHandlers) Ban() echo.HandlerFunc {
+	return h.suspend("moderationHandlers.Ban", models.SuspensionBan)
+}
+
+// Lift godoc
+// @Summary Lift suspension
+// @Description lift active suspension or ban of user, requires users:suspend permission
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /moderation/users/{user_id}/suspension [delete]
+func (h *moderationHandlers) Lift() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "moderationHandlers.Lift")
+		defer span.Finish()
+
+		admin, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.moderationUC.Lift(ctx, userID, admin.UserID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// GetActiveSuspension godoc
+// @Summary Get active suspension
+// @Description get active suspension or ban of user, requires users:suspend permission
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Success 200 {object} models.Suspension
+// @Failure 404 {object} httpErrors.RestError
+// @Router /moderation/users/{user_id}/suspension [get]
+func (h *moderationHandlers) GetActiveSuspension() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "moderationHandlers.GetActiveSuspension")
+		defer span.Finish()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		suspension, err := h.moderationUC.GetActiveSuspension(ctx, userID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, suspension)
+	}
+}
+
+// GetSuspendedUsers godoc
+// @Summary Get suspended users
+// @Description get users with active suspension or ban, requires users:suspend permission
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param status query string false "suspended or banned"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.SuspendedUsersList
+// @Failure 400 {object} httpErrors.RestError
+// @Router /moderation/users [get]
+func (h *moderationHandlers) GetSuspendedUsers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "moderationHandlers.GetSuspendedUsers")
+		defer span.Finish()
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		usersList, err := h.moderationUC.GetSuspendedUsers(ctx, c.QueryParam("status"), paginationQuery)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, usersList)
+	}
+}
+
+func (h *moderationHandlers) suspend(operationName string, kind string) echo.HandlerFunc {
+	type Suspend struct {
+		Reason    string     `json:"reason" validate:"required,lte=500"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), operationName)
+		defer span.Finish()
+
+		admin, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		request := &Suspend{}
+		if err = utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		suspension, err := h.moderationUC.Suspend(ctx, &models.Suspension{
+			UserID:    userID,
+			Kind:      kind,
+			Reason:    request.Reason,
+			ExpiresAt: request.ExpiresAt,
+			CreatedBy: &admin.UserID,
+		})
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, suspension)
+	}
+}
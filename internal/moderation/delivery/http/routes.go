@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/middleware"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/moderation"
+)
+
+// Map moderation admin routes
+func MapModerationRoutes(moderationGroup *echo.Group, h moderation.Handlers, mw *middleware.MiddlewareManager) {
+	moderationGroup.Use(mw.AuthSessionMiddleware, mw.RequirePermission(models.PermissionUsersSuspend))
+	moderationGroup.GET("/users", h.GetSuspendedUsers())
+	moderationGroup.POST("/users/:user_id/suspend", h.Suspend(), mw.CSRF)
+	moderationGroup.POST("/users/:user_id/ban", h.Ban(), mw.CSRF)
+	moderationGroup.GET("/users/:user_id/suspension", h.GetActiveSuspension())
+	moderationGroup.DELETE("/users/:user_id/suspension", h.Lift(), mw.CSRF)
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package moderation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Moderation Repository
+type Repository interface {
+	Create(ctx context.Context, suspension *models.Suspension) (*models.Suspension, error)
+	GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*models.Suspension, error)
+	Lift(ctx context.Context, userID uuid.UUID, liftedBy uuid.UUID) error
+	GetSuspendedUsers(ctx context.Context, kind string, pq *utils.PaginationQuery) (*models.SuspendedUsersList, error)
+}
@@ -0,0 +1,15 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository_mock.go -package mock
+package moderation
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Moderation redis repository, caches active suspensions of users
+type RedisRepository interface {
+	GetSuspensionCtx(ctx context.Context, key string) (*models.Suspension, error)
+	SetSuspensionCtx(ctx context.Context, key string, seconds int, suspension *models.Suspension) error
+	DeleteSuspensionCtx(ctx context.Context, key string) error
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/moderation"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Moderation Repository
+type moderationRepo struct {
+	db *sqlx.DB
+}
+
+// Moderation Repository constructor
+func NewModerationRepository(db *sqlx.DB) moderation.Repository {
+	return &moderationRepo{db: db}
+}
+
+// Create suspension, active suspension of user is lifted and replaced by the new one
+func (r *moderationRepo) Create(ctx context.Context, suspension *models.Suspension) (*models.Suspension, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationRepo.Create")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "moderationRepo.Create.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	// Concurrent suspensions of same user are serialized, so user never has more than one active suspension
+	if _, err = tx.ExecContext(ctx, lockUserQuery, suspension.UserID); err != nil {
+		return nil, errors.Wrap(err, "moderationRepo.Create.ExecContext.lockUser")
+	}
+	if _, err = tx.ExecContext(ctx, liftActiveQuery, suspension.UserID, suspension.CreatedBy); err != nil {
+		return nil, errors.Wrap(err, "moderationRepo.Create.ExecContext")
+	}
+
+	created := &models.Suspension{}
+	if err = tx.QueryRowxContext(
+		ctx,
+		createSuspensionQuery,
+		suspension.UserID,
+		suspension.Kind,
+		suspension.Reason,
+		suspension.ExpiresAt,
+		suspension.CreatedBy,
+	).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "moderationRepo.Create.StructScan")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "moderationRepo.Create.Commit")
+	}
+
+	return created, nil
+}
+
+// Get not lifted and not expired suspension of user
+func (r *moderationRepo) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*models.Suspension, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationRepo.GetActiveByUserID")
+	defer span.Finish()
+
+	suspension := &models.Suspension{}
+	if err := r.db.GetContext(ctx, suspension, getActiveByUserIDQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "moderationRepo.GetActiveByUserID.GetContext")
+	}
+
+	return suspension, nil
+}
+
+// Lift active suspension of user
+func (r *moderationRepo) Lift(ctx context.Context, userID uuid.UUID, liftedBy uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationRepo.Lift")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, liftActiveQuery, userID, liftedBy)
+	if err != nil {
+		return errors.Wrap(err, "moderationRepo.Lift.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "moderationRepo.Lift.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "moderationRepo.Lift.rowsAffected")
+	}
+
+	return nil
+}
+
+// Get users with active suspension, empty kind returns both suspended and banned users
+func (r *moderationRepo) GetSuspendedUsers(
+	ctx context.Context,
+	kind string,
+	pq *utils.PaginationQuery,
+) (*models.SuspendedUsersList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationRepo.GetSuspendedUsers")
+	defer span.Finish()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getTotalSuspendedQuery, kind); err != nil {
+		return nil, errors.Wrap(err, "moderationRepo.GetSuspendedUsers.GetContext.totalCount")
+	}
+
+	if totalCount == 0 {
+		return &models.SuspendedUsersList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Users:      make([]*models.SuspendedUser, 0),
+		}, nil
+	}
+
+	var users = make([]*models.SuspendedUser, 0, pq.GetSize())
+	if err := r.db.SelectContext(
+		ctx,
+		&users,
+		getSuspendedUsersQuery,
+		kind,
+		pq.GetOffset(),
+		pq.GetLimit(),
+	); err != nil {
+		return nil, errors.Wrap(err, "moderationRepo.GetSuspendedUsers.SelectContext")
+	}
+
+	return &models.SuspendedUsersList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Users:      users,
+	}, nil
+}
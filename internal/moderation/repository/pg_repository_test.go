@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+func TestModerationRepo_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	moderationRepo := NewModerationRepository(sqlxDB)
+
+	t.Run("Create", func(t *testing.T) {
+		adminID := uuid.New()
+		expiresAt := time.Now().Add(time.Hour)
+		suspension := &models.Suspension{
+			UserID:    uuid.New(),
+			Kind:      models.SuspensionBan,
+			Reason:    "spam",
+			ExpiresAt: &expiresAt,
+			CreatedBy: &adminID,
+		}
+
+		rows := sqlmock.NewRows([]string{
+			"suspension_id", "user_id", "kind", "reason", "expires_at", "created_by", "created_at", "lifted_at", "lifted_by",
+		}).AddRow(uuid.New(), suspension.UserID, suspension.Kind, suspension.Reason, expiresAt, adminID, time.Now(), nil, nil)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(lockUserQuery).WithArgs(suspension.UserID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(liftActiveQuery).WithArgs(suspension.UserID, suspension.CreatedBy).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(createSuspensionQuery).WithArgs(
+			suspension.UserID,
+			suspension.Kind,
+			suspension.Reason,
+			suspension.ExpiresAt,
+			suspension.CreatedBy,
+		).WillReturnRows(rows)
+		mock.ExpectCommit()
+
+		created, err := moderationRepo.Create(context.Background(), suspension)
+		require.NoError(t, err)
+		require.Equal(t, suspension.UserID, created.UserID)
+		require.Equal(t, models.SuspensionBan, created.Kind)
+		require.Nil(t, created.LiftedAt)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestModerationRepo_Lift(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	moderationRepo := NewModerationRepository(sqlxDB)
+
+	t.Run("Lift", func(t *testing.T) {
+		userID := uuid.New()
+		adminID := uuid.New()
+
+		mock.ExpectExec(liftActiveQuery).WithArgs(userID, adminID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := moderationRepo.Lift(context.Background(), userID, adminID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Not suspended", func(t *testing.T) {
+		userID := uuid.New()
+		adminID := uuid.New()
+
+		mock.ExpectExec(liftActiveQuery).WithArgs(userID, adminID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := moderationRepo.Lift(context.Background(), userID, adminID)
+		require.Equal(t, sql.ErrNoRows, errors.Cause(err))
+	})
+}
+
+func TestModerationRepo_GetSuspendedUsers(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	moderationRepo := NewModerationRepository(sqlxDB)
+
+	t.Run("GetSuspendedUsers", func(t *testing.T) {
+		pq := &utils.PaginationQuery{Size: 10, Page: 1}
+		userID := uuid.New()
+
+		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+		rows := sqlmock.NewRows([]string{
+			"suspension_id", "user_id", "kind", "reason", "expires_at", "created_by", "created_at", "lifted_at", "lifted_by",
+			"first_name", "last_name", "email",
+		}).AddRow(uuid.New(), userID, models.SuspensionSuspend, "spam", nil, nil, time.Now(), nil, nil,
+			"Alex", "Bryksin", "alex@gmail.com")
+
+		mock.ExpectQuery(getTotalSuspendedQuery).WithArgs(models.SuspensionSuspend).WillReturnRows(totalRows)
+		mock.ExpectQuery(getSuspendedUsersQuery).WithArgs(models.SuspensionSuspend, 0, 10).WillReturnRows(rows)
+
+		usersList, err := moderationRepo.GetSuspendedUsers(context.Background(), models.SuspensionSuspend, pq)
+		require.NoError(t, err)
+		require.Equal(t, 1, usersList.TotalCount)
+		require.Len(t, usersList.Users, 1)
+		require.Equal(t, userID, usersList.Users[0].UserID)
+		require.Equal(t, "alex@gmail.com", usersList.Users[0].Email)
+	})
+}
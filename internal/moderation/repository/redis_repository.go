@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/moderation"
+)
+
+// Moderation redis repository
+type moderationRedisRepo struct {
+	redisClient *redis.Client
+}
+
+// Moderation redis repository constructor
+func NewModerationRedisRepo(redisClient *redis.Client) moderation.RedisRepository {
+	return &moderationRedisRepo{redisClient: redisClient}
+}
+
+// Get cached suspension of user
+func (r *moderationRedisRepo) GetSuspensionCtx(ctx context.Context, key string) (*models.Suspension, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationRedisRepo.GetSuspensionCtx")
+	defer span.Finish()
+
+	suspensionBytes, err := r.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "moderationRedisRepo.GetSuspensionCtx.redisClient.Get")
+	}
+	suspension := &models.Suspension{}
+	if err = json.Unmarshal(suspensionBytes, suspension); err != nil {
+		return nil, errors.Wrap(err, "moderationRedisRepo.GetSuspensionCtx.json.Unmarshal")
+	}
+
+	return suspension, nil
+}
+
+// Cache suspension of user with duration in seconds
+func (r *moderationRedisRepo) SetSuspensionCtx(
+	ctx context.Context,
+	key string,
+	seconds int,
+	suspension *models.Suspension,
+) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationRedisRepo.SetSuspensionCtx")
+	defer span.Finish()
+
+	suspensionBytes, err := json.Marshal(suspension)
+	if err != nil {
+		return errors.Wrap(err, "moderationRedisRepo.SetSuspensionCtx.json.Marshal")
+	}
+	if err = r.redisClient.Set(ctx, key, suspensionBytes, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "moderationRedisRepo.SetSuspensionCtx.redisClient.Set")
+	}
+
+	return nil
+}
+
+// Delete cached suspension of user
+func (r *moderationRedisRepo) DeleteSuspensionCtx(ctx context.Context, key string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationRedisRepo.DeleteSuspensionCtx")
+	defer span.Finish()
+
+	if err := r.redisClient.Del(ctx, key).Err(); err != nil {
+		return errors.Wrap(err, "moderationRedisRepo.DeleteSuspensionCtx.redisClient.Del")
+	}
+
+	return nil
+}
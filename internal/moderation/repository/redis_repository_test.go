@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/moderation"
+)
+
+func SetupRedis() moderation.RedisRepository {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	moderationRedisRepo := NewModerationRedisRepo(client)
+	return moderationRedisRepo
+}
+
+func TestModerationRedisRepo_SuspensionCtx(t *testing.T) {
+	t.Parallel()
+
+	moderationRedisRepo := SetupRedis()
+
+	t.Run("Set, get and delete", func(t *testing.T) {
+		key := "key"
+		suspension := &models.Suspension{
+			SuspensionID: uuid.New(),
+			UserID:       uuid.New(),
+			Kind:         models.SuspensionSuspend,
+			Reason:       "spam",
+		}
+
+		err := moderationRedisRepo.SetSuspensionCtx(context.Background(), key, 10, suspension)
+		require.NoError(t, err)
+
+		cached, err := moderationRedisRepo.GetSuspensionCtx(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, suspension.SuspensionID, cached.SuspensionID)
+		require.Equal(t, suspension.Reason, cached.Reason)
+
+		err = moderationRedisRepo.DeleteSuspensionCtx(context.Background(), key)
+		require.NoError(t, err)
+
+		_, err = moderationRedisRepo.GetSuspensionCtx(context.Background(), key)
+		require.Error(t, err)
+	})
+}
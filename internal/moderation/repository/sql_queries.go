@@ -0,0 +1,28 @@
+package repository
+
+const (
+	lockUserQuery = `SELECT user_id FROM users WHERE user_id = $1 FOR UPDATE`
+
+	liftActiveQuery = `UPDATE user_suspensions SET lifted_at = now(), lifted_by = $2 
+						WHERE user_id = $1 AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > now())`
+
+	createSuspensionQuery = `INSERT INTO user_suspensions (user_id, kind, reason, expires_at, created_by, created_at) 
+						VALUES ($1, $2, $3, $4, $5, now()) 
+						RETURNING suspension_id, user_id, kind, reason, expires_at, created_by, created_at, lifted_at, lifted_by`
+
+	getActiveByUserIDQuery = `SELECT suspension_id, user_id, kind, reason, expires_at, created_by, created_at, lifted_at, lifted_by 
+						FROM user_suspensions 
+						WHERE user_id = $1 AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > now()) 
+						ORDER BY created_at DESC LIMIT 1`
+
+	getTotalSuspendedQuery = `SELECT COUNT(suspension_id) FROM user_suspensions 
+						WHERE ($1 = '' OR kind = $1) AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > now())`
+
+	getSuspendedUsersQuery = `SELECT s.suspension_id, s.user_id, s.kind, s.reason, s.expires_at, s.created_by, s.created_at, 
+       					s.lifted_at, s.lifted_by, u.first_name, u.last_name, u.email 
+						FROM user_suspensions s 
+						JOIN users u ON u.user_id = s.user_id 
+						WHERE ($1 = '' OR s.kind = $1) AND s.lifted_at IS NULL AND (s.expires_at IS NULL OR s.expires_at > now()) 
+						ORDER BY s.created_at DESC 
+						OFFSET $2 LIMIT $3`
+)
@@ -0,0 +1,20 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package moderation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Moderation UseCase
+type UseCase interface {
+	Suspend(ctx context.Context, suspension *models.Suspension) (*models.Suspension, error)
+	Lift(ctx context.Context, userID uuid.UUID, liftedBy uuid.UUID) error
+	GetActiveSuspension(ctx context.Context, userID uuid.UUID) (*models.Suspension, error)
+	GetSuspendedUsers(ctx context.Context, status string, pq *utils.PaginationQuery) (*models.SuspendedUsersList, error)
+	CheckAccount(ctx context.Context, userID uuid.UUID) error
+}
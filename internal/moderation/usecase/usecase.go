@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/auth"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/moderation"
+	"github.com/AleksK1NG/api-mc/internal/rbac"
+	"github.com/AleksK1NG/api-mc/internal/session"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+const (
+	basePrefix    = "api-moderation-suspension:"
+	cacheDuration = 600
+)
+
+// Suspension list statuses
+const (
+	statusSuspended = "suspended"
+	statusBanned    = "banned"
+)
+
+// Moderation UseCase
+type moderationUC struct {
+	cfg            *config.Config
+	moderationRepo moderation.Repository
+	redisRepo      moderation.RedisRepository
+	authRepo       auth.Repository
+	rbacRepo       rbac.Repository
+	sessUC         session.UCSession
+	logger         logger.Logger
+}
+
+// Moderation UseCase constructor
+func NewModerationUseCase(
+	cfg *config.Config,
+	moderationRepo moderation.Repository,
+	redisRepo moderation.RedisRepository,
+	authRepo auth.Repository,
+	rbacRepo rbac.Repository,
+	sessUC session.UCSession,
+	log logger.Logger,
+) moderation.UseCase {
+	return &moderationUC{
+		cfg:            cfg,
+		moderationRepo: moderationRepo,
+		redisRepo:      redisRepo,
+		authRepo:       authRepo,
+		rbacRepo:       rbacRepo,
+		sessUC:         sessUC,
+		logger:         log,
+	}
+}
+
+// Suspend or ban user, previous active suspension is replaced and all sessions of user are revoked
+func (u *moderationUC) Suspend(ctx context.Context, suspension *models.Suspension) (*models.Suspension, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationUC.Suspend")
+	defer span.Finish()
+
+	if suspension.Kind != models.SuspensionSuspend && suspension.Kind != models.SuspensionBan {
+		return nil, httpErrors.NewBadRequestError(errors.Errorf("unknown suspension kind: %s", suspension.Kind))
+	}
+	if suspension.CreatedBy != nil && *suspension.CreatedBy == suspension.UserID {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.SuspendSelf.Error(), nil)
+	}
+	if suspension.ExpiresAt != nil && !suspension.ExpiresAt.After(time.Now()) {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidSuspension.Error(), nil)
+	}
+	suspension.Reason = strings.TrimSpace(suspension.Reason)
+	if suspension.Reason == "" {
+		return nil, httpErrors.NewBadRequestError(errors.New("suspension reason is required"))
+	}
+	if err := u.checkSuspensionTarget(ctx, suspension.UserID); err != nil {
+		return nil, err
+	}
+
+	created, err := u.moderationRepo.Create(ctx, suspension)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.DeleteSuspensionCtx(ctx, u.getKeyWithPrefix(suspension.UserID.String())); err != nil {
+		u.logger.Errorf("moderationUC.Suspend.DeleteSuspensionCtx: %s", err)
+	}
+	if err = u.sessUC.RevokeUserSessions(ctx, suspension.UserID, ""); err != nil {
+		u.logger.Errorf("moderationUC.Suspend.RevokeUserSessions: %s", err)
+	}
+
+	return created, nil
+}
+
+// Moderator must hold every permission of target, otherwise users could lock out more privileged accounts
+func (u *moderationUC) checkSuspensionTarget(ctx context.Context, userID uuid.UUID) error {
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role == nil {
+		return nil
+	}
+
+	permissions, err := u.rbacRepo.GetRolePermissions(ctx, *user.Role)
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if !utils.HasPermission(ctx, permission) {
+			return httpErrors.NewRestError(http.StatusForbidden, httpErrors.SuspendPrivileged.Error(), nil)
+		}
+	}
+	return nil
+}
+
+// Lift active suspension or ban of user
+func (u *moderationUC) Lift(ctx context.Context, userID uuid.UUID, liftedBy uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationUC.Lift")
+	defer span.Finish()
+
+	if err := u.moderationRepo.Lift(ctx, userID, liftedBy); err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return httpErrors.NewNotFoundError(err)
+		}
+		return err
+	}
+
+	if err := u.redisRepo.DeleteSuspensionCtx(ctx, u.getKeyWithPrefix(userID.String())); err != nil {
+		u.logger.Errorf("moderationUC.Lift.DeleteSuspensionCtx: %s", err)
+	}
+
+	return nil
+}
+
+// Get active suspension of user
+func (u *moderationUC) GetActiveSuspension(ctx context.Context, userID uuid.UUID) (*models.Suspension, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationUC.GetActiveSuspension")
+	defer span.Finish()
+
+	suspension, err := u.getActiveSuspension(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if suspension == nil {
+		return nil, httpErrors.NewNotFoundError(errors.New("user has no active suspension"))
+	}
+
+	return suspension, nil
+}
+
+// Get users with active suspension filtered by status suspended or banned
+func (u *moderationUC) GetSuspendedUsers(
+	ctx context.Context,
+	status string,
+	pq *utils.PaginationQuery,
+) (*models.SuspendedUsersList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationUC.GetSuspendedUsers")
+	defer span.Finish()
+
+	var kind string
+	switch status {
+	case "":
+	case statusSuspended:
+		kind = models.SuspensionSuspend
+	case statusBanned:
+		kind = models.SuspensionBan
+	default:
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidSuspendStatus.Error(), nil)
+	}
+
+	return u.moderationRepo.GetSuspendedUsers(ctx, kind, pq)
+}
+
+// Check account is not suspended or banned, returns forbidden error with reason and expiration otherwise
+func (u *moderationUC) CheckAccount(ctx context.Context, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "moderationUC.CheckAccount")
+	defer span.Finish()
+
+	suspension, err := u.getActiveSuspension(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if suspension == nil {
+		return nil
+	}
+
+	accountErr := httpErrors.AccountSuspended
+	if suspension.Kind == models.SuspensionBan {
+		accountErr = httpErrors.AccountBanned
+	}
+	message := accountErr.Error()
+	if suspension.ExpiresAt != nil {
+		message = fmt.Sprintf("%s until %s", message, suspension.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	message = fmt.Sprintf("%s, reason: %s", message, suspension.Reason)
+
+	return httpErrors.NewRestError(http.StatusForbidden, message, nil)
+}
+
+// Get active suspension of user, cached in redis, returns nil when user is not suspended.
+// Absence of suspension is cached as suspension with empty id, expired suspensions are skipped,
+// so bans are lifted automatically even before the cache entry expires.
+func (u *moderationUC) getActiveSuspension(ctx context.Context, userID uuid.UUID) (*models.Suspension, error) {
+	key := u.getKeyWithPrefix(userID.String())
+
+	cached, err := u.redisRepo.GetSuspensionCtx(ctx, key)
+	if err != nil {
+		u.logger.Errorf("moderationUC.getActiveSuspension.GetSuspensionCtx: %v", err)
+	}
+	if cached != nil {
+		if cached.SuspensionID == uuid.Nil || !cached.Active(time.Now()) {
+			return nil, nil
+		}
+		return cached, nil
+	}
+
+	suspension, err := u.moderationRepo.GetActiveByUserID(ctx, userID)
+	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+		return nil, err
+	}
+	if suspension == nil {
+		suspension = &models.Suspension{}
+	}
+
+	if err = u.redisRepo.SetSuspensionCtx(ctx, key, cacheDuration, suspension); err != nil {
+		u.logger.Errorf("moderationUC.getActiveSuspension.SetSuspensionCtx: %s", err)
+	}
+
+	if suspension.SuspensionID == uuid.Nil {
+		return nil, nil
+	}
+
+	return suspension, nil
+}
+
+func (u *moderationUC) getKeyWithPrefix(userID string) string {
+	return fmt.Sprintf("%s: %s", basePrefix, userID)
+}
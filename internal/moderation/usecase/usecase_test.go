@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	authMock "github.com/AleksK1NG/api-mc/internal/auth/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/moderation/mock"
+	rbacMock "github.com/AleksK1NG/api-mc/internal/rbac/mock"
+	sessionMock "github.com/AleksK1NG/api-mc/internal/session/mock"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+func TestModerationUC_CheckAccount(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockModerationRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	moderationUC := NewModerationUseCase(cfg, mockModerationRepo, mockRedisRepo, nil, nil, nil, apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "moderationUC.CheckAccount")
+	defer span.Finish()
+
+	t.Run("Not suspended", func(t *testing.T) {
+		userID := uuid.New()
+		key := "api-moderation-suspension:: " + userID.String()
+
+		mockRedisRepo.EXPECT().GetSuspensionCtx(ctxWithTrace, key).Return(nil, errors.New("redis: nil"))
+		mockModerationRepo.EXPECT().GetActiveByUserID(ctxWithTrace, userID).Return(nil, sql.ErrNoRows)
+		mockRedisRepo.EXPECT().SetSuspensionCtx(ctxWithTrace, key, cacheDuration, &models.Suspension{}).Return(nil)
+
+		err := moderationUC.CheckAccount(ctx, userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Banned", func(t *testing.T) {
+		userID := uuid.New()
+		key := "api-moderation-suspension:: " + userID.String()
+		expiresAt := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+		suspension := &models.Suspension{
+			SuspensionID: uuid.New(),
+			UserID:       userID,
+			Kind:         models.SuspensionBan,
+			Reason:       "spam",
+			ExpiresAt:    &expiresAt,
+		}
+
+		mockRedisRepo.EXPECT().GetSuspensionCtx(ctxWithTrace, key).Return(nil, errors.New("redis: nil"))
+		mockModerationRepo.EXPECT().GetActiveByUserID(ctxWithTrace, userID).Return(suspension, nil)
+		mockRedisRepo.EXPECT().SetSuspensionCtx(ctxWithTrace, key, cacheDuration, suspension).Return(nil)
+
+		err := moderationUC.CheckAccount(ctx, userID)
+		require.Error(t, err)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), "Account is banned until 2030-01-02T15:04:05Z, reason: spam")
+	})
+
+	t.Run("Cached expired suspension", func(t *testing.T) {
+		userID := uuid.New()
+		key := "api-moderation-suspension:: " + userID.String()
+		expiresAt := time.Now().Add(-time.Minute)
+		suspension := &models.Suspension{
+			SuspensionID: uuid.New(),
+			UserID:       userID,
+			Kind:         models.SuspensionSuspend,
+			Reason:       "spam",
+			ExpiresAt:    &expiresAt,
+		}
+
+		mockRedisRepo.EXPECT().GetSuspensionCtx(ctxWithTrace, key).Return(suspension, nil)
+
+		err := moderationUC.CheckAccount(ctx, userID)
+		require.NoError(t, err)
+	})
+}
+
+func TestModerationUC_Suspend(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockModerationRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAuthRepo := authMock.NewMockRepository(ctrl)
+	mockRBACRepo := rbacMock.NewMockRepository(ctrl)
+	mockSessUC := sessionMock.NewMockUCSession(ctrl)
+	moderationUC := NewModerationUseCase(cfg, mockModerationRepo, mockRedisRepo, mockAuthRepo, mockRBACRepo, mockSessUC, apiLogger)
+
+	ctx := context.WithValue(context.Background(), utils.PermissionsCtxKey{}, []string{models.PermissionUsersSuspend})
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "moderationUC.Suspend")
+	defer span.Finish()
+
+	adminID := uuid.New()
+
+	t.Run("Suspend", func(t *testing.T) {
+		suspension := &models.Suspension{
+			UserID:    uuid.New(),
+			Kind:      models.SuspensionSuspend,
+			Reason:    " spam ",
+			CreatedBy: &adminID,
+		}
+		created := &models.Suspension{SuspensionID: uuid.New(), UserID: suspension.UserID, Kind: suspension.Kind, Reason: "spam"}
+		userRole := models.RoleUser
+
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, suspension.UserID).Return(&models.User{UserID: suspension.UserID, Role: &userRole}, nil)
+		mockRBACRepo.EXPECT().GetRolePermissions(ctxWithTrace, models.RoleUser).Return([]string{}, nil)
+		mockModerationRepo.EXPECT().Create(ctxWithTrace, suspension).Return(created, nil)
+		mockRedisRepo.EXPECT().DeleteSuspensionCtx(ctxWithTrace, "api-moderation-suspension:: "+suspension.UserID.String()).Return(nil)
+		mockSessUC.EXPECT().RevokeUserSessions(ctxWithTrace, suspension.UserID, "").Return(nil)
+
+		result, err := moderationUC.Suspend(ctx, suspension)
+		require.NoError(t, err)
+		require.Equal(t, created, result)
+		require.Equal(t, "spam", suspension.Reason)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		_, err := moderationUC.Suspend(ctx, &models.Suspension{
+			UserID:    adminID,
+			Kind:      models.SuspensionBan,
+			Reason:    "spam",
+			CreatedBy: &adminID,
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.SuspendSelf.Error())
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+
+		_, err := moderationUC.Suspend(ctx, &models.Suspension{
+			UserID:    uuid.New(),
+			Kind:      models.SuspensionBan,
+			Reason:    "spam",
+			ExpiresAt: &expiresAt,
+			CreatedBy: &adminID,
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.InvalidSuspension.Error())
+	})
+
+	t.Run("Privileged target", func(t *testing.T) {
+		adminRole := models.RoleAdmin
+		target := &models.User{UserID: uuid.New(), Role: &adminRole}
+
+		mockAuthRepo.EXPECT().GetByID(ctxWithTrace, target.UserID).Return(target, nil)
+		mockRBACRepo.EXPECT().GetRolePermissions(ctxWithTrace, models.RoleAdmin).
+			Return([]string{models.PermissionUsersSuspend, models.PermissionRolesManage}, nil)
+
+		_, err := moderationUC.Suspend(ctx, &models.Suspension{
+			UserID:    target.UserID,
+			Kind:      models.SuspensionBan,
+			Reason:    "spam",
+			CreatedBy: &adminID,
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.SuspendPrivileged.Error())
+	})
+}
+
+func TestModerationUC_GetSuspendedUsers(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockModerationRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	moderationUC := NewModerationUseCase(cfg, mockModerationRepo, mockRedisRepo, nil, nil, nil, apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "moderationUC.GetSuspendedUsers")
+	defer span.Finish()
+
+	t.Run("Banned", func(t *testing.T) {
+		usersList := &models.SuspendedUsersList{Users: make([]*models.SuspendedUser, 0)}
+		mockModerationRepo.EXPECT().GetSuspendedUsers(ctxWithTrace, models.SuspensionBan, nil).Return(usersList, nil)
+
+		result, err := moderationUC.GetSuspendedUsers(ctx, "banned", nil)
+		require.NoError(t, err)
+		require.Equal(t, usersList, result)
+	})
+
+	t.Run("Unknown status", func(t *testing.T) {
+		_, err := moderationUC.GetSuspendedUsers(ctx, "deleted", nil)
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.InvalidSuspendStatus.Error())
+	})
+}
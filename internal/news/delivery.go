@@ -9,5 +9,8 @@ type Handlers interface {
 	GetByID() echo.HandlerFunc
 	Delete() echo.HandlerFunc
 	GetNews() echo.HandlerFunc
-	SearchByTitle() echo.HandlerFunc
+	Search() echo.HandlerFunc
+	GetFeed() echo.HandlerFunc
+	GetNewsByTags() echo.HandlerFunc
+	GetPopularTags() echo.HandlerFunc
 }
@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -154,14 +155,16 @@ func (h newsHandlers) Delete() echo.HandlerFunc {
 
 // GetNews godoc
 // @Summary Get all news
-// @Description Get all news with pagination
+// @Description Get all news with pagination, category filter includes news of its subcategories
 // @Tags News
 // @Accept json
 // @Produce json
+// @Param category query string false "category slug"
 // @Param page query int false "page number" Format(page)
 // @Param size query int false "number of elements per page" Format(size)
 // @Param orderBy query int false "filter name" Format(orderBy)
 // @Success 200 {object} models.NewsList
+// @Failure 404 {object} httpErrors.RestError
 // @Router /news [get]
 func (h newsHandlers) GetNews() echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -174,7 +177,12 @@ func (h newsHandlers) GetNews() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		newsList, err := h.newsUC.GetNews(ctx, pq)
+		var newsList *models.NewsList
+		if categorySlug := c.QueryParam("category"); categorySlug != "" {
+			newsList, err = h.newsUC.GetNewsByCategory(ctx, categorySlug, pq)
+		} else {
+			newsList, err = h.newsUC.GetNews(ctx, pq)
+		}
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
@@ -184,20 +192,21 @@ func (h newsHandlers) GetNews() echo.HandlerFunc {
 	}
 }
 
-// SearchByTitle godoc
-// @Summary Search by title
-// @Description Search news by title
+// Search godoc
+// @Summary Search news
+// @Description Full text search of news title and content, most relevant first. Words are matched all together, "quoted phrase" matches adjacent words, word* matches prefix, -word excludes and OR matches either term
 // @Tags News
 // @Accept json
 // @Produce json
+// @Param q query string true "search query, title is accepted as well"
 // @Param page query int false "page number" Format(page)
 // @Param size query int false "number of elements per page" Format(size)
-// @Param orderBy query int false "filter name" Format(orderBy)
-// @Success 200 {object} models.NewsList
+// @Success 200 {object} models.NewsSearchList
+// @Failure 400 {object} httpErrors.RestError
 // @Router /news/search [get]
-func (h newsHandlers) SearchByTitle() echo.HandlerFunc {
+func (h newsHandlers) Search() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "newsHandlers.SearchByTitle")
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "newsHandlers.Search")
 		defer span.Finish()
 
 		pq, err := utils.GetPaginationFromCtx(c)
@@ -206,8 +215,89 @@ func (h newsHandlers) SearchByTitle() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		newsList, err := h.newsUC.SearchByTitle(ctx, c.QueryParam("title"), pq)
+		searchQuery := c.QueryParam("q")
+		if searchQuery == "" {
+			searchQuery = c.QueryParam("title")
+		}
+
+		searchList, err := h.newsUC.Search(ctx, searchQuery, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, searchList)
+	}
+}
+
+// GetFeed godoc
+// @Summary Get news feed
+// @Description Get news of followed authors, newest first, pass next_cursor of previous page as cursor to get next page
+// @Tags News
+// @Accept json
+// @Produce json
+// @Param size query int false "number of elements per page, max 100" Format(size)
+// @Param cursor query string false "next_cursor of previous page"
+// @Success 200 {object} models.NewsFeed
+// @Failure 400 {object} httpErrors.RestError
+// @Router /news/feed [get]
+func (h newsHandlers) GetFeed() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "newsHandlers.GetFeed")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		cq, err := utils.GetCursorFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewRestError(http.StatusBadRequest, httpErrors.BadQueryParams.Error(), err)))
+		}
 
+		feed, err := h.newsUC.GetFeed(ctx, user.UserID, cq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, feed)
+	}
+}
+
+// GetNewsByTags godoc
+// @Summary Get news by tags
+// @Description Get news tagged by all or any of given tags, newest first, tags are normalized so GoLang matches golang
+// @Tags News
+// @Accept json
+// @Produce json
+// @Param tags query string true "comma separated tags, parameter can be repeated"
+// @Param match query string false "all (default) or any"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.NewsList
+// @Failure 400 {object} httpErrors.RestError
+// @Router /news/tagged [get]
+func (h newsHandlers) GetNewsByTags() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "newsHandlers.GetNewsByTags")
+		defer span.Finish()
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tags := make([]string, 0)
+		for _, value := range c.QueryParams()["tags"] {
+			tags = append(tags, strings.Split(value, ",")...)
+		}
+
+		newsList, err := h.newsUC.GetNewsByTags(ctx, tags, c.QueryParam("match"), pq)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
@@ -216,3 +306,34 @@ func (h newsHandlers) SearchByTitle() echo.HandlerFunc {
 		return c.JSON(http.StatusOK, newsList)
 	}
 }
+
+// GetPopularTags godoc
+// @Summary Get popular tags
+// @Description Get tags used by most news with number of news tagged by them
+// @Tags News
+// @Accept json
+// @Produce json
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {array} models.Tag
+// @Router /news/tags [get]
+func (h newsHandlers) GetPopularTags() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "newsHandlers.GetPopularTags")
+		defer span.Finish()
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tags, err := h.newsUC.GetPopularTags(ctx, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, tags)
+	}
+}
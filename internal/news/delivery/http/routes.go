@@ -4,15 +4,19 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/AleksK1NG/api-mc/internal/middleware"
+	"github.com/AleksK1NG/api-mc/internal/models"
 	"github.com/AleksK1NG/api-mc/internal/news"
 )
 
 // Map news routes
 func MapNewsRoutes(newsGroup *echo.Group, h news.Handlers, mw *middleware.MiddlewareManager) {
-	newsGroup.POST("/create", h.Create(), mw.AuthSessionMiddleware, mw.CSRF)
-	newsGroup.PUT("/:news_id", h.Update(), mw.AuthSessionMiddleware, mw.CSRF)
-	newsGroup.DELETE("/:news_id", h.Delete(), mw.AuthSessionMiddleware, mw.CSRF)
+	newsGroup.POST("/create", h.Create(), mw.AuthSessionOrAPIKeyMiddleware(models.ScopeNewsWrite), mw.VerifiedEmailMiddleware, mw.CSRF)
+	newsGroup.PUT("/:news_id", h.Update(), mw.AuthSessionOrAPIKeyMiddleware(models.ScopeNewsWrite), mw.CSRF)
+	newsGroup.DELETE("/:news_id", h.Delete(), mw.AuthSessionOrAPIKeyMiddleware(models.ScopeNewsWrite), mw.CSRF)
+	newsGroup.GET("/feed", h.GetFeed(), mw.AuthSessionMiddleware)
 	newsGroup.GET("/:news_id", h.GetByID())
-	newsGroup.GET("/search", h.SearchByTitle())
+	newsGroup.GET("/search", h.Search())
+	newsGroup.GET("/tags", h.GetPopularTags())
+	newsGroup.GET("/tagged", h.GetNewsByTags())
 	newsGroup.GET("", h.GetNews())
 }
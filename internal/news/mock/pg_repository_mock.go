@@ -37,18 +37,18 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 }
 
 // Create mocks base method
-func (m *MockRepository) Create(ctx context.Context, news *models.News) (*models.News, error) {
+func (m *MockRepository) Create(ctx context.Context, news *models.News, language string) (*models.News, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, news)
+	ret := m.ctrl.Call(m, "Create", ctx, news, language)
 	ret0, _ := ret[0].(*models.News)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create
-func (mr *MockRepositoryMockRecorder) Create(ctx, news interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Create(ctx, news, language interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, news)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, news, language)
 }
 
 // Update mocks base method
@@ -110,17 +110,77 @@ func (mr *MockRepositoryMockRecorder) GetNews(ctx, pq interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNews", reflect.TypeOf((*MockRepository)(nil).GetNews), ctx, pq)
 }
 
-// SearchByTitle mocks base method
-func (m *MockRepository) SearchByTitle(ctx context.Context, title string, query *utils.PaginationQuery) (*models.NewsList, error) {
+// GetNewsByCategory mocks base method
+func (m *MockRepository) GetNewsByCategory(ctx context.Context, categorySlug string, pq *utils.PaginationQuery) (*models.NewsList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchByTitle", ctx, title, query)
+	ret := m.ctrl.Call(m, "GetNewsByCategory", ctx, categorySlug, pq)
 	ret0, _ := ret[0].(*models.NewsList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchByTitle indicates an expected call of SearchByTitle
-func (mr *MockRepositoryMockRecorder) SearchByTitle(ctx, title, query interface{}) *gomock.Call {
+// GetNewsByCategory indicates an expected call of GetNewsByCategory
+func (mr *MockRepositoryMockRecorder) GetNewsByCategory(ctx, categorySlug, pq interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByTitle", reflect.TypeOf((*MockRepository)(nil).SearchByTitle), ctx, title, query)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewsByCategory", reflect.TypeOf((*MockRepository)(nil).GetNewsByCategory), ctx, categorySlug, pq)
+}
+
+// Search mocks base method
+func (m *MockRepository) Search(ctx context.Context, language, tsQuery string, query *utils.PaginationQuery) (*models.NewsSearchList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, language, tsQuery, query)
+	ret0, _ := ret[0].(*models.NewsSearchList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search
+func (mr *MockRepositoryMockRecorder) Search(ctx, language, tsQuery, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRepository)(nil).Search), ctx, language, tsQuery, query)
+}
+
+// GetFeed mocks base method
+func (m *MockRepository) GetFeed(ctx context.Context, userID uuid.UUID, cq *utils.CursorQuery) (*models.NewsFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, userID, cq)
+	ret0, _ := ret[0].(*models.NewsFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed
+func (mr *MockRepositoryMockRecorder) GetFeed(ctx, userID, cq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockRepository)(nil).GetFeed), ctx, userID, cq)
+}
+
+// GetNewsByTags mocks base method
+func (m *MockRepository) GetNewsByTags(ctx context.Context, tags []string, matchAll bool, pq *utils.PaginationQuery) (*models.NewsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewsByTags", ctx, tags, matchAll, pq)
+	ret0, _ := ret[0].(*models.NewsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNewsByTags indicates an expected call of GetNewsByTags
+func (mr *MockRepositoryMockRecorder) GetNewsByTags(ctx, tags, matchAll, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewsByTags", reflect.TypeOf((*MockRepository)(nil).GetNewsByTags), ctx, tags, matchAll, pq)
+}
+
+// GetPopularTags mocks base method
+func (m *MockRepository) GetPopularTags(ctx context.Context, pq *utils.PaginationQuery) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopularTags", ctx, pq)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPopularTags indicates an expected call of GetPopularTags
+func (mr *MockRepositoryMockRecorder) GetPopularTags(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularTags", reflect.TypeOf((*MockRepository)(nil).GetPopularTags), ctx, pq)
 }
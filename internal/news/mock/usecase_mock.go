@@ -110,17 +110,77 @@ func (mr *MockUseCaseMockRecorder) GetNews(ctx, pq interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNews", reflect.TypeOf((*MockUseCase)(nil).GetNews), ctx, pq)
 }
 
-// SearchByTitle mocks base method
-func (m *MockUseCase) SearchByTitle(ctx context.Context, title string, query *utils.PaginationQuery) (*models.NewsList, error) {
+// GetNewsByCategory mocks base method
+func (m *MockUseCase) GetNewsByCategory(ctx context.Context, categorySlug string, pq *utils.PaginationQuery) (*models.NewsList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchByTitle", ctx, title, query)
+	ret := m.ctrl.Call(m, "GetNewsByCategory", ctx, categorySlug, pq)
 	ret0, _ := ret[0].(*models.NewsList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchByTitle indicates an expected call of SearchByTitle
-func (mr *MockUseCaseMockRecorder) SearchByTitle(ctx, title, query interface{}) *gomock.Call {
+// GetNewsByCategory indicates an expected call of GetNewsByCategory
+func (mr *MockUseCaseMockRecorder) GetNewsByCategory(ctx, categorySlug, pq interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByTitle", reflect.TypeOf((*MockUseCase)(nil).SearchByTitle), ctx, title, query)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewsByCategory", reflect.TypeOf((*MockUseCase)(nil).GetNewsByCategory), ctx, categorySlug, pq)
+}
+
+// Search mocks base method
+func (m *MockUseCase) Search(ctx context.Context, searchQuery string, query *utils.PaginationQuery) (*models.NewsSearchList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, searchQuery, query)
+	ret0, _ := ret[0].(*models.NewsSearchList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search
+func (mr *MockUseCaseMockRecorder) Search(ctx, searchQuery, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUseCase)(nil).Search), ctx, searchQuery, query)
+}
+
+// GetFeed mocks base method
+func (m *MockUseCase) GetFeed(ctx context.Context, userID uuid.UUID, cq *utils.CursorQuery) (*models.NewsFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, userID, cq)
+	ret0, _ := ret[0].(*models.NewsFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed
+func (mr *MockUseCaseMockRecorder) GetFeed(ctx, userID, cq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockUseCase)(nil).GetFeed), ctx, userID, cq)
+}
+
+// GetNewsByTags mocks base method
+func (m *MockUseCase) GetNewsByTags(ctx context.Context, tags []string, match string, pq *utils.PaginationQuery) (*models.NewsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewsByTags", ctx, tags, match, pq)
+	ret0, _ := ret[0].(*models.NewsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNewsByTags indicates an expected call of GetNewsByTags
+func (mr *MockUseCaseMockRecorder) GetNewsByTags(ctx, tags, match, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewsByTags", reflect.TypeOf((*MockUseCase)(nil).GetNewsByTags), ctx, tags, match, pq)
+}
+
+// GetPopularTags mocks base method
+func (m *MockUseCase) GetPopularTags(ctx context.Context, pq *utils.PaginationQuery) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopularTags", ctx, pq)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPopularTags indicates an expected call of GetPopularTags
+func (mr *MockUseCaseMockRecorder) GetPopularTags(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularTags", reflect.TypeOf((*MockUseCase)(nil).GetPopularTags), ctx, pq)
 }
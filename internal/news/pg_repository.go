@@ -12,10 +12,14 @@ import (
 
 // News Repository
 type Repository interface {
-	Create(ctx context.Context, news *models.News) (*models.News, error)
+	Create(ctx context.Context, news *models.News, language string) (*models.News, error)
 	Update(ctx context.Context, news *models.News) (*models.News, error)
 	GetNewsByID(ctx context.Context, newsID uuid.UUID) (*models.NewsBase, error)
 	Delete(ctx context.Context, newsID uuid.UUID) error
 	GetNews(ctx context.Context, pq *utils.PaginationQuery) (*models.NewsList, error)
-	SearchByTitle(ctx context.Context, title string, query *utils.PaginationQuery) (*models.NewsList, error)
+	GetNewsByCategory(ctx context.Context, categorySlug string, pq *utils.PaginationQuery) (*models.NewsList, error)
+	Search(ctx context.Context, language string, tsQuery string, query *utils.PaginationQuery) (*models.NewsSearchList, error)
+	GetFeed(ctx context.Context, userID uuid.UUID, cq *utils.CursorQuery) (*models.NewsFeed, error)
+	GetNewsByTags(ctx context.Context, tags []string, matchAll bool, pq *utils.PaginationQuery) (*models.NewsList, error)
+	GetPopularTags(ctx context.Context, pq *utils.PaginationQuery) ([]*models.Tag, error)
 }
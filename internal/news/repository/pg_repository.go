@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"sort"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/pgtype"
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
@@ -24,44 +26,87 @@ func NewNewsRepository(db *sqlx.DB) news.Repository {
 	return &newsRepo{db: db}
 }
 
-// Create news
-func (r *newsRepo) Create(ctx context.Context, news *models.News) (*models.News, error) {
+// Create news with its tags
+func (r *newsRepo) Create(ctx context.Context, news *models.News, language string) (*models.News, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "newsRepo.Create")
 	defer span.Finish()
 
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Create.BeginTxx")
+	}
+	defer tx.Rollback()
+
 	var n models.News
-	if err := r.db.QueryRowxContext(
+	if err = tx.QueryRowxContext(
 		ctx,
 		createNews,
 		&news.AuthorID,
 		&news.Title,
 		&news.Content,
-		&news.Category,
+		&news.ImageURL,
+		&news.CategoryID,
+		language,
 	).StructScan(&n); err != nil {
 		return nil, errors.Wrap(err, "newsRepo.Create.QueryRowxContext")
 	}
 
+	if len(news.Tags) > 0 {
+		if err = setTags(ctx, tx, n.NewsID, news.Tags); err != nil {
+			return nil, errors.Wrap(err, "newsRepo.Create.setTags")
+		}
+		n.Tags = append([]string(nil), news.Tags...)
+		sort.Strings(n.Tags)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Create.Commit")
+	}
+
 	return &n, nil
 }
 
-// Update news item
+// Update news item, tags are replaced only when given
 func (r *newsRepo) Update(ctx context.Context, news *models.News) (*models.News, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "newsRepo.Update")
 	defer span.Finish()
 
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Update.BeginTxx")
+	}
+	defer tx.Rollback()
+
 	var n models.News
-	if err := r.db.QueryRowxContext(
+	if err = tx.QueryRowxContext(
 		ctx,
 		updateNews,
 		&news.Title,
 		&news.Content,
 		&news.ImageURL,
-		&news.Category,
+		&news.CategoryID,
 		&news.NewsID,
+		news.ClearCategory,
 	).StructScan(&n); err != nil {
 		return nil, errors.Wrap(err, "newsRepo.Update.QueryRowxContext")
 	}
 
+	if news.Tags != nil {
+		if err = setTags(ctx, tx, n.NewsID, news.Tags); err != nil {
+			return nil, errors.Wrap(err, "newsRepo.Update.setTags")
+		}
+	}
+
+	tags, err := getTags(ctx, tx, n.NewsID)
+	if err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Update.getTags")
+	}
+	n.Tags = tags[n.NewsID]
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Update.Commit")
+	}
+
 	return &n, nil
 }
 
@@ -75,6 +120,12 @@ func (r *newsRepo) GetNewsByID(ctx context.Context, newsID uuid.UUID) (*models.N
 		return nil, errors.Wrap(err, "newsRepo.GetNewsByID.GetContext")
 	}
 
+	tags, err := getTags(ctx, r.db, newsID)
+	if err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetNewsByID.getTags")
+	}
+	n.Tags = tags[newsID]
+
 	return n, nil
 }
 
@@ -139,6 +190,10 @@ func (r *newsRepo) GetNews(ctx context.Context, pq *utils.PaginationQuery) (*mod
 		return nil, errors.Wrap(err, "newsRepo.GetNews.rows.Err")
 	}
 
+	if err = r.attachTags(ctx, newsList); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetNews.attachTags")
+	}
+
 	return &models.NewsList{
 		TotalCount: totalCount,
 		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
@@ -149,51 +204,297 @@ func (r *newsRepo) GetNews(ctx context.Context, pq *utils.PaginationQuery) (*mod
 	}, nil
 }
 
-// Find news by title
-func (r *newsRepo) SearchByTitle(ctx context.Context, title string, query *utils.PaginationQuery) (*models.NewsList, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "newsRepo.SearchByTitle")
+// Get news of category found by slug and of its descendant categories
+func (r *newsRepo) GetNewsByCategory(ctx context.Context, categorySlug string, pq *utils.PaginationQuery) (*models.NewsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "newsRepo.GetNewsByCategory")
 	defer span.Finish()
 
+	var categoryID uuid.UUID
+	if err := r.db.GetContext(ctx, &categoryID, getCategoryIDBySlug, categorySlug); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetNewsByCategory.GetContext.categoryID")
+	}
+
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, findByTitleCount, title); err != nil {
-		return nil, errors.Wrap(err, "newsRepo.SearchByTitle.GetContext")
+	if err := r.db.GetContext(ctx, &totalCount, getTotalCountByCategory, categoryID); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetNewsByCategory.GetContext.totalCount")
 	}
+
 	if totalCount == 0 {
 		return &models.NewsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			News:       make([]*models.News, 0),
+		}, nil
+	}
+
+	var newsList = make([]*models.News, 0, pq.GetSize())
+	if err := r.db.SelectContext(ctx, &newsList, getNewsByCategory, categoryID, pq.GetOffset(), pq.GetLimit()); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetNewsByCategory.SelectContext")
+	}
+
+	if err := r.attachTags(ctx, newsList); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetNewsByCategory.attachTags")
+	}
+
+	return &models.NewsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		News:       newsList,
+	}, nil
+}
+
+// Full text search of news title and content, tsQuery is to_tsquery expression in given text search language
+func (r *newsRepo) Search(ctx context.Context, language string, tsQuery string, query *utils.PaginationQuery) (*models.NewsSearchList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "newsRepo.Search")
+	defer span.Finish()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, searchNewsCount, language, tsQuery); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Search.GetContext")
+	}
+	if totalCount == 0 {
+		return &models.NewsSearchList{
 			TotalCount: totalCount,
 			TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
 			Page:       query.GetPage(),
 			Size:       query.GetSize(),
 			HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
-			News:       make([]*models.News, 0),
+			News:       make([]*models.NewsSearchHit, 0),
 		}, nil
 	}
 
-	var newsList = make([]*models.News, 0, query.GetSize())
-	rows, err := r.db.QueryxContext(ctx, findByTitle, title, query.GetOffset(), query.GetLimit())
+	var hits = make([]*models.NewsSearchHit, 0, query.GetSize())
+	rows, err := r.db.QueryxContext(ctx, searchNews, language, tsQuery, query.GetOffset(), query.GetLimit())
 	if err != nil {
-		return nil, errors.Wrap(err, "newsRepo.SearchByTitle.QueryxContext")
+		return nil, errors.Wrap(err, "newsRepo.Search.QueryxContext")
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		n := &models.News{}
-		if err = rows.StructScan(n); err != nil {
-			return nil, errors.Wrap(err, "newsRepo.SearchByTitle.StructScan")
+		hit := &models.NewsSearchHit{}
+		if err = rows.StructScan(hit); err != nil {
+			return nil, errors.Wrap(err, "newsRepo.Search.StructScan")
 		}
-		newsList = append(newsList, n)
+		hits = append(hits, hit)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, errors.Wrap(err, "newsRepo.SearchByTitle.rows.Err")
+		return nil, errors.Wrap(err, "newsRepo.Search.rows.Err")
 	}
 
-	return &models.NewsList{
+	newsList := make([]*models.News, 0, len(hits))
+	for _, hit := range hits {
+		newsList = append(newsList, &hit.News)
+	}
+	if err = r.attachTags(ctx, newsList); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.Search.attachTags")
+	}
+
+	return &models.NewsSearchList{
 		TotalCount: totalCount,
 		TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
 		Page:       query.GetPage(),
 		Size:       query.GetSize(),
 		HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+		News:       hits,
+	}, nil
+}
+
+// Get news of authors followed by user, newest first, one extra row is read to know if there are more
+func (r *newsRepo) GetFeed(ctx context.Context, userID uuid.UUID, cq *utils.CursorQuery) (*models.NewsFeed, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "newsRepo.GetFeed")
+	defer span.Finish()
+
+	var (
+		rows *sqlx.Rows
+		err  error
+	)
+	if cq.After == nil {
+		rows, err = r.db.QueryxContext(ctx, getFeed, userID, cq.GetLimit()+1)
+	} else {
+		rows, err = r.db.QueryxContext(ctx, getFeedAfter, userID, cq.GetLimit()+1, cq.After.CreatedAt, cq.After.ID)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetFeed.QueryxContext")
+	}
+	defer rows.Close()
+
+	newsList := make([]*models.News, 0, cq.GetLimit()+1)
+	for rows.Next() {
+		n := &models.News{}
+		if err = rows.StructScan(n); err != nil {
+			return nil, errors.Wrap(err, "newsRepo.GetFeed.StructScan")
+		}
+		newsList = append(newsList, n)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetFeed.rows.Err")
+	}
+
+	feed := &models.NewsFeed{Size: cq.GetLimit(), News: newsList}
+	if len(newsList) > cq.GetLimit() {
+		feed.News = newsList[:cq.GetLimit()]
+		feed.HasMore = true
+		last := feed.News[len(feed.News)-1]
+		feed.NextCursor = (&utils.Cursor{CreatedAt: last.CreatedAt, ID: last.NewsID}).Encode()
+	}
+
+	if err = r.attachTags(ctx, feed.News); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetFeed.attachTags")
+	}
+
+	return feed, nil
+}
+
+// Get news having all or any of given normalized tags, newest first
+func (r *newsRepo) GetNewsByTags(ctx context.Context, tags []string, matchAll bool, pq *utils.PaginationQuery) (*models.NewsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "newsRepo.GetNewsByTags")
+	defer span.Finish()
+
+	names, err := textArray(tags)
+	if err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetNewsByTags.textArray")
+	}
+	minMatches := 1
+	if matchAll {
+		minMatches = len(tags)
+	}
+
+	var totalCount int
+	if err = r.db.GetContext(ctx, &totalCount, getTotalCountByTags, names, minMatches); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetNewsByTags.GetContext.totalCount")
+	}
+
+	if totalCount == 0 {
+		return &models.NewsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			News:       make([]*models.News, 0),
+		}, nil
+	}
+
+	var newsList = make([]*models.News, 0, pq.GetSize())
+	if err = r.db.SelectContext(ctx, &newsList, getNewsByTags, names, minMatches, pq.GetOffset(), pq.GetLimit()); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetNewsByTags.SelectContext")
+	}
+
+	if err = r.attachTags(ctx, newsList); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetNewsByTags.attachTags")
+	}
+
+	return &models.NewsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
 		News:       newsList,
 	}, nil
 }
+
+// Get tags used by most news
+func (r *newsRepo) GetPopularTags(ctx context.Context, pq *utils.PaginationQuery) ([]*models.Tag, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "newsRepo.GetPopularTags")
+	defer span.Finish()
+
+	tags := make([]*models.Tag, 0, pq.GetSize())
+	if err := r.db.SelectContext(ctx, &tags, getPopularTags, pq.GetOffset(), pq.GetLimit()); err != nil {
+		return nil, errors.Wrap(err, "newsRepo.GetPopularTags.SelectContext")
+	}
+
+	return tags, nil
+}
+
+// Load tags of listed news with one query
+func (r *newsRepo) attachTags(ctx context.Context, newsList []*models.News) error {
+	if len(newsList) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(newsList))
+	for _, n := range newsList {
+		ids = append(ids, n.NewsID)
+	}
+
+	tags, err := getTags(ctx, r.db, ids...)
+	if err != nil {
+		return err
+	}
+	for _, n := range newsList {
+		n.Tags = tags[n.NewsID]
+	}
+
+	return nil
+}
+
+// Tag name of news row
+type newsTag struct {
+	NewsID uuid.UUID `db:"news_id"`
+	Name   string    `db:"name"`
+}
+
+// Tags of news by news id, ordered by name
+func getTags(ctx context.Context, q sqlx.QueryerContext, newsIDs ...uuid.UUID) (map[uuid.UUID][]string, error) {
+	ids := make([]string, 0, len(newsIDs))
+	for _, id := range newsIDs {
+		ids = append(ids, id.String())
+	}
+	idsArray, err := textArray(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*newsTag, 0)
+	if err = sqlx.SelectContext(ctx, q, &rows, getTagsByNewsIDs, idsArray); err != nil {
+		return nil, err
+	}
+
+	tags := make(map[uuid.UUID][]string, len(newsIDs))
+	for _, row := range rows {
+		tags[row.NewsID] = append(tags[row.NewsID], row.Name)
+	}
+
+	return tags, nil
+}
+
+// Replace tags of news, missing tags are created
+func setTags(ctx context.Context, tx *sqlx.Tx, newsID uuid.UUID, tags []string) error {
+	if _, err := tx.ExecContext(ctx, deleteNewsTags, newsID); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	names, err := textArray(tags)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, createTags, names); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, createNewsTags, newsID, names); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Postgres array parameter, database/sql does not pass slices to the driver
+func textArray(values []string) (*pgtype.TextArray, error) {
+	array := &pgtype.TextArray{}
+	if err := array.Set(values); err != nil {
+		return nil, err
+	}
+	return array, nil
+}
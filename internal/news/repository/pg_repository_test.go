@@ -2,14 +2,18 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
 )
 
 func TestNewsRepo_Create(t *testing.T) {
@@ -31,19 +35,34 @@ func TestNewsRepo_Create(t *testing.T) {
 
 		rows := sqlmock.NewRows([]string{"author_id", "title", "content"}).AddRow(authorUID, title, content)
 
+		imageURL := "http://localhost:9000/news-images/image.jpg"
+		categoryID := uuid.New()
+
 		news := &models.News{
-			AuthorID: authorUID,
-			Title:    title,
-			Content:  content,
+			AuthorID:   authorUID,
+			Title:      title,
+			Content:    content,
+			ImageURL:   &imageURL,
+			CategoryID: &categoryID,
+			Tags:       []string{"postgres", "golang"},
 		}
+		tags, err := textArray(news.Tags)
+		require.NoError(t, err)
 
-		mock.ExpectQuery(createNews).WithArgs(news.AuthorID, news.Title, news.Content, news.Category).WillReturnRows(rows)
+		mock.ExpectBegin()
+		mock.ExpectQuery(createNews).WithArgs(news.AuthorID, news.Title, news.Content, imageURL, categoryID, "english").WillReturnRows(rows)
+		mock.ExpectExec(deleteNewsTags).WithArgs(uuid.Nil).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(createTags).WithArgs(tags).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(createNewsTags).WithArgs(uuid.Nil, tags).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
 
-		createdNews, err := newsRepo.Create(context.Background(), news)
+		createdNews, err := newsRepo.Create(context.Background(), news, "english")
 
 		require.NoError(t, err)
 		require.NotNil(t, createdNews)
 		require.Equal(t, news.Title, createdNews.Title)
+		require.Equal(t, []string{"golang", "postgres"}, createdNews.Tags)
+		require.NoError(t, mock.ExpectationsWereMet())
 	})
 }
 
@@ -72,12 +91,19 @@ func TestNewsRepo_Update(t *testing.T) {
 			Content: content,
 		}
 
+		ids, err := textArray([]string{newsUID.String()})
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
 		mock.ExpectQuery(updateNews).WithArgs(news.Title,
 			news.Content,
 			news.ImageURL,
-			news.Category,
+			news.CategoryID,
 			news.NewsID,
+			false,
 		).WillReturnRows(rows)
+		mock.ExpectQuery(getTagsByNewsIDs).WithArgs(ids).WillReturnRows(sqlmock.NewRows([]string{"news_id", "name"}))
+		mock.ExpectCommit()
 
 		updatedNews, err := newsRepo.Update(context.Background(), news)
 
@@ -85,6 +111,37 @@ func TestNewsRepo_Update(t *testing.T) {
 		require.NotNil(t, updateNews)
 		require.Equal(t, updatedNews, news)
 	})
+
+	t.Run("Clear category", func(t *testing.T) {
+		newsUID := uuid.New()
+
+		rows := sqlmock.NewRows([]string{"news_id", "category_id"}).AddRow(newsUID, nil)
+
+		news := &models.News{
+			NewsID:        newsUID,
+			ClearCategory: true,
+		}
+
+		ids, err := textArray([]string{newsUID.String()})
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateNews).WithArgs(news.Title,
+			news.Content,
+			news.ImageURL,
+			news.CategoryID,
+			news.NewsID,
+			true,
+		).WillReturnRows(rows)
+		mock.ExpectQuery(getTagsByNewsIDs).WithArgs(ids).WillReturnRows(sqlmock.NewRows([]string{"news_id", "name"}))
+		mock.ExpectCommit()
+
+		updatedNews, err := newsRepo.Update(context.Background(), news)
+
+		require.NoError(t, err)
+		require.Nil(t, updatedNews.CategoryID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestNewsRepo_Delete(t *testing.T) {
@@ -108,3 +165,205 @@ func TestNewsRepo_Delete(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestNewsRepo_GetNewsByCategory(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	newsRepo := NewNewsRepository(sqlxDB)
+	pq := &utils.PaginationQuery{Size: 10, Page: 1}
+
+	t.Run("News of category subtree", func(t *testing.T) {
+		categoryID := uuid.New()
+		subcategoryID := uuid.New()
+		now := time.Now().UTC()
+
+		mock.ExpectQuery(getCategoryIDBySlug).WithArgs("sports").
+			WillReturnRows(sqlmock.NewRows([]string{"category_id"}).AddRow(categoryID))
+		mock.ExpectQuery(getTotalCountByCategory).WithArgs(categoryID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		firstID := uuid.New()
+		secondID := uuid.New()
+		ids, err := textArray([]string{firstID.String(), secondID.String()})
+		require.NoError(t, err)
+
+		mock.ExpectQuery(getNewsByCategory).WithArgs(categoryID, 0, 10).WillReturnRows(
+			sqlmock.NewRows([]string{"news_id", "author_id", "title", "content", "image_url", "category_id", "updated_at", "created_at"}).
+				AddRow(firstID, uuid.New(), "Match title", "Content", nil, categoryID, now, now).
+				AddRow(secondID, uuid.New(), "Final title", "Content", nil, subcategoryID, now, now))
+		mock.ExpectQuery(getTagsByNewsIDs).WithArgs(ids).WillReturnRows(
+			sqlmock.NewRows([]string{"news_id", "name"}).AddRow(firstID, "football"))
+
+		newsList, err := newsRepo.GetNewsByCategory(context.Background(), "sports", pq)
+		require.NoError(t, err)
+		require.Equal(t, 2, newsList.TotalCount)
+		require.Len(t, newsList.News, 2)
+		require.Equal(t, subcategoryID, *newsList.News[1].CategoryID)
+		require.Equal(t, []string{"football"}, newsList.News[0].Tags)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown category", func(t *testing.T) {
+		mock.ExpectQuery(getCategoryIDBySlug).WithArgs("missing").WillReturnError(sql.ErrNoRows)
+
+		newsList, err := newsRepo.GetNewsByCategory(context.Background(), "missing", pq)
+		require.Nil(t, newsList)
+		require.True(t, errors.Is(err, sql.ErrNoRows))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestNewsRepo_GetFeed(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	newsRepo := NewNewsRepository(sqlxDB)
+
+	columns := []string{"news_id", "author_id", "title", "content", "image_url", "category_id", "updated_at", "created_at"}
+	userID := uuid.New()
+	authorID := uuid.New()
+	now := time.Now().UTC()
+
+	t.Run("First page with more", func(t *testing.T) {
+		secondID := uuid.New()
+		rows := sqlmock.NewRows(columns).
+			AddRow(uuid.New(), authorID, "Newest title", "Content", nil, nil, now, now).
+			AddRow(secondID, authorID, "Second title", "Content", nil, nil, now, now.Add(-time.Minute)).
+			AddRow(uuid.New(), authorID, "Third title", "Content", nil, nil, now, now.Add(-2*time.Minute))
+
+		mock.ExpectQuery(getFeed).WithArgs(userID, 3).WillReturnRows(rows)
+		mock.ExpectQuery(getTagsByNewsIDs).WithArgs(sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"news_id", "name"}))
+
+		feed, err := newsRepo.GetFeed(context.Background(), userID, &utils.CursorQuery{Size: 2})
+		require.NoError(t, err)
+		require.Len(t, feed.News, 2)
+		require.True(t, feed.HasMore)
+
+		cursor, err := utils.DecodeCursor(feed.NextCursor)
+		require.NoError(t, err)
+		require.Equal(t, secondID, cursor.ID)
+		require.True(t, now.Add(-time.Minute).Equal(cursor.CreatedAt))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Last page after cursor", func(t *testing.T) {
+		after := &utils.Cursor{CreatedAt: now.Add(-time.Minute), ID: uuid.New()}
+		rows := sqlmock.NewRows(columns).
+			AddRow(uuid.New(), authorID, "Third title", "Content", nil, nil, now, now.Add(-2*time.Minute))
+
+		mock.ExpectQuery(getFeedAfter).WithArgs(userID, 3, after.CreatedAt, after.ID).WillReturnRows(rows)
+		mock.ExpectQuery(getTagsByNewsIDs).WithArgs(sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"news_id", "name"}))
+
+		feed, err := newsRepo.GetFeed(context.Background(), userID, &utils.CursorQuery{Size: 2, After: after})
+		require.NoError(t, err)
+		require.Len(t, feed.News, 1)
+		require.False(t, feed.HasMore)
+		require.Empty(t, feed.NextCursor)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestNewsRepo_Search(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	newsRepo := NewNewsRepository(sqlxDB)
+	query := &utils.PaginationQuery{Size: 10, Page: 1}
+
+	t.Run("Search", func(t *testing.T) {
+		newsUID := uuid.New()
+		countRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+		rows := sqlmock.NewRows([]string{"news_id", "title", "content", "rank", "title_highlight", "snippet"}).
+			AddRow(newsUID, "Go generics", "Generics are coming to go", 0.5, "\x02Go\x03 generics", "Generics are coming to \x02go\x03")
+
+		mock.ExpectQuery(searchNewsCount).WithArgs("english", "go").WillReturnRows(countRows)
+		mock.ExpectQuery(searchNews).WithArgs("english", "go", 0, 10).WillReturnRows(rows)
+		mock.ExpectQuery(getTagsByNewsIDs).WithArgs(sqlmock.AnyArg()).WillReturnRows(
+			sqlmock.NewRows([]string{"news_id", "name"}).AddRow(newsUID, "golang"))
+
+		searchList, err := newsRepo.Search(context.Background(), "english", "go", query)
+		require.NoError(t, err)
+		require.Equal(t, 1, searchList.TotalCount)
+		require.Len(t, searchList.News, 1)
+		require.Equal(t, newsUID, searchList.News[0].NewsID)
+		require.Equal(t, 0.5, searchList.News[0].Rank)
+		require.Equal(t, "\x02Go\x03 generics", searchList.News[0].TitleHighlight)
+		require.Equal(t, []string{"golang"}, searchList.News[0].Tags)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No hits", func(t *testing.T) {
+		countRows := sqlmock.NewRows([]string{"count"}).AddRow(0)
+
+		mock.ExpectQuery(searchNewsCount).WithArgs("english", "rust").WillReturnRows(countRows)
+
+		searchList, err := newsRepo.Search(context.Background(), "english", "rust", query)
+		require.NoError(t, err)
+		require.Equal(t, 0, searchList.TotalCount)
+		require.Empty(t, searchList.News)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestNewsRepo_GetNewsByTags(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	newsRepo := NewNewsRepository(sqlxDB)
+	pq := &utils.PaginationQuery{Size: 10, Page: 1}
+	tags := []string{"golang", "postgres"}
+	names, err := textArray(tags)
+	require.NoError(t, err)
+
+	t.Run("Match all", func(t *testing.T) {
+		newsUID := uuid.New()
+		now := time.Now().UTC()
+
+		mock.ExpectQuery(getTotalCountByTags).WithArgs(names, 2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(getNewsByTags).WithArgs(names, 2, 0, 10).WillReturnRows(
+			sqlmock.NewRows([]string{"news_id", "author_id", "title", "content", "image_url", "category_id", "updated_at", "created_at"}).
+				AddRow(newsUID, uuid.New(), "Tagged title", "Content", nil, nil, now, now))
+		mock.ExpectQuery(getTagsByNewsIDs).WithArgs(sqlmock.AnyArg()).WillReturnRows(
+			sqlmock.NewRows([]string{"news_id", "name"}).AddRow(newsUID, "golang").AddRow(newsUID, "postgres"))
+
+		newsList, err := newsRepo.GetNewsByTags(context.Background(), tags, true, pq)
+		require.NoError(t, err)
+		require.Equal(t, 1, newsList.TotalCount)
+		require.Equal(t, tags, newsList.News[0].Tags)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Match any without hits", func(t *testing.T) {
+		mock.ExpectQuery(getTotalCountByTags).WithArgs(names, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		newsList, err := newsRepo.GetNewsByTags(context.Background(), tags, false, pq)
+		require.NoError(t, err)
+		require.Equal(t, 0, newsList.TotalCount)
+		require.Empty(t, newsList.News)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,25 +1,25 @@
 package repository
 
 const (
-	createNews = `INSERT INTO news (author_id, title, content, image_url, category, created_at) 
-					VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($4, ''), now()) 
-					RETURNING *`
+	createNews = `INSERT INTO news (author_id, title, content, image_url, category_id, search_language, created_at) 
+					VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6::regconfig, now()) 
+					RETURNING news_id, author_id, title, content, image_url, category_id, created_at, updated_at`
 
 	updateNews = `UPDATE news 
 					SET title = COALESCE(NULLIF($1, ''), title),
 						content = COALESCE(NULLIF($2, ''), content), 
 					    image_url = COALESCE(NULLIF($3, ''), image_url), 
-					    category = COALESCE(NULLIF($4, ''), category), 
+					    category_id = CASE WHEN $6::boolean THEN NULL ELSE COALESCE($4, category_id) END, 
 					    updated_at = now() 
 					WHERE news_id = $5
-					RETURNING *`
+					RETURNING news_id, author_id, title, content, image_url, category_id, created_at, updated_at`
 
 	getNewsByID = `SELECT n.news_id,
        n.title,
        n.content,
        n.updated_at,
        n.image_url,
-       n.category,
+       n.category_id,
        CONCAT(u.first_name, ' ', u.last_name) as author,
        u.user_id as author_id
 FROM news n
@@ -30,17 +30,119 @@ WHERE news_id = $1`
 
 	getTotalCount = `SELECT COUNT(news_id) FROM news`
 
-	getNews = `SELECT news_id, author_id, title, content, image_url, category, updated_at, created_at 
+	getNews = `SELECT news_id, author_id, title, content, image_url, category_id, updated_at, created_at 
 				FROM news 
 				ORDER BY created_at, updated_at OFFSET $1 LIMIT $2`
 
-	findByTitleCount = `SELECT COUNT(*)
+	getCategoryIDBySlug = `SELECT category_id FROM categories WHERE slug = $1`
+
+	// Category and all of its descendants, UNION stops on already visited rows
+	getTotalCountByCategory = `WITH RECURSIVE subtree AS (
+					SELECT category_id FROM categories WHERE category_id = $1
+					UNION
+					SELECT c.category_id FROM categories c JOIN subtree s ON c.parent_id = s.category_id
+				)
+				SELECT COUNT(news_id) FROM news WHERE category_id IN (SELECT category_id FROM subtree)`
+
+	getNewsByCategory = `WITH RECURSIVE subtree AS (
+					SELECT category_id FROM categories WHERE category_id = $1
+					UNION
+					SELECT c.category_id FROM categories c JOIN subtree s ON c.parent_id = s.category_id
+				)
+				SELECT news_id, author_id, title, content, image_url, category_id, updated_at, created_at 
+				FROM news 
+				WHERE category_id IN (SELECT category_id FROM subtree)
+				ORDER BY created_at, updated_at OFFSET $2 LIMIT $3`
+
+	searchNewsCount = `SELECT COUNT(*)
+					FROM news
+					WHERE search_vector @@ to_tsquery($1::regconfig, $2)`
+
+	// Page is ranked first, so highlights are built only for returned rows.
+	// Matches are marked with control characters removed from text beforehand, highlights are html escaped by caller
+	searchNews = `SELECT n.news_id, n.author_id, n.title, n.content, n.image_url, n.category_id, n.updated_at, n.created_at, n.rank,
+					   ts_headline($1::regconfig, translate(n.title, E'\x02\x03', ''), n.query,
+						   E'HighlightAll=true, StartSel="\x02", StopSel="\x03"') AS title_highlight,
+					   ts_headline($1::regconfig, translate(n.content, E'\x02\x03', ''), n.query,
+						   E'StartSel="\x02", StopSel="\x03", MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" ... "') AS snippet
+				FROM (
+					SELECT news_id, author_id, title, content, image_url, category_id, updated_at, created_at,
+						   ts_rank_cd(search_vector, query, 1) AS rank, query
+					FROM news, to_tsquery($1::regconfig, $2) query
+					WHERE search_vector @@ query
+					ORDER BY rank DESC, created_at DESC, news_id
+					OFFSET $3 LIMIT $4
+				) n
+				ORDER BY n.rank DESC, n.created_at DESC, n.news_id`
+
+	// Each followed author contributes at most limit rows after cursor from news_author_id_created_at_idx,
+	// so cost is bounded by followees and limit and not by total news count
+	getFeed = `SELECT n.news_id, n.author_id, n.title, n.content, n.image_url, n.category_id, n.updated_at, n.created_at
+				FROM follows f
+						 CROSS JOIN LATERAL (
+					SELECT news_id, author_id, title, content, image_url, category_id, updated_at, created_at
 					FROM news
-					WHERE title ILIKE '%' || $1 || '%'`
+					WHERE author_id = f.followee_id
+					ORDER BY created_at DESC, news_id DESC
+					LIMIT $2
+					) n
+				WHERE f.follower_id = $1
+				ORDER BY n.created_at DESC, n.news_id DESC
+				LIMIT $2`
 
-	findByTitle = `SELECT news_id, author_id, title, content, image_url, category, updated_at, created_at
+	getFeedAfter = `SELECT n.news_id, n.author_id, n.title, n.content, n.image_url, n.category_id, n.updated_at, n.created_at
+				FROM follows f
+						 CROSS JOIN LATERAL (
+					SELECT news_id, author_id, title, content, image_url, category_id, updated_at, created_at
 					FROM news
-					WHERE title ILIKE '%' || $1 || '%'
-					ORDER BY title, created_at, updated_at
-					OFFSET $2 LIMIT $3`
+					WHERE author_id = f.followee_id
+					  AND (created_at, news_id) < ($3, $4)
+					ORDER BY created_at DESC, news_id DESC
+					LIMIT $2
+					) n
+				WHERE f.follower_id = $1
+				ORDER BY n.created_at DESC, n.news_id DESC
+				LIMIT $2`
+
+	deleteNewsTags = `DELETE FROM news_tags WHERE news_id = $1`
+
+	createTags = `INSERT INTO tags (name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`
+
+	createNewsTags = `INSERT INTO news_tags (news_id, tag_id) 
+				SELECT $1, tag_id FROM tags WHERE name = ANY($2::text[])`
+
+	getTagsByNewsIDs = `SELECT nt.news_id, t.name 
+				FROM news_tags nt 
+				JOIN tags t ON t.tag_id = nt.tag_id 
+				WHERE nt.news_id = ANY($1::uuid[]) 
+				ORDER BY t.name`
+
+	getPopularTags = `SELECT t.name, COUNT(nt.news_id) AS news_count 
+				FROM tags t 
+				JOIN news_tags nt ON nt.tag_id = t.tag_id 
+				GROUP BY t.tag_id, t.name 
+				ORDER BY news_count DESC, t.name OFFSET $1 LIMIT $2`
+
+	// News having at least $2 of given tags, so 1 matches any of them and number of tags matches all of them
+	getTotalCountByTags = `SELECT COUNT(*) 
+				FROM (
+					SELECT nt.news_id 
+					FROM news_tags nt 
+					JOIN tags t ON t.tag_id = nt.tag_id 
+					WHERE t.name = ANY($1::text[]) 
+					GROUP BY nt.news_id 
+					HAVING COUNT(*) >= $2
+				) matched`
+
+	getNewsByTags = `SELECT n.news_id, n.author_id, n.title, n.content, n.image_url, n.category_id, n.updated_at, n.created_at 
+				FROM news n 
+				JOIN (
+					SELECT nt.news_id 
+					FROM news_tags nt 
+					JOIN tags t ON t.tag_id = nt.tag_id 
+					WHERE t.name = ANY($1::text[]) 
+					GROUP BY nt.news_id 
+					HAVING COUNT(*) >= $2
+				) matched ON matched.news_id = n.news_id 
+				ORDER BY n.created_at DESC, n.news_id OFFSET $3 LIMIT $4`
 )
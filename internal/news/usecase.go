@@ -17,5 +17,9 @@ type UseCase interface {
 	GetNewsByID(ctx context.Context, newsID uuid.UUID) (*models.NewsBase, error)
 	Delete(ctx context.Context, newsID uuid.UUID) error
 	GetNews(ctx context.Context, pq *utils.PaginationQuery) (*models.NewsList, error)
-	SearchByTitle(ctx context.Context, title string, query *utils.PaginationQuery) (*models.NewsList, error)
+	GetNewsByCategory(ctx context.Context, categorySlug string, pq *utils.PaginationQuery) (*models.NewsList, error)
+	Search(ctx context.Context, searchQuery string, query *utils.PaginationQuery) (*models.NewsSearchList, error)
+	GetFeed(ctx context.Context, userID uuid.UUID, cq *utils.CursorQuery) (*models.NewsFeed, error)
+	GetNewsByTags(ctx context.Context, tags []string, match string, pq *utils.PaginationQuery) (*models.NewsList, error)
+	GetPopularTags(ctx context.Context, pq *utils.PaginationQuery) ([]*models.Tag, error)
 }
@@ -3,7 +3,10 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"html"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/opentracing/opentracing-go"
@@ -18,10 +21,15 @@ import (
 )
 
 const (
-	basePrefix    = "api-news:"
-	cacheDuration = 3600
+	basePrefix            = "api-news:"
+	cacheDuration         = 3600
+	defaultSearchLanguage = "english"
+	maxTags               = 10
+	maxTagLength          = 32
 )
 
+var highlightReplacer = strings.NewReplacer(models.HighlightStartSel, "<mark>", models.HighlightStopSel, "</mark>")
+
 // News UseCase
 type newsUC struct {
 	cfg       *config.Config
@@ -51,7 +59,11 @@ func (u *newsUC) Create(ctx context.Context, news *models.News) (*models.News, e
 		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "newsUC.Create.ValidateStruct"))
 	}
 
-	n, err := u.newsRepo.Create(ctx, news)
+	if news.Tags, err = normalizeTags(news.Tags); err != nil {
+		return nil, err
+	}
+
+	n, err := u.newsRepo.Create(ctx, news, u.getSearchLanguage())
 	if err != nil {
 		return nil, err
 	}
@@ -69,10 +81,21 @@ func (u *newsUC) Update(ctx context.Context, news *models.News) (*models.News, e
 		return nil, err
 	}
 
-	if err = utils.ValidateIsOwner(ctx, newsByID.AuthorID.String(), u.logger); err != nil {
+	if err = utils.ValidateIsOwner(ctx, newsByID.AuthorID.String(), u.logger); err != nil && !utils.HasPermission(ctx, models.PermissionNewsUpdateAny) {
 		return nil, httpErrors.NewRestError(http.StatusForbidden, "Forbidden", errors.Wrap(err, "newsUC.Update.ValidateIsOwner"))
 	}
 
+	if news.ClearCategory && news.CategoryID != nil {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.CategoryClearConflict.Error(), nil)
+	}
+
+	// Omitted tags are kept, empty list removes them
+	if news.Tags != nil {
+		if news.Tags, err = normalizeTags(news.Tags); err != nil {
+			return nil, err
+		}
+	}
+
 	updatedUser, err := u.newsRepo.Update(ctx, news)
 	if err != nil {
 		return nil, err
@@ -120,7 +143,7 @@ func (u *newsUC) Delete(ctx context.Context, newsID uuid.UUID) error {
 		return err
 	}
 
-	if err = utils.ValidateIsOwner(ctx, newsByID.AuthorID.String(), u.logger); err != nil {
+	if err = utils.ValidateIsOwner(ctx, newsByID.AuthorID.String(), u.logger); err != nil && !utils.HasPermission(ctx, models.PermissionNewsDeleteAny) {
 		return httpErrors.NewRestError(http.StatusForbidden, "Forbidden", errors.Wrap(err, "newsUC.Delete.ValidateIsOwner"))
 	}
 
@@ -143,14 +166,102 @@ func (u *newsUC) GetNews(ctx context.Context, pq *utils.PaginationQuery) (*model
 	return u.newsRepo.GetNews(ctx, pq)
 }
 
-// Find nes by title
-func (u *newsUC) SearchByTitle(ctx context.Context, title string, query *utils.PaginationQuery) (*models.NewsList, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "newsUC.SearchByTitle")
+// Get news of category and its descendant categories, unknown category is not found
+func (u *newsUC) GetNewsByCategory(ctx context.Context, categorySlug string, pq *utils.PaginationQuery) (*models.NewsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "newsUC.GetNewsByCategory")
 	defer span.Finish()
 
-	return u.newsRepo.SearchByTitle(ctx, title, query)
+	return u.newsRepo.GetNewsByCategory(ctx, strings.ToLower(strings.TrimSpace(categorySlug)), pq)
+}
+
+// Full text search of news title and content, see utils.ToTSQuery for query syntax
+func (u *newsUC) Search(ctx context.Context, searchQuery string, query *utils.PaginationQuery) (*models.NewsSearchList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "newsUC.Search")
+	defer span.Finish()
+
+	tsQuery := utils.ToTSQuery(searchQuery)
+	if tsQuery == "" {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.BadQueryParams.Error(), errors.New("newsUC.Search query has no words"))
+	}
+
+	searchList, err := u.newsRepo.Search(ctx, u.getSearchLanguage(), tsQuery, query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hit := range searchList.News {
+		hit.TitleHighlight = escapeHighlight(hit.TitleHighlight)
+		hit.Snippet = escapeHighlight(hit.Snippet)
+	}
+
+	return searchList, nil
+}
+
+// Get news feed of user from followed authors
+func (u *newsUC) GetFeed(ctx context.Context, userID uuid.UUID, cq *utils.CursorQuery) (*models.NewsFeed, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "newsUC.GetFeed")
+	defer span.Finish()
+
+	return u.newsRepo.GetFeed(ctx, userID, cq)
+}
+
+// Get news tagged by all or any of given tags, tags are normalized like on news create
+func (u *newsUC) GetNewsByTags(ctx context.Context, tags []string, match string, pq *utils.PaginationQuery) (*models.NewsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "newsUC.GetNewsByTags")
+	defer span.Finish()
+
+	if match == "" {
+		match = models.TagsMatchAll
+	}
+	if match != models.TagsMatchAll && match != models.TagsMatchAny {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidTagsMatch.Error(), nil)
+	}
+
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.TagsRequired.Error(), nil)
+	}
+
+	return u.newsRepo.GetNewsByTags(ctx, tags, match == models.TagsMatchAll, pq)
+}
+
+// Get tags used by most news with their news count
+func (u *newsUC) GetPopularTags(ctx context.Context, pq *utils.PaginationQuery) ([]*models.Tag, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "newsUC.GetPopularTags")
+	defer span.Finish()
+
+	return u.newsRepo.GetPopularTags(ctx, pq)
 }
 
 func (u *newsUC) getKeyWithPrefix(newsID string) string {
 	return fmt.Sprintf("%s: %s", basePrefix, newsID)
 }
+
+func (u *newsUC) getSearchLanguage() string {
+	if u.cfg.Search.Language == "" {
+		return defaultSearchLanguage
+	}
+	return u.cfg.Search.Language
+}
+
+// Normalize tags and check their count and length
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := utils.NormalizeTags(tags)
+	if len(normalized) > maxTags {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.TooManyTags.Error(), nil)
+	}
+	for _, tag := range normalized {
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.TagTooLong.Error(), nil)
+		}
+	}
+	return normalized, nil
+}
+
+// Html escape highlighted text and mark matched words with <mark> tags, news content is plain text
+func escapeHighlight(text string) string {
+	return highlightReplacer.Replace(html.EscapeString(text))
+}
@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -10,8 +12,10 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/require"
 
+	"github.com/AleksK1NG/api-mc/config"
 	"github.com/AleksK1NG/api-mc/internal/models"
 	"github.com/AleksK1NG/api-mc/internal/news/mock"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
 	"github.com/AleksK1NG/api-mc/pkg/logger"
 	"github.com/AleksK1NG/api-mc/pkg/utils"
 )
@@ -24,7 +28,7 @@ func TestNewsUC_Create(t *testing.T) {
 
 	apiLogger := logger.NewApiLogger(nil)
 	mockNewsRepo := mock.NewMockRepository(ctrl)
-	newsUC := NewNewsUseCase(nil, mockNewsRepo, nil, apiLogger)
+	newsUC := NewNewsUseCase(&config.Config{}, mockNewsRepo, nil, apiLogger)
 
 	userUID := uuid.New()
 
@@ -42,7 +46,7 @@ func TestNewsUC_Create(t *testing.T) {
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "newsUC.Create")
 	defer span.Finish()
 
-	mockNewsRepo.EXPECT().Create(ctxWithTrace, gomock.Eq(news)).Return(news, nil)
+	mockNewsRepo.EXPECT().Create(ctxWithTrace, gomock.Eq(news), defaultSearchLanguage).Return(news, nil)
 
 	createdNews, err := newsUC.Create(ctx, news)
 	require.NoError(t, err)
@@ -50,6 +54,68 @@ func TestNewsUC_Create(t *testing.T) {
 	require.NotNil(t, createdNews)
 }
 
+func TestNewsUC_CreateWithTags(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewApiLogger(nil)
+	mockNewsRepo := mock.NewMockRepository(ctrl)
+	newsUC := NewNewsUseCase(&config.Config{}, mockNewsRepo, nil, apiLogger)
+
+	user := &models.User{UserID: uuid.New()}
+	ctx := context.WithValue(context.Background(), utils.UserCtxKey{}, user)
+
+	t.Run("Normalized tags", func(t *testing.T) {
+		news := &models.News{
+			Title:   "Title long text string greater then 20 characters",
+			Content: "Content long text string greater then 20 characters",
+			Tags:    []string{"GoLang", " golang ", "#golang", "Machine Learning", ""},
+		}
+
+		mockNewsRepo.EXPECT().Create(gomock.Any(), gomock.Any(), defaultSearchLanguage).DoAndReturn(
+			func(ctx context.Context, news *models.News, language string) (*models.News, error) {
+				return news, nil
+			},
+		)
+
+		createdNews, err := newsUC.Create(ctx, news)
+		require.NoError(t, err)
+		require.Equal(t, []string{"golang", "machine-learning"}, createdNews.Tags)
+	})
+
+	t.Run("Too many tags", func(t *testing.T) {
+		tags := make([]string, 0, maxTags+1)
+		for i := 0; i <= maxTags; i++ {
+			tags = append(tags, fmt.Sprintf("tag%d", i))
+		}
+		news := &models.News{
+			Title:   "Title long text string greater then 20 characters",
+			Content: "Content long text string greater then 20 characters",
+			Tags:    tags,
+		}
+
+		createdNews, err := newsUC.Create(ctx, news)
+		require.Nil(t, createdNews)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.TooManyTags.Error())
+	})
+
+	t.Run("Tag too long", func(t *testing.T) {
+		news := &models.News{
+			Title:   "Title long text string greater then 20 characters",
+			Content: "Content long text string greater then 20 characters",
+			Tags:    []string{"golang", strings.Repeat("a", maxTagLength+1)},
+		}
+
+		createdNews, err := newsUC.Create(ctx, news)
+		require.Nil(t, createdNews)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.TagTooLong.Error())
+	})
+}
+
 func TestNewsUC_Update(t *testing.T) {
 	t.Parallel()
 
@@ -95,6 +161,15 @@ func TestNewsUC_Update(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, err)
 	require.NotNil(t, updatedNews)
+
+	categoryID := uuid.New()
+	conflicting := &models.News{NewsID: newsUID, CategoryID: &categoryID, ClearCategory: true}
+	mockNewsRepo.EXPECT().GetNewsByID(ctxWithTrace, gomock.Eq(news.NewsID)).Return(newsBase, nil)
+
+	updatedNews, err = newsUC.Update(ctx, conflicting)
+	require.Error(t, err)
+	require.Nil(t, updatedNews)
+	require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
 }
 
 func TestNewsUC_GetNewsByID(t *testing.T) {
@@ -163,6 +238,58 @@ func TestNewsUC_Delete(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestNewsUC_DeleteAny(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockNewsRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	newsUC := NewNewsUseCase(cfg, mockNewsRepo, mockRedisRepo, apiLogger)
+
+	newsUID := uuid.New()
+	newsBase := &models.NewsBase{
+		NewsID:   newsUID,
+		AuthorID: uuid.New(),
+	}
+
+	user := &models.User{
+		UserID: uuid.New(),
+	}
+
+	t.Run("Without permission", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), utils.UserCtxKey{}, user)
+
+		mockNewsRepo.EXPECT().GetNewsByID(gomock.Any(), gomock.Eq(newsUID)).Return(newsBase, nil)
+
+		err := newsUC.Delete(ctx, newsUID)
+		require.Error(t, err)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("With news:delete:any permission", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), utils.UserCtxKey{}, user)
+		ctx = context.WithValue(ctx, utils.PermissionsCtxKey{}, []string{models.PermissionNewsDeleteAny})
+
+		mockNewsRepo.EXPECT().GetNewsByID(gomock.Any(), gomock.Eq(newsUID)).Return(newsBase, nil)
+		mockNewsRepo.EXPECT().Delete(gomock.Any(), gomock.Eq(newsUID)).Return(nil)
+		mockRedisRepo.EXPECT().DeleteNewsCtx(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := newsUC.Delete(ctx, newsUID)
+		require.NoError(t, err)
+	})
+}
+
 func TestNewsUC_GetNews(t *testing.T) {
 	t.Parallel()
 
@@ -194,19 +321,20 @@ func TestNewsUC_GetNews(t *testing.T) {
 	require.NotNil(t, news)
 }
 
-func TestNewsUC_SearchByTitle(t *testing.T) {
+func TestNewsUC_Search(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	cfg := &config.Config{Search: config.Search{Language: "german"}}
 	apiLogger := logger.NewApiLogger(nil)
 	mockNewsRepo := mock.NewMockRepository(ctrl)
 	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
-	newsUC := NewNewsUseCase(nil, mockNewsRepo, mockRedisRepo, apiLogger)
+	newsUC := NewNewsUseCase(cfg, mockNewsRepo, mockRedisRepo, apiLogger)
 
 	ctx := context.Background()
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "newsUC.SearchByTitle")
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "newsUC.Search")
 	defer span.Finish()
 	query := &utils.PaginationQuery{
 		Size:    10,
@@ -214,13 +342,72 @@ func TestNewsUC_SearchByTitle(t *testing.T) {
 		OrderBy: "",
 	}
 
-	newsList := &models.NewsList{}
-	title := "title"
+	t.Run("Search", func(t *testing.T) {
+		searchList := &models.NewsSearchList{
+			News: []*models.NewsSearchHit{
+				{
+					TitleHighlight: "\x02Go\x03 <b>generics</b>",
+					Snippet:        "if a < b && \x02go\x03 build <script>alert(1)</script> \x02Go\x03's",
+				},
+			},
+		}
+
+		mockNewsRepo.EXPECT().Search(ctxWithTrace, "german", "(machine <-> learning) & go:*", query).Return(searchList, nil)
+
+		news, err := newsUC.Search(ctx, `"machine learning" go*`, query)
+		require.NoError(t, err)
+		require.NotNil(t, news)
+		require.Equal(t, "<mark>Go</mark> &lt;b&gt;generics&lt;/b&gt;", news.News[0].TitleHighlight)
+		require.Equal(t, "if a &lt; b &amp;&amp; <mark>go</mark> build &lt;script&gt;alert(1)&lt;/script&gt; <mark>Go</mark>&#39;s", news.News[0].Snippet)
+	})
+
+	t.Run("Empty query", func(t *testing.T) {
+		news, err := newsUC.Search(ctx, " -- ", query)
+		require.Error(t, err)
+		require.Nil(t, news)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
 
-	mockNewsRepo.EXPECT().SearchByTitle(ctxWithTrace, title, query).Return(newsList, nil)
+func TestNewsUC_GetNewsByTags(t *testing.T) {
+	t.Parallel()
 
-	news, err := newsUC.SearchByTitle(ctx, title, query)
-	require.NoError(t, err)
-	require.Nil(t, err)
-	require.NotNil(t, news)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewApiLogger(nil)
+	mockNewsRepo := mock.NewMockRepository(ctrl)
+	newsUC := NewNewsUseCase(&config.Config{}, mockNewsRepo, nil, apiLogger)
+
+	ctx := context.Background()
+	query := &utils.PaginationQuery{Size: 10, Page: 1}
+
+	t.Run("Match any", func(t *testing.T) {
+		newsList := &models.NewsList{News: []*models.News{{NewsID: uuid.New()}}}
+		mockNewsRepo.EXPECT().GetNewsByTags(gomock.Any(), []string{"golang", "postgres"}, false, query).Return(newsList, nil)
+
+		result, err := newsUC.GetNewsByTags(ctx, []string{"GoLang", "Postgres", "golang"}, models.TagsMatchAny, query)
+		require.NoError(t, err)
+		require.Equal(t, newsList, result)
+	})
+
+	t.Run("Match all by default", func(t *testing.T) {
+		mockNewsRepo.EXPECT().GetNewsByTags(gomock.Any(), []string{"golang"}, true, query).Return(&models.NewsList{}, nil)
+
+		_, err := newsUC.GetNewsByTags(ctx, []string{"golang"}, "", query)
+		require.NoError(t, err)
+	})
+
+	t.Run("Unknown match", func(t *testing.T) {
+		result, err := newsUC.GetNewsByTags(ctx, []string{"golang"}, "some", query)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), httpErrors.InvalidTagsMatch.Error())
+	})
+
+	t.Run("No tags", func(t *testing.T) {
+		result, err := newsUC.GetNewsByTags(ctx, []string{" ", "#"}, models.TagsMatchAll, query)
+		require.Nil(t, result)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.TagsRequired.Error())
+	})
 }
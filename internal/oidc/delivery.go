@@ -0,0 +1,13 @@
+package oidc
+
+import "github.com/labstack/echo/v4"
+
+// OIDC HTTP Handlers interface
+type Handlers interface {
+	GetProviders() echo.HandlerFunc
+	Login() echo.HandlerFunc
+	Link() echo.HandlerFunc
+	Callback() echo.HandlerFunc
+	GetIdentities() echo.HandlerFunc
+	Unlink() echo.HandlerFunc
+}
@@ -0,0 +1,256 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/oidc"
+	"github.com/AleksK1NG/api-mc/internal/session"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Binds authorization state to browser that started the flow
+const stateCookieName = "oidc_state"
+
+// OIDC handlers
+type oidcHandlers struct {
+	cfg    *config.Config
+	oidcUC oidc.UseCase
+	sessUC session.UCSession
+	logger logger.Logger
+}
+
+// NewOIDCHandlers OIDC handlers constructor
+func NewOIDCHandlers(cfg *config.Config, oidcUC oidc.UseCase, sessUC session.UCSession, log logger.Logger) oidc.Handlers {
+	return &oidcHandlers{cfg: cfg, oidcUC: oidcUC, sessUC: sessUC, logger: log}
+}
+
+// GetProviders godoc
+// @Summary Get identity providers
+// @Description get configured external identity provider names
+// @Tags OIDC
+// @Accept json
+// @Produce json
+// @Success 200 {array} string
+// @Router /oidc/providers [get]
+func (h *oidcHandlers) GetProviders() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "oidcHandlers.GetProviders")
+		defer span.Finish()
+
+		return c.JSON(http.StatusOK, h.oidcUC.GetProviders(ctx))
+	}
+}
+
+// Login godoc
+// @Summary Login with identity provider
+// @Description redirect to identity provider authorization endpoint
+// @Tags OIDC
+// @Param provider path string true "provider name"
+// @Success 302
+// @Failure 404 {object} httpErrors.RestError
+// @Router /oidc/{provider}/login [get]
+func (h *oidcHandlers) Login() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "oidcHandlers.Login")
+		defer span.Finish()
+
+		authURL, state, err := h.oidcUC.AuthURL(ctx, c.Param("provider"), nil)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		c.SetCookie(h.createStateCookie(state))
+
+		return c.Redirect(http.StatusFound, authURL)
+	}
+}
+
+// Link godoc
+// @Summary Link identity provider
+// @Description start linking identity provider account to current user, returns authorization url, required auth session cookie
+// @Tags OIDC
+// @Accept json
+// @Produce json
+// @Param provider path string true "provider name"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} httpErrors.RestError
+// @Router /oidc/{provider}/link [post]
+func (h *oidcHandlers) Link() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "oidcHandlers.Link")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		authURL, state, err := h.oidcUC.AuthURL(ctx, c.Param("provider"), &user.UserID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		c.SetCookie(h.createStateCookie(state))
+
+		return c.JSON(http.StatusOK, map[string]string{"url": authURL})
+	}
+}
+
+// Callback godoc
+// @Summary Identity provider callback
+// @Description finish login or linking, sets session for login without two factor challenge
+// @Tags OIDC
+// @Produce json
+// @Param provider path string true "provider name"
+// @Param state query string true "authorization state"
+// @Param code query string true "authorization code"
+// @Success 200 {object} models.OIDCLoginResult
+// @Failure 400 {object} httpErrors.RestError
+// @Router /oidc/{provider}/callback [get]
+func (h *oidcHandlers) Callback() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "oidcHandlers.Callback")
+		defer span.Finish()
+
+		if providerErr := c.QueryParam("error"); providerErr != "" {
+			err := httpErrors.NewUnauthorizedError(providerErr)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		state := c.QueryParam("state")
+		cookie, err := c.Cookie(stateCookieName)
+		if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+			restErr := httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidOIDCState.Error(), nil)
+			utils.LogResponseError(c, h.logger, restErr)
+			return c.JSON(httpErrors.ErrorResponse(restErr))
+		}
+		c.SetCookie(h.deleteStateCookie())
+
+		result, err := h.oidcUC.Callback(ctx, c.Param("provider"), state, c.QueryParam("code"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if result.Linked || result.Challenge != "" {
+			return c.JSON(http.StatusOK, result)
+		}
+
+		sess, err := h.sessUC.CreateSession(ctx, &models.Session{
+			UserID:    result.User.UserID,
+			IP:        c.RealIP(),
+			UserAgent: c.Request().UserAgent(),
+		}, h.cfg.Session.Expire)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		c.SetCookie(utils.CreateSessionCookie(h.cfg, sess))
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetIdentities godoc
+// @Summary Get linked identities
+// @Description get identity provider accounts linked to current user, required auth session cookie
+// @Tags OIDC
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.UserIdentity
+// @Failure 500 {object} httpErrors.RestError
+// @Router /oidc/identities [get]
+func (h *oidcHandlers) GetIdentities() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "oidcHandlers.GetIdentities")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		identities, err := h.oidcUC.GetIdentities(ctx, user.UserID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, identities)
+	}
+}
+
+// Unlink godoc
+// @Summary Unlink identity
+// @Description unlink identity provider account from current user, required auth session cookie
+// @Tags OIDC
+// @Accept json
+// @Produce json
+// @Param identity_id path string true "identity_id"
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /oidc/identities/{identity_id} [delete]
+func (h *oidcHandlers) Unlink() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "oidcHandlers.Unlink")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		identityID, err := uuid.Parse(c.Param("identity_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.oidcUC.Unlink(ctx, user.UserID, identityID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func (h *oidcHandlers) createStateCookie(state string) *http.Cookie {
+	return &http.Cookie{
+		Name:     stateCookieName,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   h.cfg.OIDC.StateExpire,
+		Secure:   h.cfg.Cookie.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func (h *oidcHandlers) deleteStateCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     stateCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   h.cfg.Cookie.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
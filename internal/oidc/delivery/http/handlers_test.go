@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/oidc/mock"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+func TestOIDCHandlers_Callback(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockOIDCUC := mock.NewMockUseCase(ctrl)
+	oidcHandlers := NewOIDCHandlers(cfg, mockOIDCUC, nil, apiLogger)
+
+	t.Run("State cookie mismatch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/oidc/keycloak/callback?state=attacker&code=code", nil)
+		req.AddCookie(&http.Cookie{Name: stateCookieName, Value: "victim"})
+		res := httptest.NewRecorder()
+
+		e := echo.New()
+		c := e.NewContext(req, res)
+		c.SetParamNames("provider")
+		c.SetParamValues("keycloak")
+
+		err := oidcHandlers.Callback()(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+
+	t.Run("Linked identity", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/oidc/keycloak/callback?state=state&code=code", nil)
+		req.AddCookie(&http.Cookie{Name: stateCookieName, Value: "state"})
+		res := httptest.NewRecorder()
+
+		e := echo.New()
+		c := e.NewContext(req, res)
+		c.SetParamNames("provider")
+		c.SetParamValues("keycloak")
+
+		mockOIDCUC.EXPECT().Callback(gomock.Any(), "keycloak", "state", "code").Return(&models.OIDCLoginResult{
+			Identity: &models.UserIdentity{Provider: "keycloak"},
+			Linked:   true,
+		}, nil)
+
+		err := oidcHandlers.Callback()(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.Code)
+	})
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/middleware"
+	"github.com/AleksK1NG/api-mc/internal/oidc"
+)
+
+// Map oidc routes
+func MapOIDCRoutes(oidcGroup *echo.Group, h oidc.Handlers, mw *middleware.MiddlewareManager) {
+	oidcGroup.GET("/providers", h.GetProviders())
+	oidcGroup.GET("/:provider/login", h.Login())
+	oidcGroup.GET("/:provider/callback", h.Callback())
+	oidcGroup.POST("/:provider/link", h.Link(), mw.AuthSessionMiddleware, mw.CSRF, mw.DenyImpersonation)
+	oidcGroup.GET("/identities", h.GetIdentities(), mw.AuthSessionMiddleware)
+	oidcGroup.DELETE("/identities/:identity_id", h.Unlink(), mw.AuthSessionMiddleware, mw.CSRF, mw.DenyImpersonation)
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package oidc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// OIDC identities repository interface
+type Repository interface {
+	Create(ctx context.Context, identity *models.UserIdentity) (*models.UserIdentity, error)
+	Register(ctx context.Context, user *models.User, identity *models.UserIdentity) (*models.UserIdentity, error)
+	GetByProviderSubject(ctx context.Context, provider string, subject string) (*models.UserIdentity, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.UserIdentity, error)
+	Delete(ctx context.Context, userID uuid.UUID, identityID uuid.UUID) error
+}
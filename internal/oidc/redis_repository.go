@@ -0,0 +1,14 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository_mock.go -package mock
+package oidc
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// OIDC Redis repository interface
+type RedisRepository interface {
+	SetStateCtx(ctx context.Context, key string, seconds int, state *models.OIDCState) error
+	PopStateCtx(ctx context.Context, key string) (*models.OIDCState, error)
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/oidc"
+)
+
+// OIDC identities Repository
+type oidcRepo struct {
+	db *sqlx.DB
+}
+
+// OIDC identities Repository constructor
+func NewOIDCRepository(db *sqlx.DB) oidc.Repository {
+	return &oidcRepo{db: db}
+}
+
+// Link new identity to user
+func (r *oidcRepo) Create(ctx context.Context, identity *models.UserIdentity) (*models.UserIdentity, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcRepo.Create")
+	defer span.Finish()
+
+	i := &models.UserIdentity{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createIdentityQuery,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	).StructScan(i); err != nil {
+		return nil, errors.Wrap(err, "oidcRepo.Create.StructScan")
+	}
+
+	return i, nil
+}
+
+// Create user with linked identity in one transaction, so user is never left without identity
+func (r *oidcRepo) Register(ctx context.Context, user *models.User, identity *models.UserIdentity) (*models.UserIdentity, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcRepo.Register")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidcRepo.Register.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	var userID uuid.UUID
+	if err = tx.QueryRowxContext(ctx, createUserQuery, user.FirstName, user.LastName, user.Email, user.Password).Scan(&userID); err != nil {
+		return nil, errors.Wrap(err, "oidcRepo.Register.Scan")
+	}
+
+	i := &models.UserIdentity{}
+	if err = tx.QueryRowxContext(
+		ctx,
+		createIdentityQuery,
+		userID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	).StructScan(i); err != nil {
+		return nil, errors.Wrap(err, "oidcRepo.Register.StructScan")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "oidcRepo.Register.Commit")
+	}
+
+	return i, nil
+}
+
+// Find identity by provider subject
+func (r *oidcRepo) GetByProviderSubject(ctx context.Context, provider string, subject string) (*models.UserIdentity, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcRepo.GetByProviderSubject")
+	defer span.Finish()
+
+	i := &models.UserIdentity{}
+	if err := r.db.GetContext(ctx, i, getIdentityByProviderSubjectQuery, provider, subject); err != nil {
+		return nil, errors.Wrap(err, "oidcRepo.GetByProviderSubject.GetContext")
+	}
+
+	return i, nil
+}
+
+// Get all identities linked to user
+func (r *oidcRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.UserIdentity, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcRepo.GetByUserID")
+	defer span.Finish()
+
+	identities := make([]*models.UserIdentity, 0)
+	if err := r.db.SelectContext(ctx, &identities, getIdentitiesByUserIDQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "oidcRepo.GetByUserID.SelectContext")
+	}
+
+	return identities, nil
+}
+
+// Unlink identity from user
+func (r *oidcRepo) Delete(ctx context.Context, userID uuid.UUID, identityID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcRepo.Delete")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteIdentityQuery, identityID, userID)
+	if err != nil {
+		return errors.Wrap(err, "oidcRepo.Delete.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "oidcRepo.Delete.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "oidcRepo.Delete.rowsAffected")
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+func TestOIDCRepo_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	oidcRepo := NewOIDCRepository(sqlxDB)
+
+	t.Run("Create", func(t *testing.T) {
+		userID := uuid.New()
+		email := "alex@mail.ru"
+		identity := &models.UserIdentity{
+			UserID:   userID,
+			Provider: "keycloak",
+			Subject:  "subject",
+			Email:    &email,
+		}
+
+		rows := sqlmock.NewRows([]string{"identity_id", "user_id", "provider", "subject", "email", "created_at"}).AddRow(
+			uuid.New(), userID, identity.Provider, identity.Subject, email, time.Now())
+
+		mock.ExpectQuery(createIdentityQuery).
+			WithArgs(identity.UserID, identity.Provider, identity.Subject, identity.Email).
+			WillReturnRows(rows)
+
+		createdIdentity, err := oidcRepo.Create(context.Background(), identity)
+		require.NoError(t, err)
+		require.Equal(t, identity.Subject, createdIdentity.Subject)
+		require.Equal(t, userID, createdIdentity.UserID)
+	})
+}
+
+func TestOIDCRepo_Register(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	oidcRepo := NewOIDCRepository(sqlxDB)
+
+	user := &models.User{FirstName: "Alex", LastName: "Bryksin", Email: "alex@mail.ru", Password: "hash"}
+	identity := &models.UserIdentity{Provider: "keycloak", Subject: "subject", Email: &user.Email}
+
+	t.Run("Register", func(t *testing.T) {
+		userID := uuid.New()
+		rows := sqlmock.NewRows([]string{"identity_id", "user_id", "provider", "subject", "email", "created_at"}).AddRow(
+			uuid.New(), userID, identity.Provider, identity.Subject, user.Email, time.Now())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(createUserQuery).
+			WithArgs(user.FirstName, user.LastName, user.Email, user.Password).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userID))
+		mock.ExpectQuery(createIdentityQuery).
+			WithArgs(userID, identity.Provider, identity.Subject, identity.Email).
+			WillReturnRows(rows)
+		mock.ExpectCommit()
+
+		createdIdentity, err := oidcRepo.Register(context.Background(), user, identity)
+		require.NoError(t, err)
+		require.Equal(t, userID, createdIdentity.UserID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("User is not created without identity", func(t *testing.T) {
+		userID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(createUserQuery).
+			WithArgs(user.FirstName, user.LastName, user.Email, user.Password).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userID))
+		mock.ExpectQuery(createIdentityQuery).
+			WithArgs(userID, identity.Provider, identity.Subject, identity.Email).
+			WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		createdIdentity, err := oidcRepo.Register(context.Background(), user, identity)
+		require.Error(t, err)
+		require.Nil(t, createdIdentity)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOIDCRepo_GetByUserID(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	oidcRepo := NewOIDCRepository(sqlxDB)
+
+	t.Run("GetByUserID", func(t *testing.T) {
+		userID := uuid.New()
+
+		rows := sqlmock.NewRows([]string{"identity_id", "user_id", "provider", "subject", "email", "created_at"}).
+			AddRow(uuid.New(), userID, "keycloak", "first", nil, time.Now()).
+			AddRow(uuid.New(), userID, "github", "second", nil, time.Now())
+
+		mock.ExpectQuery(getIdentitiesByUserIDQuery).WithArgs(userID).WillReturnRows(rows)
+
+		identities, err := oidcRepo.GetByUserID(context.Background(), userID)
+		require.NoError(t, err)
+		require.Len(t, identities, 2)
+	})
+}
+
+func TestOIDCRepo_Delete(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	oidcRepo := NewOIDCRepository(sqlxDB)
+
+	t.Run("Delete", func(t *testing.T) {
+		userID := uuid.New()
+		identityID := uuid.New()
+
+		mock.ExpectExec(deleteIdentityQuery).WithArgs(identityID, userID).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := oidcRepo.Delete(context.Background(), userID, identityID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete not owned", func(t *testing.T) {
+		userID := uuid.New()
+		identityID := uuid.New()
+
+		mock.ExpectExec(deleteIdentityQuery).WithArgs(identityID, userID).WillReturnResult(sqlmock.NewResult(1, 0))
+
+		err := oidcRepo.Delete(context.Background(), userID, identityID)
+		require.Error(t, err)
+	})
+}
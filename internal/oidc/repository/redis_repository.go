@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/oidc"
+)
+
+// OIDC redis repository
+type oidcRedisRepo struct {
+	redisClient *redis.Client
+}
+
+// OIDC redis repository constructor
+func NewOIDCRedisRepo(redisClient *redis.Client) oidc.RedisRepository {
+	return &oidcRedisRepo{redisClient: redisClient}
+}
+
+// Store pending authorization request state
+func (r *oidcRedisRepo) SetStateCtx(ctx context.Context, key string, seconds int, state *models.OIDCState) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcRedisRepo.SetStateCtx")
+	defer span.Finish()
+
+	stateBytes, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrap(err, "oidcRedisRepo.SetStateCtx.json.Marshal")
+	}
+
+	if err = r.redisClient.Set(ctx, key, stateBytes, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "oidcRedisRepo.SetStateCtx.redisClient.Set")
+	}
+
+	return nil
+}
+
+// Get authorization request state and delete it, so it can be used only once
+func (r *oidcRedisRepo) PopStateCtx(ctx context.Context, key string) (*models.OIDCState, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcRedisRepo.PopStateCtx")
+	defer span.Finish()
+
+	pipe := r.redisClient.TxPipeline()
+	get := pipe.Get(ctx, key)
+	pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, errors.Wrap(err, "oidcRedisRepo.PopStateCtx.pipe.Exec")
+	}
+
+	state := &models.OIDCState{}
+	if err := json.Unmarshal([]byte(get.Val()), state); err != nil {
+		return nil, errors.Wrap(err, "oidcRedisRepo.PopStateCtx.json.Unmarshal")
+	}
+
+	return state, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/oidc"
+)
+
+func SetupRedis() oidc.RedisRepository {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	oidcRedisRepo := NewOIDCRedisRepo(client)
+	return oidcRedisRepo
+}
+
+func TestOIDCRedisRepo_StateCtx(t *testing.T) {
+	t.Parallel()
+
+	oidcRedisRepo := SetupRedis()
+
+	t.Run("PopStateCtx", func(t *testing.T) {
+		key := uuid.New().String()
+		userID := uuid.New()
+		state := &models.OIDCState{
+			Provider:     "keycloak",
+			Nonce:        "nonce",
+			CodeVerifier: "verifier",
+			LinkUserID:   &userID,
+		}
+
+		err := oidcRedisRepo.SetStateCtx(context.Background(), key, 10, state)
+		require.NoError(t, err)
+
+		storedState, err := oidcRedisRepo.PopStateCtx(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, state, storedState)
+
+		_, err = oidcRedisRepo.PopStateCtx(context.Background(), key)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,23 @@
+package repository
+
+const (
+	// Email of provider created user is verified by provider
+	createUserQuery = `INSERT INTO users (first_name, last_name, email, password, role, created_at, updated_at, login_date, email_verified_at) 
+						VALUES ($1, $2, $3, $4, 'user', now(), now(), now(), now()) 
+						RETURNING user_id`
+
+	createIdentityQuery = `INSERT INTO user_identities (user_id, provider, subject, email, created_at) 
+						VALUES ($1, $2, $3, $4, now()) 
+						RETURNING *`
+
+	getIdentityByProviderSubjectQuery = `SELECT identity_id, user_id, provider, subject, email, created_at 
+						FROM user_identities 
+						WHERE provider = $1 AND subject = $2`
+
+	getIdentitiesByUserIDQuery = `SELECT identity_id, user_id, provider, subject, email, created_at 
+						FROM user_identities 
+						WHERE user_id = $1 
+						ORDER BY created_at`
+
+	deleteIdentityQuery = `DELETE FROM user_identities WHERE identity_id = $1 AND user_id = $2`
+)
@@ -0,0 +1,19 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package oidc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// OIDC UseCase interface
+type UseCase interface {
+	GetProviders(ctx context.Context) []string
+	AuthURL(ctx context.Context, provider string, linkUserID *uuid.UUID) (authURL string, state string, err error)
+	Callback(ctx context.Context, provider string, state string, code string) (*models.OIDCLoginResult, error)
+	GetIdentities(ctx context.Context, userID uuid.UUID) ([]*models.UserIdentity, error)
+	Unlink(ctx context.Context, userID uuid.UUID, identityID uuid.UUID) error
+}
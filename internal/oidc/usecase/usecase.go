@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/auth"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/oidc"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/openid"
+	"github.com/AleksK1NG/api-mc/pkg/passwords"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+const (
+	statePrefix        = "api-oidc-state:"
+	defaultStateExpire = 60 * 10
+	stateBytes         = 32
+	nameMaxLength      = 30
+)
+
+// OIDC UseCase
+type oidcUC struct {
+	cfg       *config.Config
+	oidcRepo  oidc.Repository
+	redisRepo oidc.RedisRepository
+	authRepo  auth.Repository
+	authUC    auth.UseCase
+	providers map[string]*openid.Provider
+	hasher    *passwords.Hasher
+	logger    logger.Logger
+}
+
+// OIDC UseCase constructor
+func NewOIDCUseCase(
+	cfg *config.Config,
+	oidcRepo oidc.Repository,
+	redisRepo oidc.RedisRepository,
+	authRepo auth.Repository,
+	authUC auth.UseCase,
+	log logger.Logger,
+) oidc.UseCase {
+	providers := make(map[string]*openid.Provider, len(cfg.OIDC.Providers))
+	for _, p := range cfg.OIDC.Providers {
+		providers[p.Name] = openid.NewProvider(openid.Config{
+			Name:         p.Name,
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+			AuthURL:      p.AuthURL,
+			TokenURL:     p.TokenURL,
+			UserInfoURL:  p.UserInfoURL,
+			JWKSURL:      p.JWKSURL,
+		}, nil)
+	}
+
+	return &oidcUC{
+		cfg:       cfg,
+		oidcRepo:  oidcRepo,
+		redisRepo: redisRepo,
+		authRepo:  authRepo,
+		authUC:    authUC,
+		providers: providers,
+		hasher:    passwords.NewHasher(passwords.Params(cfg.PasswordHash)),
+		logger:    log,
+	}
+}
+
+// Get configured provider names
+func (u *oidcUC) GetProviders(ctx context.Context) []string {
+	names := make([]string, 0, len(u.providers))
+	for name := range u.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Start authorization code flow, linkUserID is set when logged in user links new identity
+func (u *oidcUC) AuthURL(ctx context.Context, provider string, linkUserID *uuid.UUID) (string, string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcUC.AuthURL")
+	defer span.Finish()
+
+	p, ok := u.providers[provider]
+	if !ok {
+		return "", "", httpErrors.NewRestError(http.StatusNotFound, httpErrors.UnknownProvider.Error(), nil)
+	}
+
+	state, err := utils.GenerateRandomToken(stateBytes)
+	if err != nil {
+		return "", "", httpErrors.NewInternalServerError(errors.Wrap(err, "oidcUC.AuthURL.GenerateRandomToken"))
+	}
+	nonce, err := utils.GenerateRandomToken(stateBytes)
+	if err != nil {
+		return "", "", httpErrors.NewInternalServerError(errors.Wrap(err, "oidcUC.AuthURL.GenerateRandomToken"))
+	}
+	verifier, err := openid.GenerateVerifier()
+	if err != nil {
+		return "", "", httpErrors.NewInternalServerError(errors.Wrap(err, "oidcUC.AuthURL.GenerateVerifier"))
+	}
+
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", "", httpErrors.NewInternalServerError(errors.Wrap(err, "oidcUC.AuthURL.AuthCodeURL"))
+	}
+
+	if err = u.redisRepo.SetStateCtx(ctx, u.generateStateKey(state), u.getStateExpire(), &models.OIDCState{
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		LinkUserID:   linkUserID,
+	}); err != nil {
+		return "", "", httpErrors.NewInternalServerError(errors.Wrap(err, "oidcUC.AuthURL.SetStateCtx"))
+	}
+
+	return authURL, state, nil
+}
+
+// Finish authorization code flow, logs in, registers or links identity
+func (u *oidcUC) Callback(ctx context.Context, provider string, state string, code string) (*models.OIDCLoginResult, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcUC.Callback")
+	defer span.Finish()
+
+	storedState, err := u.redisRepo.PopStateCtx(ctx, u.generateStateKey(state))
+	if err != nil {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidOIDCState.Error(), errors.Wrap(err, "oidcUC.Callback.PopStateCtx"))
+	}
+	if storedState.Provider != provider {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidOIDCState.Error(), nil)
+	}
+
+	p, ok := u.providers[provider]
+	if !ok {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.UnknownProvider.Error(), nil)
+	}
+
+	token, err := p.Exchange(ctx, code, storedState.CodeVerifier)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(err, "oidcUC.Callback.Exchange"))
+	}
+
+	identity, err := p.Identity(ctx, token, storedState.Nonce)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(err, "oidcUC.Callback.Identity"))
+	}
+
+	existing, err := u.oidcRepo.GetByProviderSubject(ctx, provider, identity.Subject)
+	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if storedState.LinkUserID != nil {
+		return u.link(ctx, *storedState.LinkUserID, provider, identity, existing)
+	}
+
+	if existing == nil {
+		existing, err = u.register(ctx, provider, identity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	userWithToken, err := u.authUC.LoginExternal(ctx, existing.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OIDCLoginResult{UserWithToken: userWithToken, Identity: existing}, nil
+}
+
+// Get identities linked to user
+func (u *oidcUC) GetIdentities(ctx context.Context, userID uuid.UUID) ([]*models.UserIdentity, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcUC.GetIdentities")
+	defer span.Finish()
+
+	return u.oidcRepo.GetByUserID(ctx, userID)
+}
+
+// Unlink identity from user
+func (u *oidcUC) Unlink(ctx context.Context, userID uuid.UUID, identityID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "oidcUC.Unlink")
+	defer span.Finish()
+
+	return u.oidcRepo.Delete(ctx, userID, identityID)
+}
+
+func (u *oidcUC) link(
+	ctx context.Context,
+	userID uuid.UUID,
+	provider string,
+	identity *openid.Identity,
+	existing *models.UserIdentity,
+) (*models.OIDCLoginResult, error) {
+	if existing != nil {
+		if existing.UserID != userID {
+			return nil, httpErrors.NewRestError(http.StatusConflict, httpErrors.IdentityLinked.Error(), nil)
+		}
+		return &models.OIDCLoginResult{Identity: existing, Linked: true}, nil
+	}
+
+	created, err := u.oidcRepo.Create(ctx, &models.UserIdentity{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  identity.Subject,
+		Email:    optionalString(identity.Email),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OIDCLoginResult{Identity: created, Linked: true}, nil
+}
+
+// Create new user for unknown identity, existing accounts must link the provider themselves
+func (u *oidcUC) register(ctx context.Context, provider string, identity *openid.Identity) (*models.UserIdentity, error) {
+	// Provider sign up has no way to pass invite code, invited users register with email and link the provider
+	if u.cfg.Auth.InviteOnly {
+		return nil, httpErrors.NewRestError(http.StatusForbidden, httpErrors.InviteRequired.Error(), nil)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(identity.Email))
+	if email == "" {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.ExternalEmailMissing.Error(), nil)
+	}
+	// Account is owned by email, provider which does not verify emails could let anyone claim address of other person
+	if !identity.EmailVerified {
+		return nil, httpErrors.NewRestError(http.StatusForbidden, httpErrors.IdPEmailUnverified.Error(), nil)
+	}
+
+	if _, err := u.authRepo.FindByEmail(ctx, &models.User{Email: email}); err == nil {
+		return nil, httpErrors.NewRestError(http.StatusConflict, httpErrors.ExternalEmailExists.Error(), nil)
+	} else if errors.Cause(err) != sql.ErrNoRows {
+		return nil, err
+	}
+
+	// Users created by provider have unknown random password, they can set one by password reset
+	password, err := utils.GenerateRandomToken(stateBytes)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "oidcUC.register.GenerateRandomToken"))
+	}
+
+	firstName, lastName := splitName(identity, email, provider)
+	user := &models.User{
+		FirstName: firstName,
+		LastName:  lastName,
+		Email:     email,
+		Password:  password,
+	}
+	if err = user.PrepareCreate(u.hasher); err != nil {
+		return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "oidcUC.register.PrepareCreate"))
+	}
+
+	return u.oidcRepo.Register(ctx, user, &models.UserIdentity{
+		Provider: provider,
+		Subject:  identity.Subject,
+		Email:    optionalString(email),
+	})
+}
+
+func (u *oidcUC) getStateExpire() int {
+	if u.cfg.OIDC.StateExpire <= 0 {
+		return defaultStateExpire
+	}
+	return u.cfg.OIDC.StateExpire
+}
+
+func (u *oidcUC) generateStateKey(state string) string {
+	return fmt.Sprintf("%s: %s", statePrefix, utils.HashToken(state))
+}
+
+func splitName(identity *openid.Identity, email string, provider string) (string, string) {
+	firstName, lastName := identity.GivenName, identity.FamilyName
+	if firstName == "" && identity.Name != "" {
+		parts := strings.SplitN(strings.TrimSpace(identity.Name), " ", 2)
+		firstName = parts[0]
+		if len(parts) == 2 && lastName == "" {
+			lastName = parts[1]
+		}
+	}
+	if firstName == "" {
+		firstName = strings.SplitN(email, "@", 2)[0]
+	}
+	if lastName == "" {
+		lastName = provider
+	}
+	return truncate(firstName, nameMaxLength), truncate(lastName, nameMaxLength)
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(strings.TrimSpace(s))
+	if len(runes) > max {
+		return string(runes[:max])
+	}
+	return string(runes)
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	authMock "github.com/AleksK1NG/api-mc/internal/auth/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/oidc/mock"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/openid"
+)
+
+const (
+	testProvider = "mock"
+	testClientID = "api-mc"
+	testSubject  = "mock-subject"
+	testEmail    = "alex@mail.ru"
+)
+
+// Local mock issuer with discovery, jwks and token endpoints
+type mockIssuer struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	nonce         string
+	codeChallenge string
+	unverified    bool
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := &mockIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"userinfo_endpoint":      issuer.server.URL + "/userinfo",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("code") != "valid-code" ||
+			openid.CodeChallenge(r.PostFormValue("code_verifier")) != issuer.codeChallenge {
+			http.Error(w, "invalid_grant", http.StatusBadRequest)
+			return
+		}
+
+		idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":            issuer.server.URL,
+			"aud":            testClientID,
+			"sub":            testSubject,
+			"nonce":          issuer.nonce,
+			"email":          testEmail,
+			"email_verified": !issuer.unverified,
+			"given_name":     "Alex",
+			"family_name":    "Bryksin",
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(time.Minute).Unix(),
+		})
+		idToken.Header["kid"] = "test"
+		signed, err := idToken.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"id_token":     signed,
+		})
+	})
+	issuer.server = httptest.NewServer(mux)
+
+	return issuer
+}
+
+// Remember values from authorization request like real provider would
+func (i *mockIssuer) authorize(t *testing.T, authURL string) {
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, i.server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	require.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+
+	i.nonce = parsed.Query().Get("nonce")
+	i.codeChallenge = parsed.Query().Get("code_challenge")
+}
+
+func TestOIDCUC_Callback(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	cfg := &config.Config{
+		OIDC: config.OIDC{
+			Providers: []config.OIDCProvider{{
+				Name:        testProvider,
+				Issuer:      issuer.server.URL,
+				ClientID:    testClientID,
+				RedirectURL: "http://localhost/api/v1/oidc/mock/callback",
+			}},
+		},
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockOIDCRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAuthRepo := authMock.NewMockRepository(ctrl)
+	mockAuthUC := authMock.NewMockUseCase(ctrl)
+	oidcUseCase := NewOIDCUseCase(cfg, mockOIDCRepo, mockRedisRepo, mockAuthRepo, mockAuthUC, apiLogger)
+
+	ctx := context.Background()
+
+	startFlow := func(t *testing.T, linkUserID *uuid.UUID) (string, *models.OIDCState) {
+		var storedState *models.OIDCState
+		mockRedisRepo.EXPECT().SetStateCtx(gomock.Any(), gomock.Any(), defaultStateExpire, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, key string, seconds int, state *models.OIDCState) error {
+				storedState = state
+				return nil
+			},
+		)
+
+		authURL, state, err := oidcUseCase.AuthURL(ctx, testProvider, linkUserID)
+		require.NoError(t, err)
+		require.NotEmpty(t, state)
+		issuer.authorize(t, authURL)
+
+		mockRedisRepo.EXPECT().PopStateCtx(gomock.Any(), oidcUseCase.(*oidcUC).generateStateKey(state)).Return(storedState, nil)
+
+		return state, storedState
+	}
+
+	t.Run("Register new user", func(t *testing.T) {
+		state, _ := startFlow(t, nil)
+		userID := uuid.New()
+		identity := &models.UserIdentity{IdentityID: uuid.New(), UserID: userID, Provider: testProvider, Subject: testSubject}
+
+		mockOIDCRepo.EXPECT().GetByProviderSubject(gomock.Any(), testProvider, testSubject).Return(nil, sql.ErrNoRows)
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+		mockOIDCRepo.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, user *models.User, i *models.UserIdentity) (*models.UserIdentity, error) {
+				require.Equal(t, "Alex", user.FirstName)
+				require.Equal(t, "Bryksin", user.LastName)
+				require.Equal(t, testEmail, user.Email)
+				require.Equal(t, testSubject, i.Subject)
+				return identity, nil
+			},
+		)
+		mockAuthUC.EXPECT().LoginExternal(gomock.Any(), userID).Return(&models.UserWithToken{
+			User:  &models.User{UserID: userID},
+			Token: "token",
+		}, nil)
+
+		result, err := oidcUseCase.Callback(ctx, testProvider, state, "valid-code")
+		require.NoError(t, err)
+		require.False(t, result.Linked)
+		require.Equal(t, "token", result.Token)
+		require.Equal(t, identity, result.Identity)
+	})
+
+	t.Run("Unverified email", func(t *testing.T) {
+		issuer.unverified = true
+		defer func() { issuer.unverified = false }()
+		state, _ := startFlow(t, nil)
+
+		mockOIDCRepo.EXPECT().GetByProviderSubject(gomock.Any(), testProvider, testSubject).Return(nil, sql.ErrNoRows)
+
+		result, err := oidcUseCase.Callback(ctx, testProvider, state, "valid-code")
+		require.Nil(t, result)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Existing email must link", func(t *testing.T) {
+		state, _ := startFlow(t, nil)
+
+		mockOIDCRepo.EXPECT().GetByProviderSubject(gomock.Any(), testProvider, testSubject).Return(nil, sql.ErrNoRows)
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).Return(&models.User{Email: testEmail}, nil)
+
+		result, err := oidcUseCase.Callback(ctx, testProvider, state, "valid-code")
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+
+	t.Run("Link identity", func(t *testing.T) {
+		userID := uuid.New()
+		state, storedState := startFlow(t, &userID)
+		require.Equal(t, userID, *storedState.LinkUserID)
+
+		mockOIDCRepo.EXPECT().GetByProviderSubject(gomock.Any(), testProvider, testSubject).Return(nil, sql.ErrNoRows)
+		mockOIDCRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, identity *models.UserIdentity) (*models.UserIdentity, error) {
+				require.Equal(t, userID, identity.UserID)
+				require.Equal(t, testSubject, identity.Subject)
+				return identity, nil
+			},
+		)
+
+		result, err := oidcUseCase.Callback(ctx, testProvider, state, "valid-code")
+		require.NoError(t, err)
+		require.True(t, result.Linked)
+		require.Nil(t, result.UserWithToken)
+	})
+
+	t.Run("Identity linked to another user", func(t *testing.T) {
+		userID := uuid.New()
+		state, _ := startFlow(t, &userID)
+
+		mockOIDCRepo.EXPECT().GetByProviderSubject(gomock.Any(), testProvider, testSubject).Return(&models.UserIdentity{
+			UserID:   uuid.New(),
+			Provider: testProvider,
+			Subject:  testSubject,
+		}, nil)
+
+		result, err := oidcUseCase.Callback(ctx, testProvider, state, "valid-code")
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		state, _ := startFlow(t, nil)
+
+		result, err := oidcUseCase.Callback(ctx, testProvider, state, "invalid-code")
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+
+	t.Run("Unknown provider", func(t *testing.T) {
+		_, _, err := oidcUseCase.AuthURL(ctx, "unknown", nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,16 @@
+package rbac
+
+import "github.com/labstack/echo/v4"
+
+// RBAC HTTP Handlers interface
+type Handlers interface {
+	GetRoles() echo.HandlerFunc
+	CreateRole() echo.HandlerFunc
+	DeleteRole() echo.HandlerFunc
+	GetPermissions() echo.HandlerFunc
+	CreatePermission() echo.HandlerFunc
+	DeletePermission() echo.HandlerFunc
+	GrantPermission() echo.HandlerFunc
+	RevokePermission() echo.HandlerFunc
+	AssignRole() echo.HandlerFunc
+}
@@ -0,0 +1,275 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/rbac"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// RBAC handlers
+type rbacHandlers struct {
+	cfg    *config.Config
+	rbacUC rbac.UseCase
+	logger logger.Logger
+}
+
+// NewRBACHandlers RBAC handlers constructor
+func NewRBACHandlers(cfg *config.Config, rbacUC rbac.UseCase, log logger.Logger) rbac.Handlers {
+	return &rbacHandlers{cfg: cfg, rbacUC: rbacUC, logger: log}
+}
+
+// GetRoles godoc
+// @Summary Get roles
+// @Description get all roles with granted permissions, requires roles:manage permission
+// @Tags RBAC
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Role
+// @Failure 403 {object} httpErrors.RestError
+// @Router /rbac/roles [get]
+func (h *rbacHandlers) GetRoles() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "rbacHandlers.GetRoles")
+		defer span.Finish()
+
+		roles, err := h.rbacUC.GetRoles(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, roles)
+	}
+}
+
+// CreateRole godoc
+// @Summary Create role
+// @Description create role without permissions, requires roles:manage permission
+// @Tags RBAC
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Role
+// @Failure 400 {object} httpErrors.RestError
+// @Router /rbac/roles [post]
+func (h *rbacHandlers) CreateRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "rbacHandlers.CreateRole")
+		defer span.Finish()
+
+		role := &models.Role{}
+		if err := utils.ReadRequest(c, role); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		createdRole, err := h.rbacUC.CreateRole(ctx, role)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdRole)
+	}
+}
+
+// DeleteRole godoc
+// @Summary Delete role
+// @Description delete role not assigned to any user, requires roles:manage permission
+// @Tags RBAC
+// @Accept json
+// @Produce json
+// @Param role_name path string true "role_name"
+// @Success 200 {string} string	"ok"
+// @Failure 400 {object} httpErrors.RestError
+// @Router /rbac/roles/{role_name} [delete]
+func (h *rbacHandlers) DeleteRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "rbacHandlers.DeleteRole")
+		defer span.Finish()
+
+		if err := h.rbacUC.DeleteRole(ctx, c.Param("role_name")); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// GetPermissions godoc
+// @Summary Get permissions
+// @Description get all permissions, requires roles:manage permission
+// @Tags RBAC
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Permission
+// @Failure 403 {object} httpErrors.RestError
+// @Router /rbac/permissions [get]
+func (h *rbacHandlers) GetPermissions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "rbacHandlers.GetPermissions")
+		defer span.Finish()
+
+		permissions, err := h.rbacUC.GetPermissions(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, permissions)
+	}
+}
+
+// CreatePermission godoc
+// @Summary Create permission
+// @Description create permission, requires roles:manage permission
+// @Tags RBAC
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Permission
+// @Failure 400 {object} httpErrors.RestError
+// @Router /rbac/permissions [post]
+func (h *rbacHandlers) CreatePermission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "rbacHandlers.CreatePermission")
+		defer span.Finish()
+
+		permission := &models.Permission{}
+		if err := utils.ReadRequest(c, permission); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		createdPermission, err := h.rbacUC.CreatePermission(ctx, permission)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdPermission)
+	}
+}
+
+// DeletePermission godoc
+// @Summary Delete permission
+// @Description delete permission and revoke it from all roles, requires roles:manage permission
+// @Tags RBAC
+// @Accept json
+// @Produce json
+// @Param permission_name path string true "permission_name"
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /rbac/permissions/{permission_name} [delete]
+func (h *rbacHandlers) DeletePermission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "rbacHandlers.DeletePermission")
+		defer span.Finish()
+
+		if err := h.rbacUC.DeletePermission(ctx, c.Param("permission_name")); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// GrantPermission godoc
+// @Summary Grant permission
+// @Description grant permission to role, requires roles:manage permission
+// @Tags RBAC
+// @Accept json
+// @Produce json
+// @Param role_name path string true "role_name"
+// @Param permission_name path string true "permission_name"
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /rbac/roles/{role_name}/permissions/{permission_name} [put]
+func (h *rbacHandlers) GrantPermission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "rbacHandlers.GrantPermission")
+		defer span.Finish()
+
+		if err := h.rbacUC.GrantPermission(ctx, c.Param("role_name"), c.Param("permission_name")); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// RevokePermission godoc
+// @Summary Revoke permission
+// @Description revoke permission from role, requires roles:manage permission
+// @Tags RBAC
+// @Accept json
+// @Produce json
+// @Param role_name path string true "role_name"
+// @Param permission_name path string true "permission_name"
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /rbac/roles/{role_name}/permissions/{permission_name} [delete]
+func (h *rbacHandlers) RevokePermission() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "rbacHandlers.RevokePermission")
+		defer span.Finish()
+
+		if err := h.rbacUC.RevokePermission(ctx, c.Param("role_name"), c.Param("permission_name")); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// AssignRole godoc
+// @Summary Assign role
+// @Description assign role to user, requires roles:manage permission
+// @Tags RBAC
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Success 200 {object} models.User
+// @Failure 400 {object} httpErrors.RestError
+// @Router /rbac/users/{user_id}/role [put]
+func (h *rbacHandlers) AssignRole() echo.HandlerFunc {
+	type AssignRole struct {
+		Role string `json:"role" validate:"required,lte=32"`
+	}
+
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "rbacHandlers.AssignRole")
+		defer span.Finish()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		request := &AssignRole{}
+		if err = utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		user, err := h.rbacUC.AssignRole(ctx, userID, request.Role)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, user)
+	}
+}
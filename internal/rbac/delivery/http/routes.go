@@ -0,0 +1,23 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/middleware"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/rbac"
+)
+
+// Map RBAC admin routes
+func MapRBACRoutes(rbacGroup *echo.Group, h rbac.Handlers, mw *middleware.MiddlewareManager) {
+	rbacGroup.Use(mw.AuthSessionMiddleware, mw.DenyImpersonation, mw.RequirePermission(models.PermissionRolesManage))
+	rbacGroup.GET("/roles", h.GetRoles())
+	rbacGroup.POST("/roles", h.CreateRole(), mw.CSRF)
+	rbacGroup.DELETE("/roles/:role_name", h.DeleteRole(), mw.CSRF)
+	rbacGroup.PUT("/roles/:role_name/permissions/:permission_name", h.GrantPermission(), mw.CSRF)
+	rbacGroup.DELETE("/roles/:role_name/permissions/:permission_name", h.RevokePermission(), mw.CSRF)
+	rbacGroup.GET("/permissions", h.GetPermissions())
+	rbacGroup.POST("/permissions", h.CreatePermission(), mw.CSRF)
+	rbacGroup.DELETE("/permissions/:permission_name", h.DeletePermission(), mw.CSRF)
+	rbacGroup.PUT("/users/:user_id/role", h.AssignRole(), mw.CSRF)
+}
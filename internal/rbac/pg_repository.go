@@ -0,0 +1,23 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package rbac
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// RBAC Repository
+type Repository interface {
+	GetRoles(ctx context.Context) ([]*models.Role, error)
+	GetRoleByName(ctx context.Context, name string) (*models.Role, error)
+	CreateRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	DeleteRole(ctx context.Context, name string) error
+	GetPermissions(ctx context.Context) ([]*models.Permission, error)
+	GetPermissionByName(ctx context.Context, name string) (*models.Permission, error)
+	CreatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error)
+	DeletePermission(ctx context.Context, name string) error
+	GetRolePermissions(ctx context.Context, roleName string) ([]string, error)
+	GrantPermission(ctx context.Context, roleName string, permissionName string) error
+	RevokePermission(ctx context.Context, roleName string, permissionName string) error
+}
@@ -0,0 +1,13 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository_mock.go -package mock
+package rbac
+
+import (
+	"context"
+)
+
+// RBAC redis repository, caches permissions of roles
+type RedisRepository interface {
+	GetPermissionsCtx(ctx context.Context, key string) ([]string, error)
+	SetPermissionsCtx(ctx context.Context, key string, seconds int, permissions []string) error
+	DeletePermissionsCtx(ctx context.Context, keys ...string) error
+}
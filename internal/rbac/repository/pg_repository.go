@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/rbac"
+)
+
+// RBAC Repository
+type rbacRepo struct {
+	db *sqlx.DB
+}
+
+// RBAC Repository constructor
+func NewRBACRepository(db *sqlx.DB) rbac.Repository {
+	return &rbacRepo{db: db}
+}
+
+// Get all roles
+func (r *rbacRepo) GetRoles(ctx context.Context) ([]*models.Role, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.GetRoles")
+	defer span.Finish()
+
+	roles := make([]*models.Role, 0)
+	if err := r.db.SelectContext(ctx, &roles, getRolesQuery); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.GetRoles.SelectContext")
+	}
+
+	return roles, nil
+}
+
+// Find role by name
+func (r *rbacRepo) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.GetRoleByName")
+	defer span.Finish()
+
+	role := &models.Role{}
+	if err := r.db.GetContext(ctx, role, getRoleByNameQuery, name); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.GetRoleByName.GetContext")
+	}
+
+	return role, nil
+}
+
+// Create role
+func (r *rbacRepo) CreateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.CreateRole")
+	defer span.Finish()
+
+	created := &models.Role{}
+	if err := r.db.QueryRowxContext(ctx, createRoleQuery, role.Name, role.Description).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.CreateRole.StructScan")
+	}
+
+	return created, nil
+}
+
+// Delete role, fails while role is assigned to users
+func (r *rbacRepo) DeleteRole(ctx context.Context, name string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.DeleteRole")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteRoleQuery, name)
+	if err != nil {
+		return errors.Wrap(err, "rbacRepo.DeleteRole.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "rbacRepo.DeleteRole.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "rbacRepo.DeleteRole.rowsAffected")
+	}
+
+	return nil
+}
+
+// Get all permissions
+func (r *rbacRepo) GetPermissions(ctx context.Context) ([]*models.Permission, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.GetPermissions")
+	defer span.Finish()
+
+	permissions := make([]*models.Permission, 0)
+	if err := r.db.SelectContext(ctx, &permissions, getPermissionsQuery); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.GetPermissions.SelectContext")
+	}
+
+	return permissions, nil
+}
+
+// Find permission by name
+func (r *rbacRepo) GetPermissionByName(ctx context.Context, name string) (*models.Permission, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.GetPermissionByName")
+	defer span.Finish()
+
+	permission := &models.Permission{}
+	if err := r.db.GetContext(ctx, permission, getPermissionByNameQuery, name); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.GetPermissionByName.GetContext")
+	}
+
+	return permission, nil
+}
+
+// Create permission
+func (r *rbacRepo) CreatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.CreatePermission")
+	defer span.Finish()
+
+	created := &models.Permission{}
+	if err := r.db.QueryRowxContext(ctx, createPermissionQuery, permission.Name, permission.Description).StructScan(created); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.CreatePermission.StructScan")
+	}
+
+	return created, nil
+}
+
+// Delete permission, grants are removed by cascade
+func (r *rbacRepo) DeletePermission(ctx context.Context, name string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.DeletePermission")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deletePermissionQuery, name)
+	if err != nil {
+		return errors.Wrap(err, "rbacRepo.DeletePermission.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "rbacRepo.DeletePermission.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "rbacRepo.DeletePermission.rowsAffected")
+	}
+
+	return nil
+}
+
+// Get permission names granted to role
+func (r *rbacRepo) GetRolePermissions(ctx context.Context, roleName string) ([]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.GetRolePermissions")
+	defer span.Finish()
+
+	permissions := make([]string, 0)
+	if err := r.db.SelectContext(ctx, &permissions, getRolePermissionsQuery, roleName); err != nil {
+		return nil, errors.Wrap(err, "rbacRepo.GetRolePermissions.SelectContext")
+	}
+
+	return permissions, nil
+}
+
+// Grant permission to role, granting already granted permission is not an error
+func (r *rbacRepo) GrantPermission(ctx context.Context, roleName string, permissionName string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.GrantPermission")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, grantPermissionQuery, roleName, permissionName)
+	if err != nil {
+		return errors.Wrap(err, "rbacRepo.GrantPermission.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "rbacRepo.GrantPermission.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "rbacRepo.GrantPermission.rowsAffected")
+	}
+
+	return nil
+}
+
+// Revoke permission from role
+func (r *rbacRepo) RevokePermission(ctx context.Context, roleName string, permissionName string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRepo.RevokePermission")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, revokePermissionQuery, roleName, permissionName)
+	if err != nil {
+		return errors.Wrap(err, "rbacRepo.RevokePermission.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "rbacRepo.RevokePermission.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "rbacRepo.RevokePermission.rowsAffected")
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+func TestRBACRepo_CreateRole(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	rbacRepo := NewRBACRepository(sqlxDB)
+
+	t.Run("CreateRole", func(t *testing.T) {
+		description := "Moderates content"
+		role := &models.Role{Name: "moderator", Description: &description}
+
+		rows := sqlmock.NewRows([]string{"role_id", "name", "description", "created_at"}).AddRow(
+			uuid.New(), role.Name, description, time.Now())
+
+		mock.ExpectQuery(createRoleQuery).WithArgs(role.Name, role.Description).WillReturnRows(rows)
+
+		createdRole, err := rbacRepo.CreateRole(context.Background(), role)
+		require.NoError(t, err)
+		require.Equal(t, role.Name, createdRole.Name)
+		require.Equal(t, description, *createdRole.Description)
+	})
+}
+
+func TestRBACRepo_GetRolePermissions(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	rbacRepo := NewRBACRepository(sqlxDB)
+
+	t.Run("GetRolePermissions", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"name"}).
+			AddRow(models.PermissionCommentsDeleteAny).
+			AddRow(models.PermissionNewsDeleteAny)
+
+		mock.ExpectQuery(getRolePermissionsQuery).WithArgs("moderator").WillReturnRows(rows)
+
+		permissions, err := rbacRepo.GetRolePermissions(context.Background(), "moderator")
+		require.NoError(t, err)
+		require.Equal(t, []string{models.PermissionCommentsDeleteAny, models.PermissionNewsDeleteAny}, permissions)
+	})
+}
+
+func TestRBACRepo_GrantPermission(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	rbacRepo := NewRBACRepository(sqlxDB)
+
+	t.Run("GrantPermission", func(t *testing.T) {
+		mock.ExpectExec(grantPermissionQuery).
+			WithArgs("moderator", models.PermissionNewsUpdateAny).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := rbacRepo.GrantPermission(context.Background(), "moderator", models.PermissionNewsUpdateAny)
+		require.NoError(t, err)
+	})
+
+	t.Run("Unknown role or permission", func(t *testing.T) {
+		mock.ExpectExec(grantPermissionQuery).
+			WithArgs("moderator", "unknown").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := rbacRepo.GrantPermission(context.Background(), "moderator", "unknown")
+		require.Error(t, err)
+		require.Equal(t, sql.ErrNoRows, errors.Cause(err))
+	})
+}
+
+func TestRBACRepo_RevokePermission(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	rbacRepo := NewRBACRepository(sqlxDB)
+
+	t.Run("Not granted", func(t *testing.T) {
+		mock.ExpectExec(revokePermissionQuery).
+			WithArgs("moderator", models.PermissionUsersDelete).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := rbacRepo.RevokePermission(context.Background(), "moderator", models.PermissionUsersDelete)
+		require.Error(t, err)
+		require.Equal(t, sql.ErrNoRows, errors.Cause(err))
+	})
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/rbac"
+)
+
+// RBAC redis repository
+type rbacRedisRepo struct {
+	redisClient *redis.Client
+}
+
+// RBAC redis repository constructor
+func NewRBACRedisRepo(redisClient *redis.Client) rbac.RedisRepository {
+	return &rbacRedisRepo{redisClient: redisClient}
+}
+
+// Get cached permissions of role
+func (r *rbacRedisRepo) GetPermissionsCtx(ctx context.Context, key string) ([]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRedisRepo.GetPermissionsCtx")
+	defer span.Finish()
+
+	permissionsBytes, err := r.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "rbacRedisRepo.GetPermissionsCtx.redisClient.Get")
+	}
+	permissions := make([]string, 0)
+	if err = json.Unmarshal(permissionsBytes, &permissions); err != nil {
+		return nil, errors.Wrap(err, "rbacRedisRepo.GetPermissionsCtx.json.Unmarshal")
+	}
+
+	return permissions, nil
+}
+
+// Cache permissions of role with duration in seconds
+func (r *rbacRedisRepo) SetPermissionsCtx(ctx context.Context, key string, seconds int, permissions []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRedisRepo.SetPermissionsCtx")
+	defer span.Finish()
+
+	permissionsBytes, err := json.Marshal(permissions)
+	if err != nil {
+		return errors.Wrap(err, "rbacRedisRepo.SetPermissionsCtx.json.Marshal")
+	}
+	if err = r.redisClient.Set(ctx, key, permissionsBytes, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "rbacRedisRepo.SetPermissionsCtx.redisClient.Set")
+	}
+
+	return nil
+}
+
+// Delete cached permissions of roles
+func (r *rbacRedisRepo) DeletePermissionsCtx(ctx context.Context, keys ...string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacRedisRepo.DeletePermissionsCtx")
+	defer span.Finish()
+
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := r.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return errors.Wrap(err, "rbacRedisRepo.DeletePermissionsCtx.redisClient.Del")
+	}
+
+	return nil
+}
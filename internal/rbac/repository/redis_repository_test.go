@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/rbac"
+)
+
+func SetupRedis() rbac.RedisRepository {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	rbacRedisRepo := NewRBACRedisRepo(client)
+	return rbacRedisRepo
+}
+
+func TestRBACRedisRepo_PermissionsCtx(t *testing.T) {
+	t.Parallel()
+
+	rbacRedisRepo := SetupRedis()
+
+	t.Run("Set, get and delete", func(t *testing.T) {
+		key := "key"
+		permissions := []string{models.PermissionNewsDeleteAny}
+
+		err := rbacRedisRepo.SetPermissionsCtx(context.Background(), key, 10, permissions)
+		require.NoError(t, err)
+
+		cached, err := rbacRedisRepo.GetPermissionsCtx(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, permissions, cached)
+
+		err = rbacRedisRepo.DeletePermissionsCtx(context.Background(), key)
+		require.NoError(t, err)
+
+		_, err = rbacRedisRepo.GetPermissionsCtx(context.Background(), key)
+		require.Error(t, err)
+	})
+
+	t.Run("Role without permissions", func(t *testing.T) {
+		key := "empty"
+
+		err := rbacRedisRepo.SetPermissionsCtx(context.Background(), key, 10, []string{})
+		require.NoError(t, err)
+
+		cached, err := rbacRedisRepo.GetPermissionsCtx(context.Background(), key)
+		require.NoError(t, err)
+		require.NotNil(t, cached)
+		require.Empty(t, cached)
+	})
+}
@@ -0,0 +1,36 @@
+package repository
+
+const (
+	getRolesQuery = `SELECT role_id, name, description, created_at FROM roles ORDER BY name`
+
+	getRoleByNameQuery = `SELECT role_id, name, description, created_at FROM roles WHERE name = $1`
+
+	createRoleQuery = `INSERT INTO roles (name, description, created_at) VALUES ($1, $2, now()) 
+						RETURNING role_id, name, description, created_at`
+
+	deleteRoleQuery = `DELETE FROM roles WHERE name = $1`
+
+	getPermissionsQuery = `SELECT permission_id, name, description, created_at FROM permissions ORDER BY name`
+
+	getPermissionByNameQuery = `SELECT permission_id, name, description, created_at FROM permissions WHERE name = $1`
+
+	createPermissionQuery = `INSERT INTO permissions (name, description, created_at) VALUES ($1, $2, now()) 
+						RETURNING permission_id, name, description, created_at`
+
+	deletePermissionQuery = `DELETE FROM permissions WHERE name = $1`
+
+	getRolePermissionsQuery = `SELECT p.name FROM permissions p 
+						JOIN role_permissions rp ON rp.permission_id = p.permission_id 
+						JOIN roles r ON r.role_id = rp.role_id 
+						WHERE r.name = $1 
+						ORDER BY p.name`
+
+	grantPermissionQuery = `INSERT INTO role_permissions (role_id, permission_id) 
+						SELECT r.role_id, p.permission_id FROM roles r, permissions p 
+						WHERE r.name = $1 AND p.name = $2 
+						ON CONFLICT (role_id, permission_id) DO UPDATE SET permission_id = EXCLUDED.permission_id`
+
+	revokePermissionQuery = `DELETE FROM role_permissions rp USING roles r, permissions p 
+						WHERE rp.role_id = r.role_id AND rp.permission_id = p.permission_id 
+						AND r.name = $1 AND p.name = $2`
+)
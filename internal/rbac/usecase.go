@@ -0,0 +1,24 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package rbac
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// RBAC UseCase
+type UseCase interface {
+	GetRoles(ctx context.Context) ([]*models.Role, error)
+	CreateRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	DeleteRole(ctx context.Context, name string) error
+	GetPermissions(ctx context.Context) ([]*models.Permission, error)
+	CreatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error)
+	DeletePermission(ctx context.Context, name string) error
+	GrantPermission(ctx context.Context, roleName string, permissionName string) error
+	RevokePermission(ctx context.Context, roleName string, permissionName string) error
+	GetRolePermissions(ctx context.Context, roleName string) ([]string, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, roleName string) (*models.User, error)
+}
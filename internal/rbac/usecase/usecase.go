@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/auth"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/rbac"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+const (
+	basePrefix    = "api-rbac-role:"
+	cacheDuration = 3600
+)
+
+// RBAC UseCase
+type rbacUC struct {
+	cfg       *config.Config
+	rbacRepo  rbac.Repository
+	redisRepo rbac.RedisRepository
+	authUC    auth.UseCase
+	logger    logger.Logger
+}
+
+// RBAC UseCase constructor
+func NewRBACUseCase(
+	cfg *config.Config,
+	rbacRepo rbac.Repository,
+	redisRepo rbac.RedisRepository,
+	authUC auth.UseCase,
+	log logger.Logger,
+) rbac.UseCase {
+	return &rbacUC{cfg: cfg, rbacRepo: rbacRepo, redisRepo: redisRepo, authUC: authUC, logger: log}
+}
+
+// Get all roles with granted permissions
+func (u *rbacUC) GetRoles(ctx context.Context) ([]*models.Role, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacUC.GetRoles")
+	defer span.Finish()
+
+	roles, err := u.rbacRepo.GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		if role.Permissions, err = u.GetRolePermissions(ctx, role.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return roles, nil
+}
+
+// Create role without permissions
+func (u *rbacUC) CreateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacUC.CreateRole")
+	defer span.Finish()
+
+	role.Name = normalizeName(role.Name)
+
+	_, err := u.rbacRepo.GetRoleByName(ctx, role.Name)
+	if err == nil {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.RoleExists.Error(), nil)
+	}
+	if errors.Cause(err) != sql.ErrNoRows {
+		return nil, err
+	}
+
+	createdRole, err := u.rbacRepo.CreateRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	createdRole.Permissions = make([]string, 0)
+
+	return createdRole, nil
+}
+
+// Delete role, built-in roles and roles assigned to users can not be deleted
+func (u *rbacUC) DeleteRole(ctx context.Context, name string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacUC.DeleteRole")
+	defer span.Finish()
+
+	name = normalizeName(name)
+	if name == models.RoleUser || name == models.RoleAdmin {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.ProtectedRole.Error(), nil)
+	}
+
+	if err := u.rbacRepo.DeleteRole(ctx, name); err != nil {
+		return err
+	}
+
+	if err := u.redisRepo.DeletePermissionsCtx(ctx, u.getKeyWithPrefix(name)); err != nil {
+		u.logger.Errorf("rbacUC.DeleteRole.DeletePermissionsCtx: %s", err)
+	}
+
+	return nil
+}
+
+// Get all permissions
+func (u *rbacUC) GetPermissions(ctx context.Context) ([]*models.Permission, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacUC.GetPermissions")
+	defer span.Finish()
+
+	return u.rbacRepo.GetPermissions(ctx)
+}
+
+// Create permission, it has effect only when checked by the api
+func (u *rbacUC) CreatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacUC.CreatePermission")
+	defer span.Finish()
+
+	permission.Name = normalizeName(permission.Name)
+
+	_, err := u.rbacRepo.GetPermissionByName(ctx, permission.Name)
+	if err == nil {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.PermissionExists.Error(), nil)
+	}
+	if errors.Cause(err) != sql.ErrNoRows {
+		return nil, err
+	}
+
+	return u.rbacRepo.CreatePermission(ctx, permission)
+}
+
+// Delete permission and revoke it from all roles
+func (u *rbacUC) DeletePermission(ctx context.Context, name string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacUC.DeletePermission")
+	defer span.Finish()
+
+	name = normalizeName(name)
+	if name == models.PermissionRolesManage {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.ProtectedRole.Error(), nil)
+	}
+
+	if err := u.rbacRepo.DeletePermission(ctx, name); err != nil {
+		return err
+	}
+
+	roles, err := u.rbacRepo.GetRoles(ctx)
+	if err != nil {
+		u.logger.Errorf("rbacUC.DeletePermission.GetRoles: %s", err)
+		return nil
+	}
+
+	keys := make([]string, 0, len(roles))
+	for _, role := range roles {
+		keys = append(keys, u.getKeyWithPrefix(role.Name))
+	}
+	if err = u.redisRepo.DeletePermissionsCtx(ctx, keys...); err != nil {
+		u.logger.Errorf("rbacUC.DeletePermission.DeletePermissionsCtx: %s", err)
+	}
+
+	return nil
+}
+
+// Grant permission to role
+func (u *rbacUC) GrantPermission(ctx context.Context, roleName string, permissionName string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacUC.GrantPermission")
+	defer span.Finish()
+
+	roleName = normalizeName(roleName)
+	if err := u.rbacRepo.GrantPermission(ctx, roleName, normalizeName(permissionName)); err != nil {
+		return err
+	}
+
+	if err := u.redisRepo.DeletePermissionsCtx(ctx, u.getKeyWithPrefix(roleName)); err != nil {
+		u.logger.Errorf("rbacUC.GrantPermission.DeletePermissionsCtx: %s", err)
+	}
+
+	return nil
+}
+
+// Revoke permission from role, admin always keeps roles management
+func (u *rbacUC) RevokePermission(ctx context.Context, roleName string, permissionName string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacUC.RevokePermission")
+	defer span.Finish()
+
+	roleName = normalizeName(roleName)
+	permissionName = normalizeName(permissionName)
+	if roleName == models.RoleAdmin && permissionName == models.PermissionRolesManage {
+		return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.ProtectedRole.Error(), nil)
+	}
+
+	if err := u.rbacRepo.RevokePermission(ctx, roleName, permissionName); err != nil {
+		return err
+	}
+
+	if err := u.redisRepo.DeletePermissionsCtx(ctx, u.getKeyWithPrefix(roleName)); err != nil {
+		u.logger.Errorf("rbacUC.RevokePermission.DeletePermissionsCtx: %s", err)
+	}
+
+	return nil
+}
+
+// Get permissions granted to role, cached in redis
+func (u *rbacUC) GetRolePermissions(ctx context.Context, roleName string) ([]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacUC.GetRolePermissions")
+	defer span.Finish()
+
+	cached, err := u.redisRepo.GetPermissionsCtx(ctx, u.getKeyWithPrefix(roleName))
+	if err != nil {
+		u.logger.Errorf("rbacUC.GetRolePermissions.GetPermissionsCtx: %v", err)
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	permissions, err := u.rbacRepo.GetRolePermissions(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.SetPermissionsCtx(ctx, u.getKeyWithPrefix(roleName), cacheDuration, permissions); err != nil {
+		u.logger.Errorf("rbacUC.GetRolePermissions.SetPermissionsCtx: %s", err)
+	}
+
+	return permissions, nil
+}
+
+// Assign existing role to user
+func (u *rbacUC) AssignRole(ctx context.Context, userID uuid.UUID, roleName string) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "rbacUC.AssignRole")
+	defer span.Finish()
+
+	roleName = normalizeName(roleName)
+
+	role, err := u.rbacRepo.GetRoleByName(ctx, roleName)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.UnknownRole.Error(), err)
+		}
+		return nil, err
+	}
+
+	return u.authUC.UpdateRole(ctx, userID, role.Name)
+}
+
+func (u *rbacUC) getKeyWithPrefix(roleName string) string {
+	return fmt.Sprintf("%s: %s", basePrefix, roleName)
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
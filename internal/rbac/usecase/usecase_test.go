@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	mockAuth "github.com/AleksK1NG/api-mc/internal/auth/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/rbac/mock"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+func TestRBACUC_GetRolePermissions(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockRBACRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	rbacUC := NewRBACUseCase(cfg, mockRBACRepo, mockRedisRepo, nil, apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "rbacUC.GetRolePermissions")
+	defer span.Finish()
+
+	t.Run("Cached", func(t *testing.T) {
+		permissions := []string{models.PermissionNewsDeleteAny}
+		mockRedisRepo.EXPECT().GetPermissionsCtx(ctxWithTrace, "api-rbac-role:: moderator").Return(permissions, nil)
+
+		result, err := rbacUC.GetRolePermissions(ctx, "moderator")
+		require.NoError(t, err)
+		require.Equal(t, permissions, result)
+	})
+
+	t.Run("Cache miss", func(t *testing.T) {
+		permissions := []string{models.PermissionRolesManage}
+		mockRedisRepo.EXPECT().GetPermissionsCtx(ctxWithTrace, "api-rbac-role:: admin").Return(nil, errors.New("redis: nil"))
+		mockRBACRepo.EXPECT().GetRolePermissions(ctxWithTrace, "admin").Return(permissions, nil)
+		mockRedisRepo.EXPECT().SetPermissionsCtx(ctxWithTrace, "api-rbac-role:: admin", cacheDuration, permissions).Return(nil)
+
+		result, err := rbacUC.GetRolePermissions(ctx, "admin")
+		require.NoError(t, err)
+		require.Equal(t, permissions, result)
+	})
+}
+
+func TestRBACUC_CreateRole(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockRBACRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	rbacUC := NewRBACUseCase(cfg, mockRBACRepo, mockRedisRepo, nil, apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "rbacUC.CreateRole")
+	defer span.Finish()
+
+	t.Run("CreateRole", func(t *testing.T) {
+		role := &models.Role{Name: " Editor "}
+		mockRBACRepo.EXPECT().GetRoleByName(ctxWithTrace, "editor").Return(nil, errors.Wrap(sql.ErrNoRows, "GetContext"))
+		mockRBACRepo.EXPECT().CreateRole(ctxWithTrace, gomock.Any()).Return(&models.Role{RoleID: uuid.New(), Name: "editor"}, nil)
+
+		createdRole, err := rbacUC.CreateRole(ctx, role)
+		require.NoError(t, err)
+		require.Equal(t, "editor", createdRole.Name)
+		require.Empty(t, createdRole.Permissions)
+	})
+
+	t.Run("Role exists", func(t *testing.T) {
+		mockRBACRepo.EXPECT().GetRoleByName(ctxWithTrace, "admin").Return(&models.Role{Name: "admin"}, nil)
+
+		_, err := rbacUC.CreateRole(ctx, &models.Role{Name: "admin"})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestRBACUC_ProtectedRoles(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockRBACRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	rbacUC := NewRBACUseCase(cfg, mockRBACRepo, mockRedisRepo, nil, apiLogger)
+
+	ctx := context.Background()
+
+	t.Run("Delete built-in role", func(t *testing.T) {
+		err := rbacUC.DeleteRole(ctx, models.RoleAdmin)
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Delete roles management permission", func(t *testing.T) {
+		err := rbacUC.DeletePermission(ctx, models.PermissionRolesManage)
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Revoke roles management from admin", func(t *testing.T) {
+		err := rbacUC.RevokePermission(ctx, "Admin", models.PermissionRolesManage)
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestRBACUC_GrantPermission(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockRBACRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	rbacUC := NewRBACUseCase(cfg, mockRBACRepo, mockRedisRepo, nil, apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "rbacUC.GrantPermission")
+	defer span.Finish()
+
+	t.Run("GrantPermission", func(t *testing.T) {
+		mockRBACRepo.EXPECT().GrantPermission(ctxWithTrace, "moderator", models.PermissionNewsUpdateAny).Return(nil)
+		mockRedisRepo.EXPECT().DeletePermissionsCtx(ctxWithTrace, "api-rbac-role:: moderator").Return(nil)
+
+		err := rbacUC.GrantPermission(ctx, "moderator", models.PermissionNewsUpdateAny)
+		require.NoError(t, err)
+	})
+}
+
+func TestRBACUC_AssignRole(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockRBACRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAuthUC := mockAuth.NewMockUseCase(ctrl)
+	rbacUC := NewRBACUseCase(cfg, mockRBACRepo, mockRedisRepo, mockAuthUC, apiLogger)
+
+	ctx := context.Background()
+	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "rbacUC.AssignRole")
+	defer span.Finish()
+
+	userID := uuid.New()
+
+	t.Run("AssignRole", func(t *testing.T) {
+		role := "moderator"
+		mockRBACRepo.EXPECT().GetRoleByName(ctxWithTrace, role).Return(&models.Role{Name: role}, nil)
+		mockAuthUC.EXPECT().UpdateRole(ctxWithTrace, userID, role).Return(&models.User{UserID: userID, Role: &role}, nil)
+
+		user, err := rbacUC.AssignRole(ctx, userID, "Moderator")
+		require.NoError(t, err)
+		require.Equal(t, role, *user.Role)
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		mockRBACRepo.EXPECT().GetRoleByName(ctxWithTrace, "root").Return(nil, errors.Wrap(sql.ErrNoRows, "GetContext"))
+
+		_, err := rbacUC.AssignRole(ctx, userID, "root")
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
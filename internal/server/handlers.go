@@ -12,24 +12,66 @@ import (
 	echoSwagger "github.com/swaggo/echo-swagger"
 
 	// _ "github.com/AleksK1NG/api-mc/docs"
+	apiKeysHttp "github.com/AleksK1NG/api-mc/internal/apikeys/delivery/http"
+	apiKeysRepository "github.com/AleksK1NG/api-mc/internal/apikeys/repository"
+	apiKeysUseCase "github.com/AleksK1NG/api-mc/internal/apikeys/usecase"
+	auditHttp "github.com/AleksK1NG/api-mc/internal/audit/delivery/http"
+	auditRepository "github.com/AleksK1NG/api-mc/internal/audit/repository"
+	auditUseCase "github.com/AleksK1NG/api-mc/internal/audit/usecase"
 	authHttp "github.com/AleksK1NG/api-mc/internal/auth/delivery/http"
 	authRepository "github.com/AleksK1NG/api-mc/internal/auth/repository"
 	authUseCase "github.com/AleksK1NG/api-mc/internal/auth/usecase"
+	categoriesHttp "github.com/AleksK1NG/api-mc/internal/categories/delivery/http"
+	categoriesRepository "github.com/AleksK1NG/api-mc/internal/categories/repository"
+	categoriesUseCase "github.com/AleksK1NG/api-mc/internal/categories/usecase"
 	commentsHttp "github.com/AleksK1NG/api-mc/internal/comments/delivery/http"
 	commentsRepository "github.com/AleksK1NG/api-mc/internal/comments/repository"
 	commentsUseCase "github.com/AleksK1NG/api-mc/internal/comments/usecase"
+	filesHttp "github.com/AleksK1NG/api-mc/internal/files/delivery/http"
+	followsHttp "github.com/AleksK1NG/api-mc/internal/follows/delivery/http"
+	followsRepository "github.com/AleksK1NG/api-mc/internal/follows/repository"
+	followsUseCase "github.com/AleksK1NG/api-mc/internal/follows/usecase"
+	gdprHttp "github.com/AleksK1NG/api-mc/internal/gdpr/delivery/http"
+	gdprRepository "github.com/AleksK1NG/api-mc/internal/gdpr/repository"
+	gdprUseCase "github.com/AleksK1NG/api-mc/internal/gdpr/usecase"
+	invitesHttp "github.com/AleksK1NG/api-mc/internal/invites/delivery/http"
+	invitesRepository "github.com/AleksK1NG/api-mc/internal/invites/repository"
+	invitesUseCase "github.com/AleksK1NG/api-mc/internal/invites/usecase"
 	apiMiddlewares "github.com/AleksK1NG/api-mc/internal/middleware"
+	moderationHttp "github.com/AleksK1NG/api-mc/internal/moderation/delivery/http"
+	moderationRepository "github.com/AleksK1NG/api-mc/internal/moderation/repository"
+	moderationUseCase "github.com/AleksK1NG/api-mc/internal/moderation/usecase"
 	newsHttp "github.com/AleksK1NG/api-mc/internal/news/delivery/http"
 	newsRepository "github.com/AleksK1NG/api-mc/internal/news/repository"
 	newsUseCase "github.com/AleksK1NG/api-mc/internal/news/usecase"
+	oidcHttp "github.com/AleksK1NG/api-mc/internal/oidc/delivery/http"
+	oidcRepository "github.com/AleksK1NG/api-mc/internal/oidc/repository"
+	oidcUseCase "github.com/AleksK1NG/api-mc/internal/oidc/usecase"
+	rbacHttp "github.com/AleksK1NG/api-mc/internal/rbac/delivery/http"
+	rbacRepository "github.com/AleksK1NG/api-mc/internal/rbac/repository"
+	rbacUseCase "github.com/AleksK1NG/api-mc/internal/rbac/usecase"
 	sessionRepository "github.com/AleksK1NG/api-mc/internal/session/repository"
 	"github.com/AleksK1NG/api-mc/internal/session/usecase"
+	uploadsHttp "github.com/AleksK1NG/api-mc/internal/uploads/delivery/http"
+	uploadsRepository "github.com/AleksK1NG/api-mc/internal/uploads/repository"
+	uploadsUseCase "github.com/AleksK1NG/api-mc/internal/uploads/usecase"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+	"github.com/AleksK1NG/api-mc/pkg/mailer"
 	"github.com/AleksK1NG/api-mc/pkg/metric"
 	"github.com/AleksK1NG/api-mc/pkg/utils"
 )
 
+// Verifiers may cache published keys, new keys should be published before they are activated
+const jwksCacheControl = "public, max-age=300"
+
 // Map Server Handlers
 func (s *Server) MapHandlers(e *echo.Echo) error {
+	ipExtractor, err := newIPExtractor(s.cfg)
+	if err != nil {
+		return err
+	}
+	e.IPExtractor = ipExtractor
+
 	metrics, err := metric.CreateMetrics(s.cfg.Metrics.URL, s.cfg.Metrics.ServiceName)
 	if err != nil {
 		s.logger.Errorf("CreateMetrics Error: %s", err)
@@ -40,27 +82,79 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		s.cfg.Metrics.ServiceName,
 	)
 
+	blobStore, err := blobstore.NewBlobStore(s.cfg, s.awsClient)
+	if err != nil {
+		return err
+	}
+
 	// Init repositories
 	aRepo := authRepository.NewAuthRepository(s.db)
 	nRepo := newsRepository.NewNewsRepository(s.db)
 	cRepo := commentsRepository.NewCommentsRepository(s.db)
 	sRepo := sessionRepository.NewSessionRepository(s.redisClient, s.cfg)
-	aAWSRepo := authRepository.NewAuthAWSRepository(s.awsClient)
+	aAWSRepo := authRepository.NewAuthAWSRepository(blobStore)
 	authRedisRepo := authRepository.NewAuthRedisRepo(s.redisClient)
 	newsRedisRepo := newsRepository.NewNewsRedisRepo(s.redisClient)
+	oRepo := oidcRepository.NewOIDCRepository(s.db)
+	oidcRedisRepo := oidcRepository.NewOIDCRedisRepo(s.redisClient)
+	akRepo := apiKeysRepository.NewAPIKeysRepository(s.db)
+	rbacRepo := rbacRepository.NewRBACRepository(s.db)
+	rbacRedisRepo := rbacRepository.NewRBACRedisRepo(s.redisClient)
+	gdprRepo := gdprRepository.NewGDPRRepository(s.db)
+	gdprRedisRepo := gdprRepository.NewGDPRRedisRepo(s.redisClient)
+	gdprAWSRepo := gdprRepository.NewGDPRAWSRepository(blobStore)
+	moderationRepo := moderationRepository.NewModerationRepository(s.db)
+	moderationRedisRepo := moderationRepository.NewModerationRedisRepo(s.redisClient)
+	auditRepo := auditRepository.NewAuditRepository(s.db)
+	invitesRepo := invitesRepository.NewInvitesRepository(s.db)
+	followsRepo := followsRepository.NewFollowsRepository(s.db)
+	uploadsRedisRepo := uploadsRepository.NewUploadsRedisRepo(s.redisClient)
+	// Direct uploads are presigned by minio, tickets are refused with other store backends
+	uploadsAWSRepo := uploadsRepository.NewUploadsAWSRepository(s.awsClient, blobStore)
+	categoriesRepo := categoriesRepository.NewCategoriesRepository(s.db)
+
+	appMailer, err := mailer.NewMailer(s.cfg)
+	if err != nil {
+		return err
+	}
+
+	jwtKeys, err := utils.NewJWTKeySet(s.cfg)
+	if err != nil {
+		return err
+	}
 
 	// Init useCases
-	authUC := authUseCase.NewAuthUseCase(s.cfg, aRepo, authRedisRepo, aAWSRepo, s.logger)
+	sessUC := usecase.NewSessionUseCase(sRepo, s.cfg)
+	moderationUC := moderationUseCase.NewModerationUseCase(s.cfg, moderationRepo, moderationRedisRepo, aRepo, rbacRepo, sessUC, s.logger)
+	invitesUC := invitesUseCase.NewInvitesUseCase(s.cfg, invitesRepo, rbacRepo, s.logger)
+	authUC := authUseCase.NewAuthUseCase(s.cfg, aRepo, authRedisRepo, aAWSRepo, sessUC, moderationUC, invitesUC, appMailer, jwtKeys, s.logger)
 	newsUC := newsUseCase.NewNewsUseCase(s.cfg, nRepo, newsRedisRepo, s.logger)
 	commUC := commentsUseCase.NewCommentsUseCase(s.cfg, cRepo, s.logger)
-	sessUC := usecase.NewSessionUseCase(sRepo, s.cfg)
+	oidcUC := oidcUseCase.NewOIDCUseCase(s.cfg, oRepo, oidcRedisRepo, aRepo, authUC, s.logger)
+	apiKeysUC := apiKeysUseCase.NewAPIKeysUseCase(s.cfg, akRepo, s.logger)
+	rbacUC := rbacUseCase.NewRBACUseCase(s.cfg, rbacRepo, rbacRedisRepo, authUC, s.logger)
+	auditUC := auditUseCase.NewAuditUseCase(s.cfg, auditRepo, s.logger)
+	followsUC := followsUseCase.NewFollowsUseCase(s.cfg, followsRepo, aRepo, s.logger)
+	gdprUC := gdprUseCase.NewGDPRUseCase(s.cfg, gdprRepo, gdprRedisRepo, gdprAWSRepo, aRepo, authUC, s.logger)
+	uploadsUC := uploadsUseCase.NewUploadsUseCase(s.cfg, uploadsRedisRepo, uploadsAWSRepo, authUC, newsUC, s.logger)
+	categoriesUC := categoriesUseCase.NewCategoriesUseCase(s.cfg, categoriesRepo, s.logger)
 
 	// Init handlers
-	authHandlers := authHttp.NewAuthHandlers(s.cfg, authUC, sessUC, s.logger)
+	authHandlers := authHttp.NewAuthHandlers(s.cfg, authUC, sessUC, auditUC, rbacUC, s.logger)
 	newsHandlers := newsHttp.NewNewsHandlers(s.cfg, newsUC, s.logger)
 	commHandlers := commentsHttp.NewCommentsHandlers(s.cfg, commUC, s.logger)
-
-	mw := apiMiddlewares.NewMiddlewareManager(sessUC, authUC, s.cfg, []string{"*"}, s.logger)
+	oidcHandlers := oidcHttp.NewOIDCHandlers(s.cfg, oidcUC, sessUC, s.logger)
+	apiKeysHandlers := apiKeysHttp.NewAPIKeysHandlers(s.cfg, apiKeysUC, s.logger)
+	rbacHandlers := rbacHttp.NewRBACHandlers(s.cfg, rbacUC, s.logger)
+	gdprHandlers := gdprHttp.NewGDPRHandlers(s.cfg, gdprUC, s.logger)
+	moderationHandlers := moderationHttp.NewModerationHandlers(s.cfg, moderationUC, s.logger)
+	auditHandlers := auditHttp.NewAuditHandlers(s.cfg, auditUC, s.logger)
+	invitesHandlers := invitesHttp.NewInvitesHandlers(s.cfg, invitesUC, s.logger)
+	followsHandlers := followsHttp.NewFollowsHandlers(s.cfg, followsUC, s.logger)
+	uploadsHandlers := uploadsHttp.NewUploadsHandlers(s.cfg, uploadsUC, s.logger)
+	categoriesHandlers := categoriesHttp.NewCategoriesHandlers(s.cfg, categoriesUC, s.logger)
+
+	mw := apiMiddlewares.NewMiddlewareManager(sessUC, authUC, apiKeysUC, rbacUC, moderationUC, auditUC, jwtKeys, s.cfg, []string{"*"}, s.logger)
 
 	e.Use(mw.RequestLoggerMiddleware)
 
@@ -73,7 +167,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, csrf.CSRFHeader},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, echo.HeaderAuthorization, csrf.CSRFHeader},
 	}))
 	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
 		StackSize:         1 << 10, // 1 KB
@@ -85,26 +179,63 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: 5,
+		// Stored files are already compressed images and range responses must not be re-encoded
 		Skipper: func(c echo.Context) bool {
-			return strings.Contains(c.Request().URL.Path, "swagger")
+			return strings.Contains(c.Request().URL.Path, "swagger") || strings.HasPrefix(c.Request().URL.Path, blobstore.FilesPath)
 		},
 	}))
 	e.Use(middleware.Secure())
-	e.Use(middleware.BodyLimit("2M"))
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Limit: "2M",
+		// Avatar upload route sets its own limit
+		Skipper: func(c echo.Context) bool {
+			return strings.HasSuffix(c.Path(), "/avatar")
+		},
+	}))
 	if s.cfg.Server.Debug {
 		e.Use(mw.DebugMiddleware)
 	}
 
+	e.GET("/.well-known/jwks.json", func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", jwksCacheControl)
+		return c.JSON(http.StatusOK, jwtKeys.JWKS())
+	})
+
 	v1 := e.Group("/api/v1")
 
 	health := v1.Group("/health")
 	authGroup := v1.Group("/auth")
 	newsGroup := v1.Group("/news")
 	commGroup := v1.Group("/comments")
+	oidcGroup := v1.Group("/oidc")
+	apiKeysGroup := v1.Group("/api-keys")
+	rbacGroup := v1.Group("/rbac")
+	gdprGroup := v1.Group("/gdpr")
+	moderationGroup := v1.Group("/moderation")
+	auditGroup := v1.Group("/audit")
+	invitesGroup := v1.Group("/invites")
+	followsGroup := v1.Group("/follows")
+	uploadsGroup := v1.Group("/uploads")
+	categoriesGroup := v1.Group("/categories")
 
 	authHttp.MapAuthRoutes(authGroup, authHandlers, mw)
 	newsHttp.MapNewsRoutes(newsGroup, newsHandlers, mw)
 	commentsHttp.MapCommentsRoutes(commGroup, commHandlers, mw)
+	oidcHttp.MapOIDCRoutes(oidcGroup, oidcHandlers, mw)
+	apiKeysHttp.MapAPIKeysRoutes(apiKeysGroup, apiKeysHandlers, mw)
+	rbacHttp.MapRBACRoutes(rbacGroup, rbacHandlers, mw)
+	gdprHttp.MapGDPRRoutes(gdprGroup, gdprHandlers, mw)
+	moderationHttp.MapModerationRoutes(moderationGroup, moderationHandlers, mw)
+	auditHttp.MapAuditRoutes(auditGroup, auditHandlers, mw)
+	invitesHttp.MapInvitesRoutes(invitesGroup, invitesHandlers, mw)
+	followsHttp.MapFollowsRoutes(followsGroup, followsHandlers, mw)
+	uploadsHttp.MapUploadsRoutes(uploadsGroup, uploadsHandlers, mw)
+	categoriesHttp.MapCategoriesRoutes(categoriesGroup, categoriesHandlers, mw)
+
+	// Minio serves its objects itself, filesystem backend files are served by API
+	if fileServer, ok := blobStore.(blobstore.FileServer); ok {
+		filesHttp.MapFilesRoutes(v1.Group("/files"), filesHttp.NewFilesHandlers(s.cfg, fileServer, s.logger))
+	}
 
 	health.GET("", func(c echo.Context) error {
 		s.logger.Infof("Health check RequestID: %s", utils.GetRequestID(c))
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
 	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
 
 	"github.com/AleksK1NG/api-mc/config"
 	_ "github.com/AleksK1NG/api-mc/docs"
@@ -115,3 +117,26 @@ func (s *Server) Run() error {
 	s.logger.Info("Server Exited Properly")
 	return s.echo.Server.Shutdown(ctx)
 }
+
+// Client address from X-Forwarded-For is used only when request came through configured trusted proxy,
+// otherwise any client could spoof its address and bypass per-IP limits
+func newIPExtractor(cfg *config.Config) (echo.IPExtractor, error) {
+	if len(cfg.Server.TrustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range cfg.Server.TrustedProxies {
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Server.TrustedProxies %s", proxy)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	models "github.com/AleksK1NG/api-mc/internal/models"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	reflect "reflect"
 )
 
@@ -77,3 +78,60 @@ func (mr *MockSessRepositoryMockRecorder) DeleteByID(ctx, sessionID interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSessRepository)(nil).DeleteByID), ctx, sessionID)
 }
+
+// TouchSession mocks base method
+func (m *MockSessRepository) TouchSession(ctx context.Context, sessionID string, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", ctx, sessionID, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession
+func (mr *MockSessRepositoryMockRecorder) TouchSession(ctx, sessionID, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockSessRepository)(nil).TouchSession), ctx, sessionID, session)
+}
+
+// GetSessionsByUserID mocks base method
+func (m *MockSessRepository) GetSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionsByUserID indicates an expected call of GetSessionsByUserID
+func (mr *MockSessRepositoryMockRecorder) GetSessionsByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionsByUserID", reflect.TypeOf((*MockSessRepository)(nil).GetSessionsByUserID), ctx, userID)
+}
+
+// DeleteUserSession mocks base method
+func (m *MockSessRepository) DeleteUserSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSession indicates an expected call of DeleteUserSession
+func (mr *MockSessRepositoryMockRecorder) DeleteUserSession(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSession", reflect.TypeOf((*MockSessRepository)(nil).DeleteUserSession), ctx, userID, sessionID)
+}
+
+// DeleteUserSessions mocks base method
+func (m *MockSessRepository) DeleteUserSessions(ctx context.Context, userID uuid.UUID, exceptSessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSessions", ctx, userID, exceptSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSessions indicates an expected call of DeleteUserSessions
+func (mr *MockSessRepositoryMockRecorder) DeleteUserSessions(ctx, userID, exceptSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockSessRepository)(nil).DeleteUserSessions), ctx, userID, exceptSessionID)
+}
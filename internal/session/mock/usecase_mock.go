@@ -8,6 +8,7 @@ import (
 	context "context"
 	models "github.com/AleksK1NG/api-mc/internal/models"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	reflect "reflect"
 )
 
@@ -77,3 +78,60 @@ func (mr *MockUCSessionMockRecorder) DeleteByID(ctx, sessionID interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockUCSession)(nil).DeleteByID), ctx, sessionID)
 }
+
+// TouchSession mocks base method
+func (m *MockUCSession) TouchSession(ctx context.Context, sessionID string, session *models.Session, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", ctx, sessionID, session, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession
+func (mr *MockUCSessionMockRecorder) TouchSession(ctx, sessionID, session, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockUCSession)(nil).TouchSession), ctx, sessionID, session, ip)
+}
+
+// GetUserSessions mocks base method
+func (m *MockUCSession) GetUserSessions(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessions", ctx, userID, currentSessionID)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessions indicates an expected call of GetUserSessions
+func (mr *MockUCSessionMockRecorder) GetUserSessions(ctx, userID, currentSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessions", reflect.TypeOf((*MockUCSession)(nil).GetUserSessions), ctx, userID, currentSessionID)
+}
+
+// RevokeSession mocks base method
+func (m *MockUCSession) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession
+func (mr *MockUCSessionMockRecorder) RevokeSession(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUCSession)(nil).RevokeSession), ctx, userID, sessionID)
+}
+
+// RevokeUserSessions mocks base method
+func (m *MockUCSession) RevokeUserSessions(ctx context.Context, userID uuid.UUID, exceptSessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, userID, exceptSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions
+func (mr *MockUCSessionMockRecorder) RevokeUserSessions(ctx, userID, exceptSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockUCSession)(nil).RevokeUserSessions), ctx, userID, exceptSessionID)
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
+	"sort"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
@@ -17,9 +17,31 @@ import (
 )
 
 const (
-	basePrefix = "api-session:"
+	basePrefix      = "api-session:"
+	userIndexPrefix = "api-session-user:"
 )
 
+// Stores session and adds it to user index, index ttl is only raised so shorter session never expires
+// index before longer sessions of user
+var createSessionScript = redis.NewScript(`
+redis.call('SET', KEYS[1], ARGV[1], 'EX', ARGV[3])
+redis.call('SADD', KEYS[2], ARGV[2])
+if redis.call('TTL', KEYS[2]) < tonumber(ARGV[3]) then
+	redis.call('EXPIRE', KEYS[2], ARGV[3])
+end
+return 1
+`)
+
+// Rewrites session only while it exists keeping its expiration, so revoked session is never recreated
+var touchSessionScript = redis.NewScript(`
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl <= 0 then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+return 1
+`)
+
 // Session repository
 type sessionRepo struct {
 	redisClient *redis.Client
@@ -44,8 +66,12 @@ func (s *sessionRepo) CreateSession(ctx context.Context, sess *models.Session, e
 	if err != nil {
 		return "", errors.WithMessage(err, "sessionRepo.CreateSession.json.Marshal")
 	}
-	if err = s.redisClient.Set(ctx, sessionKey, sessBytes, time.Second*time.Duration(expire)).Err(); err != nil {
-		return "", errors.Wrap(err, "sessionRepo.CreateSession.redisClient.Set")
+
+	// Index keeps session ids of user, it lives as long as the longest living session
+	indexKey := s.createUserIndexKey(sess.UserID)
+	keys := []string{sessionKey, indexKey}
+	if err = createSessionScript.Run(ctx, s.redisClient, keys, sessBytes, sess.SessionID, expire).Err(); err != nil {
+		return "", errors.Wrap(err, "sessionRepo.CreateSession.createSessionScript.Run")
 	}
 	return sessionKey, nil
 }
@@ -72,12 +98,146 @@ func (s *sessionRepo) DeleteByID(ctx context.Context, sessionID string) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "sessionRepo.DeleteByID")
 	defer span.Finish()
 
-	if err := s.redisClient.Del(ctx, sessionID).Err(); err != nil {
+	sess, err := s.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		if errors.Cause(err) == redis.Nil {
+			return nil
+		}
+		return errors.Wrap(err, "sessionRepo.DeleteByID.GetSessionByID")
+	}
+
+	pipe := s.redisClient.TxPipeline()
+	pipe.Del(ctx, sessionID)
+	pipe.SRem(ctx, s.createUserIndexKey(sess.UserID), sess.SessionID)
+	if _, err = pipe.Exec(ctx); err != nil {
 		return errors.Wrap(err, "sessionRepo.DeleteByID")
 	}
 	return nil
 }
 
+// Update stored session keeping its expiration
+func (s *sessionRepo) TouchSession(ctx context.Context, sessionID string, sess *models.Session) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sessionRepo.TouchSession")
+	defer span.Finish()
+
+	sessBytes, err := json.Marshal(sess)
+	if err != nil {
+		return errors.WithMessage(err, "sessionRepo.TouchSession.json.Marshal")
+	}
+	if err = touchSessionScript.Run(ctx, s.redisClient, []string{sessionID}, sessBytes).Err(); err != nil {
+		return errors.Wrap(err, "sessionRepo.TouchSession.touchSessionScript.Run")
+	}
+	return nil
+}
+
+// Get active sessions of user, expired sessions are removed from index
+func (s *sessionRepo) GetSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sessionRepo.GetSessionsByUserID")
+	defer span.Finish()
+
+	indexKey := s.createUserIndexKey(userID)
+	sessionIDs, err := s.redisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "sessionRepo.GetSessionsByUserID.redisClient.SMembers")
+	}
+	if len(sessionIDs) == 0 {
+		return []*models.Session{}, nil
+	}
+
+	keys := make([]string, 0, len(sessionIDs))
+	for _, id := range sessionIDs {
+		keys = append(keys, s.createKey(id))
+	}
+
+	values, err := s.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "sessionRepo.GetSessionsByUserID.redisClient.MGet")
+	}
+
+	sessions := make([]*models.Session, 0, len(values))
+	expired := make([]interface{}, 0)
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			expired = append(expired, sessionIDs[i])
+			continue
+		}
+		sess := &models.Session{}
+		if err = json.Unmarshal([]byte(raw), sess); err != nil {
+			return nil, errors.Wrap(err, "sessionRepo.GetSessionsByUserID.json.Unmarshal")
+		}
+		sessions = append(sessions, sess)
+	}
+
+	if len(expired) > 0 {
+		if err = s.redisClient.SRem(ctx, indexKey, expired...).Err(); err != nil {
+			return nil, errors.Wrap(err, "sessionRepo.GetSessionsByUserID.redisClient.SRem")
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeen.After(sessions[j].LastSeen)
+	})
+
+	return sessions, nil
+}
+
+// Delete single session of user, returns redis.Nil when session does not belong to user
+func (s *sessionRepo) DeleteUserSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sessionRepo.DeleteUserSession")
+	defer span.Finish()
+
+	removed, err := s.redisClient.SRem(ctx, s.createUserIndexKey(userID), sessionID).Result()
+	if err != nil {
+		return errors.Wrap(err, "sessionRepo.DeleteUserSession.redisClient.SRem")
+	}
+	if removed == 0 {
+		return errors.Wrap(redis.Nil, "sessionRepo.DeleteUserSession")
+	}
+
+	if err = s.redisClient.Del(ctx, s.createKey(sessionID)).Err(); err != nil {
+		return errors.Wrap(err, "sessionRepo.DeleteUserSession.redisClient.Del")
+	}
+	return nil
+}
+
+// Delete all sessions of user except given one, empty exceptSessionID deletes all of them
+func (s *sessionRepo) DeleteUserSessions(ctx context.Context, userID uuid.UUID, exceptSessionID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sessionRepo.DeleteUserSessions")
+	defer span.Finish()
+
+	indexKey := s.createUserIndexKey(userID)
+	sessionIDs, err := s.redisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return errors.Wrap(err, "sessionRepo.DeleteUserSessions.redisClient.SMembers")
+	}
+
+	keys := make([]string, 0, len(sessionIDs))
+	members := make([]interface{}, 0, len(sessionIDs))
+	for _, id := range sessionIDs {
+		if id == exceptSessionID {
+			continue
+		}
+		keys = append(keys, s.createKey(id))
+		members = append(members, id)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := s.redisClient.TxPipeline()
+	pipe.Del(ctx, keys...)
+	pipe.SRem(ctx, indexKey, members...)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "sessionRepo.DeleteUserSessions.pipe.Exec")
+	}
+	return nil
+}
+
 func (s *sessionRepo) createKey(sessionID string) string {
 	return fmt.Sprintf("%s: %s", s.basePrefix, sessionID)
 }
+
+func (s *sessionRepo) createUserIndexKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s: %s", userIndexPrefix, userID.String())
+}
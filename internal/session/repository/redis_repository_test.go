@@ -4,10 +4,12 @@ import (
 	"context"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/AleksK1NG/api-mc/internal/models"
@@ -76,3 +78,123 @@ func TestSessionRepo_DeleteByID(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestSessionRepo_UserSessions(t *testing.T) {
+	t.Parallel()
+
+	sessRepository := SetupRedis()
+
+	t.Run("UserSessions", func(t *testing.T) {
+		userID := uuid.New()
+		now := time.Now().UTC()
+
+		first, err := sessRepository.CreateSession(context.Background(), &models.Session{
+			UserID:   userID,
+			LastSeen: now.Add(-time.Hour),
+		}, 10)
+		require.NoError(t, err)
+		second, err := sessRepository.CreateSession(context.Background(), &models.Session{
+			UserID:   userID,
+			LastSeen: now,
+		}, 10)
+		require.NoError(t, err)
+		third, err := sessRepository.CreateSession(context.Background(), &models.Session{
+			UserID:   userID,
+			LastSeen: now.Add(-time.Minute),
+		}, 10)
+		require.NoError(t, err)
+
+		sessions, err := sessRepository.GetSessionsByUserID(context.Background(), userID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 3)
+		require.True(t, sessions[0].LastSeen.Equal(now))
+
+		secondSess, err := sessRepository.GetSessionByID(context.Background(), second)
+		require.NoError(t, err)
+		secondSess.IP = "10.0.0.1"
+		err = sessRepository.TouchSession(context.Background(), second, secondSess)
+		require.NoError(t, err)
+
+		secondSess, err = sessRepository.GetSessionByID(context.Background(), second)
+		require.NoError(t, err)
+		require.Equal(t, "10.0.0.1", secondSess.IP)
+
+		err = sessRepository.DeleteUserSession(context.Background(), uuid.New(), secondSess.SessionID)
+		require.Error(t, err)
+		require.Equal(t, redis.Nil, errors.Cause(err))
+
+		err = sessRepository.DeleteByID(context.Background(), third)
+		require.NoError(t, err)
+
+		sessions, err = sessRepository.GetSessionsByUserID(context.Background(), userID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+
+		err = sessRepository.DeleteUserSessions(context.Background(), userID, secondSess.SessionID)
+		require.NoError(t, err)
+
+		_, err = sessRepository.GetSessionByID(context.Background(), first)
+		require.Error(t, err)
+
+		sessions, err = sessRepository.GetSessionsByUserID(context.Background(), userID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		require.Equal(t, secondSess.SessionID, sessions[0].SessionID)
+
+		err = sessRepository.DeleteUserSession(context.Background(), userID, secondSess.SessionID)
+		require.NoError(t, err)
+
+		_, err = sessRepository.GetSessionByID(context.Background(), second)
+		require.Error(t, err)
+	})
+}
+
+func TestSessionRepo_UserIndexTTL(t *testing.T) {
+	t.Parallel()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	sessRepository := NewSessionRepository(redis.NewClient(&redis.Options{Addr: mr.Addr()}), nil)
+
+	t.Run("Shorter session keeps index ttl", func(t *testing.T) {
+		userID := uuid.New()
+
+		_, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userID}, 3600)
+		require.NoError(t, err)
+		_, err = sessRepository.CreateSession(context.Background(), &models.Session{UserID: userID}, 1800)
+		require.NoError(t, err)
+
+		mr.FastForward(time.Second * 1801)
+
+		sessions, err := sessRepository.GetSessionsByUserID(context.Background(), userID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+	})
+}
+
+func TestSessionRepo_TouchRevokedSession(t *testing.T) {
+	t.Parallel()
+
+	sessRepository := SetupRedis()
+
+	t.Run("Revoked session stays gone", func(t *testing.T) {
+		userID := uuid.New()
+
+		sessionID, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userID}, 10)
+		require.NoError(t, err)
+		sess, err := sessRepository.GetSessionByID(context.Background(), sessionID)
+		require.NoError(t, err)
+
+		err = sessRepository.DeleteUserSessions(context.Background(), userID, "")
+		require.NoError(t, err)
+
+		err = sessRepository.TouchSession(context.Background(), sessionID, sess)
+		require.NoError(t, err)
+
+		_, err = sessRepository.GetSessionByID(context.Background(), sessionID)
+		require.Error(t, err)
+		require.Equal(t, redis.Nil, errors.Cause(err))
+	})
+}
@@ -4,6 +4,8 @@ package session
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/AleksK1NG/api-mc/internal/models"
 )
 
@@ -12,4 +14,8 @@ type UCSession interface {
 	CreateSession(ctx context.Context, session *models.Session, expire int) (string, error)
 	GetSessionByID(ctx context.Context, sessionID string) (*models.Session, error)
 	DeleteByID(ctx context.Context, sessionID string) error
+	TouchSession(ctx context.Context, sessionID string, session *models.Session, ip string) error
+	GetUserSessions(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]*models.Session, error)
+	RevokeSession(ctx context.Context, userID uuid.UUID, sessionID string) error
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID, exceptSessionID string) error
 }
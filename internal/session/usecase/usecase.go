@@ -2,14 +2,23 @@ package usecase
 
 import (
 	"context"
+	"net/http"
+	"time"
 
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
 	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
 
 	"github.com/AleksK1NG/api-mc/config"
 	"github.com/AleksK1NG/api-mc/internal/models"
 	"github.com/AleksK1NG/api-mc/internal/session"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
 )
 
+// Last seen time is written at most once per interval to avoid redis write on every request
+const lastSeenInterval = time.Minute
+
 // Session use case
 type sessionUC struct {
 	sessionRepo session.SessRepository
@@ -26,6 +35,10 @@ func (u *sessionUC) CreateSession(ctx context.Context, session *models.Session,
 	span, ctx := opentracing.StartSpanFromContext(ctx, "sessionUC.CreateSession")
 	defer span.Finish()
 
+	now := time.Now().UTC()
+	session.CreatedAt = now
+	session.LastSeen = now
+
 	return u.sessionRepo.CreateSession(ctx, session, expire)
 }
 
@@ -44,3 +57,61 @@ func (u *sessionUC) GetSessionByID(ctx context.Context, sessionID string) (*mode
 
 	return u.sessionRepo.GetSessionByID(ctx, sessionID)
 }
+
+// Update session last seen time and ip address
+func (u *sessionUC) TouchSession(ctx context.Context, sessionID string, session *models.Session, ip string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sessionUC.TouchSession")
+	defer span.Finish()
+
+	now := time.Now().UTC()
+	if now.Sub(session.LastSeen) < lastSeenInterval && (ip == "" || ip == session.IP) {
+		return nil
+	}
+
+	session.LastSeen = now
+	if ip != "" {
+		session.IP = ip
+	}
+
+	return u.sessionRepo.TouchSession(ctx, sessionID, session)
+}
+
+// Get active sessions of user, current session is marked
+func (u *sessionUC) GetUserSessions(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]*models.Session, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sessionUC.GetUserSessions")
+	defer span.Finish()
+
+	sessions, err := u.sessionRepo.GetSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sess := range sessions {
+		sess.Current = sess.SessionID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+// Revoke single session of user
+func (u *sessionUC) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sessionUC.RevokeSession")
+	defer span.Finish()
+
+	if err := u.sessionRepo.DeleteUserSession(ctx, userID, sessionID); err != nil {
+		if errors.Cause(err) == redis.Nil {
+			return httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Revoke all sessions of user except given one, empty exceptSessionID revokes all of them
+func (u *sessionUC) RevokeUserSessions(ctx context.Context, userID uuid.UUID, exceptSessionID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sessionUC.RevokeUserSessions")
+	defer span.Finish()
+
+	return u.sessionRepo.DeleteUserSessions(ctx, userID, exceptSessionID)
+}
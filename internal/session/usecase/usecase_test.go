@@ -2,13 +2,19 @@ package usecase
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/AleksK1NG/api-mc/internal/models"
 	"github.com/AleksK1NG/api-mc/internal/session/mock"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
 )
 
 func TestSessionUC_CreateSession(t *testing.T) {
@@ -71,3 +77,73 @@ func TestSessionUC_DeleteByID(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, err)
 }
+
+func TestSessionUC_TouchSession(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessRepo := mock.NewMockSessRepository(ctrl)
+	sessUC := NewSessionUseCase(mockSessRepo, nil)
+
+	ctx := context.Background()
+	sid := "session id"
+
+	recent := &models.Session{LastSeen: time.Now().UTC(), IP: "10.0.0.1"}
+	err := sessUC.TouchSession(ctx, sid, recent, "10.0.0.1")
+	require.NoError(t, err)
+
+	stale := &models.Session{LastSeen: time.Now().UTC().Add(-time.Hour), IP: "10.0.0.1"}
+	mockSessRepo.EXPECT().TouchSession(gomock.Any(), sid, gomock.Eq(stale)).Return(nil)
+
+	err = sessUC.TouchSession(ctx, sid, stale, "10.0.0.2")
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.2", stale.IP)
+	require.WithinDuration(t, time.Now().UTC(), stale.LastSeen, time.Second)
+}
+
+func TestSessionUC_GetUserSessions(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessRepo := mock.NewMockSessRepository(ctrl)
+	sessUC := NewSessionUseCase(mockSessRepo, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	sessions := []*models.Session{{SessionID: "current"}, {SessionID: "other"}}
+
+	mockSessRepo.EXPECT().GetSessionsByUserID(gomock.Any(), userID).Return(sessions, nil)
+
+	userSessions, err := sessUC.GetUserSessions(ctx, userID, "current")
+	require.NoError(t, err)
+	require.True(t, userSessions[0].Current)
+	require.False(t, userSessions[1].Current)
+}
+
+func TestSessionUC_RevokeSession(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessRepo := mock.NewMockSessRepository(ctrl)
+	sessUC := NewSessionUseCase(mockSessRepo, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockSessRepo.EXPECT().DeleteUserSession(gomock.Any(), userID, "session id").Return(nil)
+
+	err := sessUC.RevokeSession(ctx, userID, "session id")
+	require.NoError(t, err)
+
+	mockSessRepo.EXPECT().DeleteUserSession(gomock.Any(), userID, "unknown").Return(errors.Wrap(redis.Nil, "sessionRepo.DeleteUserSession"))
+
+	err = sessUC.RevokeSession(ctx, userID, "unknown")
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+}
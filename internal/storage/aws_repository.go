@@ -0,0 +1,14 @@
+//go:generate mockgen -source aws_repository.go -destination mock/aws_repository_mock.go -package mock
+package storage
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Storage AWS S3 interface
+type AWSRepository interface {
+	ListObjects(ctx context.Context, bucket string) ([]*models.StoredObject, error)
+	RemoveObject(ctx context.Context, bucket string, objectName string) error
+}
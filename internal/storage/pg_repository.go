@@ -0,0 +1,9 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package storage
+
+import "context"
+
+// Storage Repository
+type Repository interface {
+	GetReferencedURLs(ctx context.Context, prefix string) ([]string, error)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/storage"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+)
+
+// Storage AWS S3 repository
+type storageAWSRepository struct {
+	store blobstore.BlobStore
+}
+
+// Storage AWS S3 repository constructor
+func NewStorageAWSRepository(blobStore blobstore.BlobStore) storage.AWSRepository {
+	return &storageAWSRepository{store: blobStore}
+}
+
+// List all objects of bucket
+func (aws *storageAWSRepository) ListObjects(ctx context.Context, bucket string) ([]*models.StoredObject, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storageAWSRepository.ListObjects")
+	defer span.Finish()
+
+	infos, err := aws.store.List(ctx, bucket)
+	if err != nil {
+		return nil, errors.Wrap(err, "storageAWSRepository.ListObjects")
+	}
+
+	objects := make([]*models.StoredObject, 0, len(infos))
+	for _, info := range infos {
+		objects = append(objects, &models.StoredObject{
+			Key:          info.Key,
+			Size:         info.Size,
+			LastModified: info.LastModified,
+		})
+	}
+
+	return objects, nil
+}
+
+// Delete object
+func (aws *storageAWSRepository) RemoveObject(ctx context.Context, bucket string, objectName string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storageAWSRepository.RemoveObject")
+	defer span.Finish()
+
+	if err := aws.store.Remove(ctx, bucket, objectName); err != nil {
+		return errors.Wrap(err, "storageAWSRepository.RemoveObject")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/storage"
+)
+
+// Storage Repository
+type storageRepo struct {
+	db *sqlx.DB
+}
+
+// Storage Repository constructor
+func NewStorageRepository(db *sqlx.DB) storage.Repository {
+	return &storageRepo{db: db}
+}
+
+// Get distinct urls with prefix referenced by any row
+func (r *storageRepo) GetReferencedURLs(ctx context.Context, prefix string) ([]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storageRepo.GetReferencedURLs")
+	defer span.Finish()
+
+	urls := make([]string, 0)
+	if err := r.db.SelectContext(ctx, &urls, getReferencedURLsQuery, prefix); err != nil {
+		return nil, errors.Wrap(err, "storageRepo.GetReferencedURLs.SelectContext")
+	}
+
+	return urls, nil
+}
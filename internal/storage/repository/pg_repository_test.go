@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageRepo_GetReferencedURLs(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	storageRepo := NewStorageRepository(sqlxDB)
+
+	t.Run("GetReferencedURLs", func(t *testing.T) {
+		prefix := "http://127.0.0.1:9000/minio/avatars/"
+		rows := sqlmock.NewRows([]string{"url"}).
+			AddRow(prefix + "uid/1/64.jpg").
+			AddRow(prefix + "uid/1/512.jpg")
+
+		mock.ExpectQuery(getReferencedURLsQuery).WithArgs(prefix).WillReturnRows(rows)
+
+		urls, err := storageRepo.GetReferencedURLs(context.Background(), prefix)
+		require.NoError(t, err)
+		require.Len(t, urls, 2)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
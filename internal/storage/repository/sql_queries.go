@@ -0,0 +1,13 @@
+package repository
+
+const (
+	// Every column holding urls of stored objects must be listed here, unlisted objects are removed as orphans
+	getReferencedURLsQuery = `SELECT a.value AS url
+						FROM users u,
+							 jsonb_each_text(u.avatars) a
+						WHERE a.value LIKE $1 || '%'
+						UNION
+						SELECT image_url AS url
+						FROM news
+						WHERE image_url LIKE $1 || '%'`
+)
@@ -0,0 +1,14 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Storage UseCase
+type UseCase interface {
+	Reconcile(ctx context.Context, bucket string, gracePeriod time.Duration, dryRun bool) (*models.ReconcileReport, error)
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/storage"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+const defaultExportBucket = "user-exports"
+
+var errExportBucket = errors.New("export archives are tracked by export jobs, not rows, and can not be reconciled")
+
+// Storage UseCase
+type storageUC struct {
+	cfg         *config.Config
+	storageRepo storage.Repository
+	awsRepo     storage.AWSRepository
+	logger      logger.Logger
+}
+
+// Storage UseCase constructor
+func NewStorageUseCase(cfg *config.Config, storageRepo storage.Repository, awsRepo storage.AWSRepository, log logger.Logger) storage.UseCase {
+	return &storageUC{cfg: cfg, storageRepo: storageRepo, awsRepo: awsRepo, logger: log}
+}
+
+// Find bucket objects no row references and remove those older than grace period.
+// Grace period covers objects uploaded but not yet saved to a row.
+// Objects and referenced urls both belong to configured store backend.
+func (u *storageUC) Reconcile(ctx context.Context, bucket string, gracePeriod time.Duration, dryRun bool) (*models.ReconcileReport, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storageUC.Reconcile")
+	defer span.Finish()
+
+	if bucket == u.getExportBucket() {
+		return nil, errExportBucket
+	}
+
+	// Objects are listed before references are read, so object saved to a row meanwhile is always seen as referenced
+	objects, err := u.awsRepo.ListObjects(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	urls, err := u.storageRepo.GetReferencedURLs(ctx, blobstore.ObjectURL(u.cfg, bucket, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		if urlBucket, objectName, ok := blobstore.ParseObjectURL(u.cfg, url); ok && urlBucket == bucket {
+			referenced[objectName] = true
+		}
+	}
+
+	report := &models.ReconcileReport{
+		Bucket:      bucket,
+		DryRun:      dryRun,
+		GracePeriod: gracePeriod.String(),
+		Scanned:     len(objects),
+		Orphaned:    make([]*models.StoredObject, 0),
+	}
+
+	deadline := time.Now().Add(-gracePeriod)
+	for _, object := range objects {
+		if referenced[object.Key] {
+			report.Referenced++
+			continue
+		}
+		if object.LastModified.After(deadline) {
+			report.InGracePeriod++
+			continue
+		}
+
+		report.Orphaned = append(report.Orphaned, object)
+		report.OrphanedBytes += object.Size
+		if dryRun {
+			continue
+		}
+
+		if err = u.awsRepo.RemoveObject(ctx, bucket, object.Key); err != nil {
+			u.logger.Errorf("storageUC.Reconcile.RemoveObject Bucket: %s, Key: %s, Error: %s", bucket, object.Key, err)
+			report.Failed++
+			continue
+		}
+		report.Removed++
+	}
+
+	return report, nil
+}
+
+func (u *storageUC) getExportBucket() string {
+	if u.cfg.GDPR.ExportBucket == "" {
+		return defaultExportBucket
+	}
+	return u.cfg.GDPR.ExportBucket
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/storage/mock"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+)
+
+func TestStorageUC_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AWS: config.AWS{
+			MinioEndpoint: "http://127.0.0.1:9000",
+		},
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockStorageRepo := mock.NewMockRepository(ctrl)
+	mockAWSRepo := mock.NewMockAWSRepository(ctrl)
+	storageUC := NewStorageUseCase(cfg, mockStorageRepo, mockAWSRepo, apiLogger)
+
+	ctx := context.Background()
+	old := time.Now().Add(-48 * time.Hour)
+	objects := []*models.StoredObject{
+		{Key: "uid/1/64.jpg", Size: 10, LastModified: old},
+		{Key: "uid/0/64.jpg", Size: 20, LastModified: old},
+		{Key: "uid/0/512.jpg", Size: 30, LastModified: old},
+		{Key: "uid/2/64.jpg", Size: 40, LastModified: time.Now()},
+	}
+	referenced := []string{
+		"http://127.0.0.1:9000/minio/avatars/uid/1/64.jpg",
+		"http://127.0.0.1:9000/minio/other/uid/0/64.jpg",
+	}
+
+	t.Run("Dry run", func(t *testing.T) {
+		mockAWSRepo.EXPECT().ListObjects(gomock.Any(), "avatars").Return(objects, nil)
+		mockStorageRepo.EXPECT().GetReferencedURLs(gomock.Any(), "http://127.0.0.1:9000/minio/avatars/").Return(referenced, nil)
+
+		report, err := storageUC.Reconcile(ctx, "avatars", 24*time.Hour, true)
+		require.NoError(t, err)
+		require.Equal(t, 4, report.Scanned)
+		require.Equal(t, 1, report.Referenced)
+		require.Equal(t, 1, report.InGracePeriod)
+		require.Len(t, report.Orphaned, 2)
+		require.Equal(t, int64(50), report.OrphanedBytes)
+		require.Equal(t, 0, report.Removed)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		mockAWSRepo.EXPECT().ListObjects(gomock.Any(), "avatars").Return(objects, nil)
+		mockStorageRepo.EXPECT().GetReferencedURLs(gomock.Any(), gomock.Any()).Return(referenced, nil)
+		mockAWSRepo.EXPECT().RemoveObject(gomock.Any(), "avatars", "uid/0/64.jpg").Return(nil)
+		mockAWSRepo.EXPECT().RemoveObject(gomock.Any(), "avatars", "uid/0/512.jpg").Return(errors.New("connection reset"))
+
+		report, err := storageUC.Reconcile(ctx, "avatars", 24*time.Hour, false)
+		require.NoError(t, err)
+		require.Equal(t, 1, report.Removed)
+		require.Equal(t, 1, report.Failed)
+	})
+
+	t.Run("Filesystem backend", func(t *testing.T) {
+		fsCfg := &config.Config{Store: config.Store{Backend: "filesystem", PublicURL: "http://localhost:5000"}}
+		fsStorageUC := NewStorageUseCase(fsCfg, mockStorageRepo, mockAWSRepo, apiLogger)
+
+		mockAWSRepo.EXPECT().ListObjects(gomock.Any(), "avatars").Return(objects, nil)
+		mockStorageRepo.EXPECT().GetReferencedURLs(gomock.Any(), "http://localhost:5000/api/v1/files/avatars/").Return(
+			[]string{"http://localhost:5000/api/v1/files/avatars/uid/0/64.jpg"}, nil,
+		)
+
+		report, err := fsStorageUC.Reconcile(ctx, "avatars", 24*time.Hour, true)
+		require.NoError(t, err)
+		require.Equal(t, 1, report.Referenced)
+		require.Len(t, report.Orphaned, 2)
+	})
+
+	t.Run("Export bucket", func(t *testing.T) {
+		report, err := storageUC.Reconcile(ctx, defaultExportBucket, 24*time.Hour, true)
+		require.Error(t, err)
+		require.Nil(t, report)
+	})
+}
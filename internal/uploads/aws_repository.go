@@ -0,0 +1,19 @@
+//go:generate mockgen -source aws_repository.go -destination mock/aws_repository_mock.go -package mock
+package uploads
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Uploads AWS S3 interface, objects are uploaded by clients with presigned POST policies
+type AWSRepository interface {
+	PresignPost(ctx context.Context, bucket string, objectName string, contentType string, maxSize int64, expires time.Duration) (string, map[string]string, error)
+	PublishObject(ctx context.Context, bucket string, objectName string, contentType string) error
+	StatObject(ctx context.Context, bucket string, objectName string) (*models.StoredObject, error)
+	GetObject(ctx context.Context, bucket string, objectName string) (io.ReadCloser, error)
+	RemoveObject(ctx context.Context, bucket string, objectName string) error
+}
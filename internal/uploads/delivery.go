@@ -0,0 +1,9 @@
+package uploads
+
+import "github.com/labstack/echo/v4"
+
+// Uploads HTTP Handlers interface
+type Handlers interface {
+	CreateTicket() echo.HandlerFunc
+	Complete() echo.HandlerFunc
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/uploads"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+// Uploads handlers
+type uploadsHandlers struct {
+	cfg       *config.Config
+	uploadsUC uploads.UseCase
+	logger    logger.Logger
+}
+
+// NewUploadsHandlers Uploads handlers constructor
+func NewUploadsHandlers(cfg *config.Config, uploadsUC uploads.UseCase, log logger.Logger) uploads.Handlers {
+	return &uploadsHandlers{cfg: cfg, uploadsUC: uploadsUC, logger: log}
+}
+
+// CreateTicket godoc
+// @Summary Create upload ticket
+// @Description issue presigned POST url and form data for avatar or news image, upload object to it as multipart form with ticket form data fields, then complete the ticket
+// @Tags Uploads
+// @Accept json
+// @Produce json
+// @Param body body models.UploadTicketRequest true "upload target, content type and size"
+// @Success 201 {object} models.UploadTicket
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Router /uploads [post]
+func (h *uploadsHandlers) CreateTicket() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "uploadsHandlers.CreateTicket")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		req := &models.UploadTicketRequest{}
+		if err := utils.ReadRequest(c, req); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		ticket, err := h.uploadsUC.CreateTicket(ctx, user.UserID, req)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, ticket)
+	}
+}
+
+// Complete godoc
+// @Summary Complete upload
+// @Description verify uploaded object and attach it to user avatar or news item, ticket can be completed once
+// @Tags Uploads
+// @Accept json
+// @Produce json
+// @Param ticket_id path string true "ticket_id"
+// @Success 200 {object} models.UploadResult
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /uploads/{ticket_id}/complete [post]
+func (h *uploadsHandlers) Complete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "uploadsHandlers.Complete")
+		defer span.Finish()
+
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			utils.LogResponseError(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		ticketID, err := uuid.Parse(c.Param("ticket_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		result, err := h.uploadsUC.Complete(ctx, user.UserID, ticketID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
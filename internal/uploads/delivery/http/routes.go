@@ -0,0 +1,14 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/AleksK1NG/api-mc/internal/middleware"
+	"github.com/AleksK1NG/api-mc/internal/uploads"
+)
+
+// Map uploads routes
+func MapUploadsRoutes(uploadsGroup *echo.Group, h uploads.Handlers, mw *middleware.MiddlewareManager) {
+	uploadsGroup.POST("", h.CreateTicket(), mw.AuthSessionMiddleware, mw.CSRF)
+	uploadsGroup.POST("/:ticket_id/complete", h.Complete(), mw.AuthSessionMiddleware, mw.CSRF)
+}
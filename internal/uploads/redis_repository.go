@@ -0,0 +1,15 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository_mock.go -package mock
+package uploads
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Uploads redis repository, stores pending upload tickets
+type RedisRepository interface {
+	GetTicketCtx(ctx context.Context, key string) (*models.UploadTicket, error)
+	SetTicketCtx(ctx context.Context, key string, seconds int, ticket *models.UploadTicket) error
+	DeleteTicketCtx(ctx context.Context, key string) error
+}
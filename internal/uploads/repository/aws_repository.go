@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/uploads"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+)
+
+// Uploads AWS S3 repository, presigning is minio only and uploaded objects are read through blob store
+type uploadsAWSRepository struct {
+	client *minio.Client
+	store  blobstore.BlobStore
+}
+
+// Uploads AWS S3 repository constructor
+func NewUploadsAWSRepository(awsClient *minio.Client, blobStore blobstore.BlobStore) uploads.AWSRepository {
+	return &uploadsAWSRepository{client: awsClient, store: blobStore}
+}
+
+// Presigned POST url and form fields for object, storage rejects uploads of other content type
+// or larger than max size. Bucket is created on first use
+func (aws *uploadsAWSRepository) PresignPost(
+	ctx context.Context,
+	bucket string,
+	objectName string,
+	contentType string,
+	maxSize int64,
+	expires time.Duration,
+) (string, map[string]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadsAWSRepository.PresignPost")
+	defer span.Finish()
+
+	exists, err := aws.client.BucketExists(ctx, bucket)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "uploadsAWSRepository.PresignPost.BucketExists")
+	}
+	if !exists {
+		if err = aws.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+			return "", nil, errors.Wrap(err, "uploadsAWSRepository.PresignPost.MakeBucket")
+		}
+	}
+
+	policy := minio.NewPostPolicy()
+	if err = policy.SetBucket(bucket); err != nil {
+		return "", nil, errors.Wrap(err, "uploadsAWSRepository.PresignPost.SetBucket")
+	}
+	if err = policy.SetKey(objectName); err != nil {
+		return "", nil, errors.Wrap(err, "uploadsAWSRepository.PresignPost.SetKey")
+	}
+	if err = policy.SetExpires(time.Now().UTC().Add(expires)); err != nil {
+		return "", nil, errors.Wrap(err, "uploadsAWSRepository.PresignPost.SetExpires")
+	}
+	if err = policy.SetContentType(contentType); err != nil {
+		return "", nil, errors.Wrap(err, "uploadsAWSRepository.PresignPost.SetContentType")
+	}
+	if err = policy.SetContentLengthRange(1, maxSize); err != nil {
+		return "", nil, errors.Wrap(err, "uploadsAWSRepository.PresignPost.SetContentLengthRange")
+	}
+
+	uploadURL, formData, err := aws.client.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "uploadsAWSRepository.PresignPost.PresignedPostPolicy")
+	}
+
+	return uploadURL.String(), formData, nil
+}
+
+// Copy object in place with public read acl, the same metadata objects uploaded through API get
+func (aws *uploadsAWSRepository) PublishObject(ctx context.Context, bucket string, objectName string, contentType string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadsAWSRepository.PublishObject")
+	defer span.Finish()
+
+	dst := minio.CopyDestOptions{
+		Bucket:          bucket,
+		Object:          objectName,
+		ReplaceMetadata: true,
+		UserMetadata: map[string]string{
+			"Content-Type": contentType,
+			"x-amz-acl":    "public-read",
+		},
+	}
+	if _, err := aws.client.CopyObject(ctx, dst, minio.CopySrcOptions{Bucket: bucket, Object: objectName}); err != nil {
+		return errors.Wrap(err, "uploadsAWSRepository.PublishObject.CopyObject")
+	}
+
+	return nil
+}
+
+// Object size and content type, missing object is blobstore.ErrNotFound
+func (aws *uploadsAWSRepository) StatObject(ctx context.Context, bucket string, objectName string) (*models.StoredObject, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadsAWSRepository.StatObject")
+	defer span.Finish()
+
+	info, err := aws.store.Stat(ctx, bucket, objectName)
+	if err != nil {
+		return nil, errors.Wrap(err, "uploadsAWSRepository.StatObject")
+	}
+
+	return &models.StoredObject{
+		Key:          info.Key,
+		Size:         info.Size,
+		LastModified: info.LastModified,
+		ContentType:  info.ContentType,
+	}, nil
+}
+
+// Download object
+func (aws *uploadsAWSRepository) GetObject(ctx context.Context, bucket string, objectName string) (io.ReadCloser, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadsAWSRepository.GetObject")
+	defer span.Finish()
+
+	object, err := aws.store.Get(ctx, bucket, objectName)
+	if err != nil {
+		return nil, errors.Wrap(err, "uploadsAWSRepository.GetObject")
+	}
+
+	return object, nil
+}
+
+// Delete object
+func (aws *uploadsAWSRepository) RemoveObject(ctx context.Context, bucket string, objectName string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadsAWSRepository.RemoveObject")
+	defer span.Finish()
+
+	if err := aws.store.Remove(ctx, bucket, objectName); err != nil {
+		return errors.Wrap(err, "uploadsAWSRepository.RemoveObject")
+	}
+
+	return nil
+}
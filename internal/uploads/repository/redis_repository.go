@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/uploads"
+)
+
+// Uploads redis repository
+type uploadsRedisRepo struct {
+	redisClient *redis.Client
+}
+
+// Uploads redis repository constructor
+func NewUploadsRedisRepo(redisClient *redis.Client) uploads.RedisRepository {
+	return &uploadsRedisRepo{redisClient: redisClient}
+}
+
+// Get upload ticket
+func (r *uploadsRedisRepo) GetTicketCtx(ctx context.Context, key string) (*models.UploadTicket, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadsRedisRepo.GetTicketCtx")
+	defer span.Finish()
+
+	ticketBytes, err := r.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "uploadsRedisRepo.GetTicketCtx.redisClient.Get")
+	}
+	ticket := &models.UploadTicket{}
+	if err = json.Unmarshal(ticketBytes, ticket); err != nil {
+		return nil, errors.Wrap(err, "uploadsRedisRepo.GetTicketCtx.json.Unmarshal")
+	}
+
+	return ticket, nil
+}
+
+// Store upload ticket with duration in seconds
+func (r *uploadsRedisRepo) SetTicketCtx(ctx context.Context, key string, seconds int, ticket *models.UploadTicket) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadsRedisRepo.SetTicketCtx")
+	defer span.Finish()
+
+	ticketBytes, err := json.Marshal(ticket)
+	if err != nil {
+		return errors.Wrap(err, "uploadsRedisRepo.SetTicketCtx.json.Marshal")
+	}
+	if err = r.redisClient.Set(ctx, key, ticketBytes, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "uploadsRedisRepo.SetTicketCtx.redisClient.Set")
+	}
+
+	return nil
+}
+
+// Delete upload ticket
+func (r *uploadsRedisRepo) DeleteTicketCtx(ctx context.Context, key string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadsRedisRepo.DeleteTicketCtx")
+	defer span.Finish()
+
+	if err := r.redisClient.Del(ctx, key).Err(); err != nil {
+		return errors.Wrap(err, "uploadsRedisRepo.DeleteTicketCtx.redisClient.Del")
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/uploads"
+)
+
+func SetupRedis() uploads.RedisRepository {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	uploadsRedisRepo := NewUploadsRedisRepo(client)
+	return uploadsRedisRepo
+}
+
+func TestUploadsRedisRepo_TicketCtx(t *testing.T) {
+	t.Parallel()
+
+	uploadsRedisRepo := SetupRedis()
+
+	t.Run("Set, get and delete", func(t *testing.T) {
+		key := "key"
+		ticket := &models.UploadTicket{
+			TicketID:    uuid.New(),
+			UserID:      uuid.New(),
+			Target:      models.UploadAvatar,
+			ContentType: "image/png",
+			MaxSize:     1000,
+			ExpiresAt:   time.Now().UTC().Truncate(time.Second),
+		}
+
+		err := uploadsRedisRepo.SetTicketCtx(context.Background(), key, 10, ticket)
+		require.NoError(t, err)
+
+		storedTicket, err := uploadsRedisRepo.GetTicketCtx(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, ticket.TicketID, storedTicket.TicketID)
+		require.Equal(t, ticket.MaxSize, storedTicket.MaxSize)
+
+		err = uploadsRedisRepo.DeleteTicketCtx(context.Background(), key)
+		require.NoError(t, err)
+
+		_, err = uploadsRedisRepo.GetTicketCtx(context.Background(), key)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package uploads
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AleksK1NG/api-mc/internal/models"
+)
+
+// Uploads use case
+type UseCase interface {
+	CreateTicket(ctx context.Context, userID uuid.UUID, req *models.UploadTicketRequest) (*models.UploadTicket, error)
+	Complete(ctx context.Context, userID uuid.UUID, ticketID uuid.UUID) (*models.UploadResult, error)
+}
@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+	"github.com/AleksK1NG/api-mc/internal/auth"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/internal/news"
+	"github.com/AleksK1NG/api-mc/internal/uploads"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+const (
+	ticketPrefix            = "api-upload-ticket:"
+	defaultAvatarBucket     = "avatars"
+	defaultNewsImageBucket  = "news-images"
+	defaultTicketExpire     = 900
+	defaultMaxAvatarSize    = 10 << 20
+	defaultMaxNewsImageSize = 5 << 20
+	// Content sniffing never reads more than this
+	sniffLength = 512
+)
+
+var uploadExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// Uploads UseCase
+type uploadsUC struct {
+	cfg       *config.Config
+	redisRepo uploads.RedisRepository
+	awsRepo   uploads.AWSRepository
+	authUC    auth.UseCase
+	newsUC    news.UseCase
+	logger    logger.Logger
+}
+
+// Uploads UseCase constructor
+func NewUploadsUseCase(
+	cfg *config.Config,
+	redisRepo uploads.RedisRepository,
+	awsRepo uploads.AWSRepository,
+	authUC auth.UseCase,
+	newsUC news.UseCase,
+	log logger.Logger,
+) uploads.UseCase {
+	return &uploadsUC{
+		cfg:       cfg,
+		redisRepo: redisRepo,
+		awsRepo:   awsRepo,
+		authUC:    authUC,
+		newsUC:    newsUC,
+		logger:    log,
+	}
+}
+
+// Issue presigned POST policy limited to ticket content type and size, and ticket to complete upload with.
+// Objects of tickets never completed are removed by storage reconciliation after its grace period.
+func (u *uploadsUC) CreateTicket(ctx context.Context, userID uuid.UUID, req *models.UploadTicketRequest) (*models.UploadTicket, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadsUC.CreateTicket")
+	defer span.Finish()
+
+	// Presigned urls are issued by minio, filesystem backend only accepts uploads through API
+	if blobstore.Backend(u.cfg) != blobstore.BackendMinio {
+		return nil, httpErrors.NewRestError(http.StatusNotImplemented, httpErrors.DirectUploadsDisabled.Error(), nil)
+	}
+
+	if err := utils.ValidateStruct(ctx, req); err != nil {
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "uploadsUC.CreateTicket.ValidateStruct"))
+	}
+
+	ticket := &models.UploadTicket{
+		TicketID:    uuid.New(),
+		UserID:      userID,
+		Target:      req.Target,
+		ContentType: req.ContentType,
+		MaxSize:     req.Size,
+		ExpiresAt:   time.Now().UTC().Add(time.Duration(u.getTicketExpire()) * time.Second),
+	}
+
+	var maxSize int64
+	switch req.Target {
+	case models.UploadAvatar:
+		maxSize = u.getMaxAvatarSize()
+		ticket.Bucket = u.getAvatarBucket()
+		// Staged object is processed into avatar sizes and removed on completion
+		ticket.ObjectName = fmt.Sprintf("uploads/%s/%s", userID.String(), ticket.TicketID.String())
+	case models.UploadNewsImage:
+		if req.NewsID == nil {
+			return nil, httpErrors.NewBadRequestError(errors.New("uploadsUC.CreateTicket news_id is required for news image"))
+		}
+		if err := u.validateNewsOwner(ctx, *req.NewsID); err != nil {
+			return nil, err
+		}
+		maxSize = u.getMaxNewsImageSize()
+		ticket.NewsID = req.NewsID
+		ticket.Bucket = u.getNewsImageBucket()
+		ticket.ObjectName = fmt.Sprintf("%s/%s%s", req.NewsID.String(), ticket.TicketID.String(), uploadExtensions[req.ContentType])
+	default:
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidUploadTarget.Error(), nil)
+	}
+
+	if req.Size > maxSize {
+		return nil, httpErrors.NewRestError(
+			http.StatusBadRequest,
+			httpErrors.UploadTooLarge.Error(),
+			errors.Errorf("uploadsUC.CreateTicket size %d exceeds %d", req.Size, maxSize),
+		)
+	}
+
+	uploadURL, formData, err := u.awsRepo.PresignPost(
+		ctx,
+		ticket.Bucket,
+		ticket.ObjectName,
+		ticket.ContentType,
+		ticket.MaxSize,
+		time.Duration(u.getTicketExpire())*time.Second,
+	)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "uploadsUC.CreateTicket.PresignPost"))
+	}
+	ticket.UploadURL = uploadURL
+	ticket.FormData = formData
+
+	if err = u.redisRepo.SetTicketCtx(ctx, u.getKeyWithPrefix(ticket.TicketID.String()), u.getTicketExpire(), ticket); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "uploadsUC.CreateTicket.SetTicketCtx"))
+	}
+
+	return ticket, nil
+}
+
+// Verify uploaded object size, content type and magic bytes, then attach it to ticket target.
+// Tickets are single use, rejected objects are removed.
+func (u *uploadsUC) Complete(ctx context.Context, userID uuid.UUID, ticketID uuid.UUID) (*models.UploadResult, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadsUC.Complete")
+	defer span.Finish()
+
+	ticket, err := u.redisRepo.GetTicketCtx(ctx, u.getKeyWithPrefix(ticketID.String()))
+	if err != nil {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.InvalidUploadTicket.Error(), errors.Wrap(err, "uploadsUC.Complete.GetTicketCtx"))
+	}
+	if ticket.UserID != userID {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.InvalidUploadTicket.Error(), errors.New("uploadsUC.Complete ticket of another user"))
+	}
+
+	object, err := u.awsRepo.StatObject(ctx, ticket.Bucket, ticket.ObjectName)
+	if err != nil {
+		if errors.Cause(err) == blobstore.ErrNotFound {
+			return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.UploadNotReceived.Error(), errors.Wrap(err, "uploadsUC.Complete.StatObject"))
+		}
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "uploadsUC.Complete.StatObject"))
+	}
+
+	if err = u.redisRepo.DeleteTicketCtx(ctx, u.getKeyWithPrefix(ticketID.String())); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "uploadsUC.Complete.DeleteTicketCtx"))
+	}
+
+	if err = u.verifyObject(ctx, ticket, object); err != nil {
+		u.removeObject(ctx, ticket.Bucket, ticket.ObjectName)
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidUpload.Error(), errors.Wrap(err, "uploadsUC.Complete.verifyObject"))
+	}
+
+	result := &models.UploadResult{TicketID: ticket.TicketID, Target: ticket.Target, NewsID: ticket.NewsID}
+	switch ticket.Target {
+	case models.UploadAvatar:
+		avatars, err := u.attachAvatar(ctx, ticket, object)
+		if err != nil {
+			return nil, err
+		}
+		result.Avatars = avatars
+	case models.UploadNewsImage:
+		imageURL, err := u.attachNewsImage(ctx, ticket)
+		if err != nil {
+			return nil, err
+		}
+		result.ImageURL = imageURL
+	default:
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.InvalidUploadTarget.Error(), nil)
+	}
+
+	return result, nil
+}
+
+// Object stored content type must be the ticket one, so it is never served as something else
+func (u *uploadsUC) verifyObject(ctx context.Context, ticket *models.UploadTicket, object *models.StoredObject) error {
+	if object.Size <= 0 || object.Size > ticket.MaxSize {
+		return errors.Errorf("size %d, max size %d", object.Size, ticket.MaxSize)
+	}
+	if object.ContentType != ticket.ContentType {
+		return errors.Errorf("content type %s, declared %s", object.ContentType, ticket.ContentType)
+	}
+
+	reader, err := u.awsRepo.GetObject(ctx, ticket.Bucket, ticket.ObjectName)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	header, err := ioutil.ReadAll(io.LimitReader(reader, sniffLength))
+	if err != nil {
+		return err
+	}
+	if detected := http.DetectContentType(header); detected != ticket.ContentType {
+		return errors.Errorf("detected content type %s, declared %s", detected, ticket.ContentType)
+	}
+
+	return nil
+}
+
+// Process staged object the same way as avatar uploaded through API, staged object is removed afterwards
+func (u *uploadsUC) attachAvatar(ctx context.Context, ticket *models.UploadTicket, object *models.StoredObject) (models.Avatars, error) {
+	reader, err := u.awsRepo.GetObject(ctx, ticket.Bucket, ticket.ObjectName)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "uploadsUC.attachAvatar.GetObject"))
+	}
+	defer reader.Close()
+
+	user, err := u.authUC.UploadAvatar(ctx, ticket.UserID, models.UploadInput{
+		File:        io.LimitReader(reader, ticket.MaxSize),
+		Name:        ticket.TicketID.String() + uploadExtensions[ticket.ContentType],
+		Size:        object.Size,
+		ContentType: ticket.ContentType,
+		BucketName:  ticket.Bucket,
+	})
+	u.removeObject(ctx, ticket.Bucket, ticket.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Avatars, nil
+}
+
+// Publish object and set it as news image, previous image object is removed
+func (u *uploadsUC) attachNewsImage(ctx context.Context, ticket *models.UploadTicket) (string, error) {
+	newsBase, err := u.newsUC.GetNewsByID(ctx, *ticket.NewsID)
+	if err != nil {
+		return "", err
+	}
+
+	if err = u.awsRepo.PublishObject(ctx, ticket.Bucket, ticket.ObjectName, ticket.ContentType); err != nil {
+		return "", httpErrors.NewInternalServerError(errors.Wrap(err, "uploadsUC.attachNewsImage.PublishObject"))
+	}
+
+	imageURL := blobstore.ObjectURL(u.cfg, ticket.Bucket, ticket.ObjectName)
+	if _, err = u.newsUC.Update(ctx, &models.News{NewsID: *ticket.NewsID, ImageURL: &imageURL}); err != nil {
+		return "", err
+	}
+
+	if newsBase.ImageURL != nil {
+		if bucket, objectName, ok := blobstore.ParseObjectURL(u.cfg, *newsBase.ImageURL); ok && bucket == ticket.Bucket {
+			u.removeObject(ctx, bucket, objectName)
+		}
+	}
+
+	return imageURL, nil
+}
+
+// Only author or user allowed to update any news may upload its image
+func (u *uploadsUC) validateNewsOwner(ctx context.Context, newsID uuid.UUID) error {
+	newsBase, err := u.newsUC.GetNewsByID(ctx, newsID)
+	if err != nil {
+		return err
+	}
+
+	if err = utils.ValidateIsOwner(ctx, newsBase.AuthorID.String(), u.logger); err != nil && !utils.HasPermission(ctx, models.PermissionNewsUpdateAny) {
+		return httpErrors.NewRestError(http.StatusForbidden, "Forbidden", errors.Wrap(err, "uploadsUC.validateNewsOwner.ValidateIsOwner"))
+	}
+
+	return nil
+}
+
+// Object left behind on failure is removed by storage reconciliation later
+func (u *uploadsUC) removeObject(ctx context.Context, bucket string, objectName string) {
+	if err := u.awsRepo.RemoveObject(ctx, bucket, objectName); err != nil {
+		u.logger.Errorf("uploadsUC.RemoveObject Bucket: %s, Key: %s, Error: %s", bucket, objectName, err)
+	}
+}
+
+func (u *uploadsUC) getKeyWithPrefix(ticketID string) string {
+	return fmt.Sprintf("%s: %s", ticketPrefix, ticketID)
+}
+
+func (u *uploadsUC) getAvatarBucket() string {
+	if u.cfg.Uploads.AvatarBucket == "" {
+		return defaultAvatarBucket
+	}
+	return u.cfg.Uploads.AvatarBucket
+}
+
+func (u *uploadsUC) getNewsImageBucket() string {
+	if u.cfg.Uploads.NewsImageBucket == "" {
+		return defaultNewsImageBucket
+	}
+	return u.cfg.Uploads.NewsImageBucket
+}
+
+func (u *uploadsUC) getTicketExpire() int {
+	if u.cfg.Uploads.TicketExpire <= 0 {
+		return defaultTicketExpire
+	}
+	return u.cfg.Uploads.TicketExpire
+}
+
+func (u *uploadsUC) getMaxAvatarSize() int64 {
+	if u.cfg.Uploads.MaxAvatarSize <= 0 {
+		return defaultMaxAvatarSize
+	}
+	return u.cfg.Uploads.MaxAvatarSize
+}
+
+func (u *uploadsUC) getMaxNewsImageSize() int64 {
+	if u.cfg.Uploads.MaxNewsImageSize <= 0 {
+		return defaultMaxNewsImageSize
+	}
+	return u.cfg.Uploads.MaxNewsImageSize
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AleksK1NG/api-mc/config"
+	authMock "github.com/AleksK1NG/api-mc/internal/auth/mock"
+	"github.com/AleksK1NG/api-mc/internal/models"
+	newsMock "github.com/AleksK1NG/api-mc/internal/news/mock"
+	"github.com/AleksK1NG/api-mc/internal/uploads/mock"
+	"github.com/AleksK1NG/api-mc/pkg/blobstore"
+	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
+	"github.com/AleksK1NG/api-mc/pkg/logger"
+	"github.com/AleksK1NG/api-mc/pkg/utils"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestUploadsUC_CreateTicket(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAWSRepo := mock.NewMockAWSRepository(ctrl)
+	mockNewsUC := newsMock.NewMockUseCase(ctrl)
+	uploadsUC := NewUploadsUseCase(cfg, mockRedisRepo, mockAWSRepo, nil, mockNewsUC, apiLogger)
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), utils.UserCtxKey{}, &models.User{UserID: userID})
+
+	t.Run("Avatar", func(t *testing.T) {
+		req := &models.UploadTicketRequest{Target: models.UploadAvatar, ContentType: "image/png", Size: 1 << 20}
+
+		mockAWSRepo.EXPECT().PresignPost(gomock.Any(), defaultAvatarBucket, gomock.Any(), "image/png", int64(1<<20), gomock.Any()).Return(
+			"http://127.0.0.1:9000/avatars", map[string]string{"policy": "policy"}, nil,
+		)
+		mockRedisRepo.EXPECT().SetTicketCtx(gomock.Any(), gomock.Any(), defaultTicketExpire, gomock.Any()).Return(nil)
+
+		ticket, err := uploadsUC.CreateTicket(ctx, userID, req)
+		require.NoError(t, err)
+		require.Equal(t, userID, ticket.UserID)
+		require.Equal(t, defaultAvatarBucket, ticket.Bucket)
+		require.Equal(t, "uploads/"+userID.String()+"/"+ticket.TicketID.String(), ticket.ObjectName)
+		require.Equal(t, int64(1<<20), ticket.MaxSize)
+		require.NotEmpty(t, ticket.UploadURL)
+		require.NotEmpty(t, ticket.FormData)
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		req := &models.UploadTicketRequest{Target: models.UploadAvatar, ContentType: "image/png", Size: defaultMaxAvatarSize + 1}
+
+		ticket, err := uploadsUC.CreateTicket(ctx, userID, req)
+		require.Error(t, err)
+		require.Nil(t, ticket)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.UploadTooLarge.Error())
+	})
+
+	t.Run("Unsupported content type", func(t *testing.T) {
+		req := &models.UploadTicketRequest{Target: models.UploadAvatar, ContentType: "text/html", Size: 100}
+
+		ticket, err := uploadsUC.CreateTicket(ctx, userID, req)
+		require.Error(t, err)
+		require.Nil(t, ticket)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("News image", func(t *testing.T) {
+		newsID := uuid.New()
+		req := &models.UploadTicketRequest{Target: models.UploadNewsImage, NewsID: &newsID, ContentType: "image/jpeg", Size: 100}
+
+		mockNewsUC.EXPECT().GetNewsByID(gomock.Any(), newsID).Return(&models.NewsBase{NewsID: newsID, AuthorID: userID}, nil)
+		mockAWSRepo.EXPECT().PresignPost(gomock.Any(), defaultNewsImageBucket, gomock.Any(), "image/jpeg", int64(100), gomock.Any()).Return(
+			"http://127.0.0.1:9000/news-images", map[string]string{"policy": "policy"}, nil,
+		)
+		mockRedisRepo.EXPECT().SetTicketCtx(gomock.Any(), gomock.Any(), defaultTicketExpire, gomock.Any()).Return(nil)
+
+		ticket, err := uploadsUC.CreateTicket(ctx, userID, req)
+		require.NoError(t, err)
+		require.Equal(t, newsID.String()+"/"+ticket.TicketID.String()+".jpg", ticket.ObjectName)
+	})
+
+	t.Run("Filesystem backend", func(t *testing.T) {
+		fsCfg := &config.Config{Store: config.Store{Backend: "filesystem"}}
+		fsUploadsUC := NewUploadsUseCase(fsCfg, mockRedisRepo, mockAWSRepo, nil, mockNewsUC, apiLogger)
+		req := &models.UploadTicketRequest{Target: models.UploadAvatar, ContentType: "image/png", Size: 100}
+
+		ticket, err := fsUploadsUC.CreateTicket(ctx, userID, req)
+		require.Error(t, err)
+		require.Nil(t, ticket)
+		require.Equal(t, http.StatusNotImplemented, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("News image of another author", func(t *testing.T) {
+		newsID := uuid.New()
+		req := &models.UploadTicketRequest{Target: models.UploadNewsImage, NewsID: &newsID, ContentType: "image/jpeg", Size: 100}
+
+		mockNewsUC.EXPECT().GetNewsByID(gomock.Any(), newsID).Return(&models.NewsBase{NewsID: newsID, AuthorID: uuid.New()}, nil)
+
+		ticket, err := uploadsUC.CreateTicket(ctx, userID, req)
+		require.Error(t, err)
+		require.Nil(t, ticket)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestUploadsUC_Complete(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AWS: config.AWS{
+			MinioEndpoint: "http://127.0.0.1:9000",
+		},
+		Logger: config.Logger{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAWSRepo := mock.NewMockAWSRepository(ctrl)
+	mockAuthUC := authMock.NewMockUseCase(ctrl)
+	mockNewsUC := newsMock.NewMockUseCase(ctrl)
+	uploadsUC := NewUploadsUseCase(cfg, mockRedisRepo, mockAWSRepo, mockAuthUC, mockNewsUC, apiLogger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	avatarTicket := func() *models.UploadTicket {
+		ticketID := uuid.New()
+		return &models.UploadTicket{
+			TicketID:    ticketID,
+			UserID:      userID,
+			Target:      models.UploadAvatar,
+			Bucket:      defaultAvatarBucket,
+			ObjectName:  "uploads/" + userID.String() + "/" + ticketID.String(),
+			ContentType: "image/png",
+			MaxSize:     1000,
+		}
+	}
+
+	t.Run("Avatar", func(t *testing.T) {
+		ticket := avatarTicket()
+		avatars := models.Avatars{"64": "http://127.0.0.1:9000/minio/avatars/64.jpg"}
+
+		mockRedisRepo.EXPECT().GetTicketCtx(gomock.Any(), gomock.Any()).Return(ticket, nil)
+		mockAWSRepo.EXPECT().StatObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(&models.StoredObject{Size: 500, ContentType: "image/png"}, nil)
+		mockRedisRepo.EXPECT().DeleteTicketCtx(gomock.Any(), gomock.Any()).Return(nil)
+		mockAWSRepo.EXPECT().GetObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(ioutil.NopCloser(bytes.NewReader(pngHeader)), nil).Times(2)
+		mockAuthUC.EXPECT().UploadAvatar(gomock.Any(), userID, gomock.Any()).Return(&models.User{UserID: userID, Avatars: avatars}, nil)
+		mockAWSRepo.EXPECT().RemoveObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(nil)
+
+		result, err := uploadsUC.Complete(ctx, userID, ticket.TicketID)
+		require.NoError(t, err)
+		require.Equal(t, avatars, result.Avatars)
+	})
+
+	t.Run("News image", func(t *testing.T) {
+		newsID := uuid.New()
+		previousURL := "http://127.0.0.1:9000/minio/news-images/" + newsID.String() + "/previous.jpg"
+		ticket := &models.UploadTicket{
+			TicketID:    uuid.New(),
+			UserID:      userID,
+			Target:      models.UploadNewsImage,
+			NewsID:      &newsID,
+			Bucket:      defaultNewsImageBucket,
+			ObjectName:  newsID.String() + "/image.png",
+			ContentType: "image/png",
+			MaxSize:     1000,
+		}
+
+		mockRedisRepo.EXPECT().GetTicketCtx(gomock.Any(), gomock.Any()).Return(ticket, nil)
+		mockAWSRepo.EXPECT().StatObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(&models.StoredObject{Size: 500, ContentType: "image/png"}, nil)
+		mockRedisRepo.EXPECT().DeleteTicketCtx(gomock.Any(), gomock.Any()).Return(nil)
+		mockAWSRepo.EXPECT().GetObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(ioutil.NopCloser(bytes.NewReader(pngHeader)), nil)
+		mockNewsUC.EXPECT().GetNewsByID(gomock.Any(), newsID).Return(&models.NewsBase{NewsID: newsID, ImageURL: &previousURL}, nil)
+		mockAWSRepo.EXPECT().PublishObject(gomock.Any(), ticket.Bucket, ticket.ObjectName, "image/png").Return(nil)
+		mockNewsUC.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&models.News{NewsID: newsID}, nil)
+		mockAWSRepo.EXPECT().RemoveObject(gomock.Any(), ticket.Bucket, newsID.String()+"/previous.jpg").Return(nil)
+
+		result, err := uploadsUC.Complete(ctx, userID, ticket.TicketID)
+		require.NoError(t, err)
+		require.Equal(t, "http://127.0.0.1:9000/minio/news-images/"+ticket.ObjectName, result.ImageURL)
+	})
+
+	t.Run("Magic bytes mismatch", func(t *testing.T) {
+		ticket := avatarTicket()
+
+		mockRedisRepo.EXPECT().GetTicketCtx(gomock.Any(), gomock.Any()).Return(ticket, nil)
+		mockAWSRepo.EXPECT().StatObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(&models.StoredObject{Size: 500, ContentType: "image/png"}, nil)
+		mockRedisRepo.EXPECT().DeleteTicketCtx(gomock.Any(), gomock.Any()).Return(nil)
+		mockAWSRepo.EXPECT().GetObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(ioutil.NopCloser(bytes.NewReader([]byte("<html><script></script></html>"))), nil)
+		mockAWSRepo.EXPECT().RemoveObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(nil)
+
+		result, err := uploadsUC.Complete(ctx, userID, ticket.TicketID)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		require.Contains(t, err.Error(), httpErrors.InvalidUpload.Error())
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		ticket := avatarTicket()
+
+		mockRedisRepo.EXPECT().GetTicketCtx(gomock.Any(), gomock.Any()).Return(ticket, nil)
+		mockAWSRepo.EXPECT().StatObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(&models.StoredObject{Size: 5000, ContentType: "image/png"}, nil)
+		mockRedisRepo.EXPECT().DeleteTicketCtx(gomock.Any(), gomock.Any()).Return(nil)
+		mockAWSRepo.EXPECT().RemoveObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(nil)
+
+		result, err := uploadsUC.Complete(ctx, userID, ticket.TicketID)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), httpErrors.InvalidUpload.Error())
+	})
+
+	t.Run("Not uploaded", func(t *testing.T) {
+		ticket := avatarTicket()
+
+		mockRedisRepo.EXPECT().GetTicketCtx(gomock.Any(), gomock.Any()).Return(ticket, nil)
+		mockAWSRepo.EXPECT().StatObject(gomock.Any(), ticket.Bucket, ticket.ObjectName).Return(nil, errors.Wrap(blobstore.ErrNotFound, "uploadsAWSRepository.StatObject"))
+
+		result, err := uploadsUC.Complete(ctx, userID, ticket.TicketID)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), httpErrors.UploadNotReceived.Error())
+	})
+
+	t.Run("Ticket of another user", func(t *testing.T) {
+		ticket := avatarTicket()
+
+		mockRedisRepo.EXPECT().GetTicketCtx(gomock.Any(), gomock.Any()).Return(ticket, nil)
+
+		result, err := uploadsUC.Complete(ctx, uuid.New(), ticket.TicketID)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+}
@@ -0,0 +1,13 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS totp_secret     VARCHAR(64)              DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS totp_enabled_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes
+(
+    code_id    UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id    UUID                     NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    code_hash  VARCHAR(64)              NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE          DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, code_hash)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    key_id       UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id      UUID                     NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    name         VARCHAR(64)              NOT NULL CHECK ( name <> '' ),
+    prefix       VARCHAR(16)              NOT NULL UNIQUE CHECK ( prefix <> '' ),
+    key_hash     VARCHAR(64)              NOT NULL CHECK ( key_hash <> '' ),
+    scopes       TEXT[]                   NOT NULL DEFAULT '{}',
+    expires_at   TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS api_keys_user_id_idx ON api_keys (user_id);
@@ -0,0 +1,59 @@
+DROP TABLE IF EXISTS role_permissions CASCADE;
+DROP TABLE IF EXISTS permissions CASCADE;
+DROP TABLE IF EXISTS roles CASCADE;
+
+CREATE TABLE IF NOT EXISTS roles
+(
+    role_id     UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    name        VARCHAR(32)              NOT NULL UNIQUE CHECK ( name <> '' ),
+    description VARCHAR(250),
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS permissions
+(
+    permission_id UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    name          VARCHAR(64)              NOT NULL UNIQUE CHECK ( name <> '' ),
+    description   VARCHAR(250),
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions
+(
+    role_id       UUID NOT NULL REFERENCES roles (role_id) ON DELETE CASCADE,
+    permission_id UUID NOT NULL REFERENCES permissions (permission_id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+INSERT INTO roles (name, description)
+VALUES ('user', 'Default role of registered users'),
+       ('moderator', 'Moderates news and comments of other users'),
+       ('admin', 'Full access');
+
+INSERT INTO permissions (name, description)
+VALUES ('news:update:any', 'Update news of any author'),
+       ('news:delete:any', 'Delete news of any author'),
+       ('comments:update:any', 'Update comments of any author'),
+       ('comments:delete:any', 'Delete comments of any author'),
+       ('users:update:any', 'Update profile of any user'),
+       ('users:delete', 'Delete users'),
+       ('users:unlock', 'Unlock users locked out after failed logins'),
+       ('roles:manage', 'Manage roles, permissions and user roles');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+         JOIN permissions p ON p.name IN ('news:delete:any', 'comments:delete:any')
+WHERE r.name = 'moderator';
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+         CROSS JOIN permissions p
+WHERE r.name = 'admin';
+
+UPDATE users SET role = 'user' WHERE role NOT IN (SELECT name FROM roles);
+
+ALTER TABLE users
+    ALTER COLUMN role TYPE VARCHAR(32),
+    ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles (name) ON UPDATE CASCADE;
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS comments_author_id_idx;
+DROP INDEX IF EXISTS news_author_id_idx;
+
+DELETE FROM comments WHERE author_id IS NULL;
+ALTER TABLE comments
+    DROP CONSTRAINT IF EXISTS comments_author_id_fkey,
+    ADD CONSTRAINT comments_author_id_fkey FOREIGN KEY (author_id) REFERENCES users (user_id) ON DELETE CASCADE,
+    ALTER COLUMN author_id SET NOT NULL;
+
+DELETE FROM news WHERE author_id IS NULL;
+ALTER TABLE news
+    DROP CONSTRAINT IF EXISTS news_author_id_fkey,
+    ADD CONSTRAINT news_author_id_fkey FOREIGN KEY (author_id) REFERENCES users (user_id),
+    ALTER COLUMN author_id SET NOT NULL;
@@ -0,0 +1,12 @@
+ALTER TABLE news
+    ALTER COLUMN author_id DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS news_author_id_fkey,
+    ADD CONSTRAINT news_author_id_fkey FOREIGN KEY (author_id) REFERENCES users (user_id) ON DELETE SET NULL;
+
+ALTER TABLE comments
+    ALTER COLUMN author_id DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS comments_author_id_fkey,
+    ADD CONSTRAINT comments_author_id_fkey FOREIGN KEY (author_id) REFERENCES users (user_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS news_author_id_idx ON news (author_id);
+CREATE INDEX IF NOT EXISTS comments_author_id_idx ON comments (author_id);
@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'users:suspend';
+
+DROP TABLE IF EXISTS user_suspensions CASCADE;
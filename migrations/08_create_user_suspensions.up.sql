@@ -0,0 +1,25 @@
+DROP TABLE IF EXISTS user_suspensions CASCADE;
+CREATE TABLE IF NOT EXISTS user_suspensions
+(
+    suspension_id UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id       UUID                     NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    kind          VARCHAR(16)              NOT NULL CHECK ( kind IN ('suspend', 'ban') ),
+    reason        VARCHAR(500)             NOT NULL CHECK ( reason <> '' ),
+    expires_at    TIMESTAMP WITH TIME ZONE,
+    created_by    UUID REFERENCES users (user_id) ON DELETE SET NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    lifted_at     TIMESTAMP WITH TIME ZONE,
+    lifted_by     UUID REFERENCES users (user_id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS user_suspensions_user_id_idx ON user_suspensions (user_id);
+CREATE INDEX IF NOT EXISTS user_suspensions_active_idx ON user_suspensions (kind, created_at) WHERE lifted_at IS NULL;
+
+INSERT INTO permissions (name, description)
+VALUES ('users:suspend', 'Suspend and ban users');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+         JOIN permissions p ON p.name = 'users:suspend'
+WHERE r.name = 'admin';
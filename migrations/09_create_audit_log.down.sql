@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name IN ('users:impersonate', 'audit:read');
+
+DROP TABLE IF EXISTS audit_log CASCADE;
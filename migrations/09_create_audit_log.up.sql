@@ -0,0 +1,27 @@
+DROP TABLE IF EXISTS audit_log CASCADE;
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    entry_id   UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    actor_id   UUID REFERENCES users (user_id) ON DELETE SET NULL,
+    user_id    UUID REFERENCES users (user_id) ON DELETE SET NULL,
+    action     VARCHAR(64)              NOT NULL CHECK ( action <> '' ),
+    method     VARCHAR(10)              NOT NULL DEFAULT '',
+    path       VARCHAR(512)             NOT NULL DEFAULT '',
+    status     INTEGER                  NOT NULL DEFAULT 0,
+    ip         VARCHAR(64)              NOT NULL DEFAULT '',
+    request_id VARCHAR(64)              NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_actor_id_idx ON audit_log (actor_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_log_user_id_idx ON audit_log (user_id, created_at);
+
+INSERT INTO permissions (name, description)
+VALUES ('users:impersonate', 'Sign in as another user for support'),
+       ('audit:read', 'Read audit log');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+         JOIN permissions p ON p.name IN ('users:impersonate', 'audit:read')
+WHERE r.name = 'admin';
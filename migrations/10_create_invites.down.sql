@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE name = 'invites:manage';
+
+DROP TABLE IF EXISTS invite_redemptions CASCADE;
+DROP TABLE IF EXISTS invites CASCADE;
@@ -0,0 +1,34 @@
+DROP TABLE IF EXISTS invite_redemptions CASCADE;
+DROP TABLE IF EXISTS invites CASCADE;
+CREATE TABLE IF NOT EXISTS invites
+(
+    invite_id  UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    code_hash  VARCHAR(64)              NOT NULL UNIQUE,
+    max_uses   INTEGER                  NOT NULL CHECK ( max_uses > 0 ),
+    uses       INTEGER                  NOT NULL DEFAULT 0 CHECK ( uses >= 0 AND uses <= max_uses ),
+    role       VARCHAR(32) REFERENCES roles (name) ON UPDATE CASCADE ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by UUID REFERENCES users (user_id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS invites_created_at_idx ON invites (created_at);
+
+CREATE TABLE IF NOT EXISTS invite_redemptions
+(
+    invite_id   UUID                     NOT NULL REFERENCES invites (invite_id) ON DELETE CASCADE,
+    user_id     UUID PRIMARY KEY REFERENCES users (user_id) ON DELETE CASCADE,
+    redeemed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS invite_redemptions_invite_id_idx ON invite_redemptions (invite_id);
+
+INSERT INTO permissions (name, description)
+VALUES ('invites:manage', 'Create, list and revoke registration invites');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+         JOIN permissions p ON p.name = 'invites:manage'
+WHERE r.name = 'admin';
@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'users:read:private';
+
+DROP TABLE IF EXISTS user_privacy CASCADE;
@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS user_privacy CASCADE;
+CREATE TABLE IF NOT EXISTS user_privacy
+(
+    user_id           UUID PRIMARY KEY REFERENCES users (user_id) ON DELETE CASCADE,
+    show_email        BOOLEAN                  NOT NULL DEFAULT FALSE,
+    show_phone_number BOOLEAN                  NOT NULL DEFAULT FALSE,
+    show_address      BOOLEAN                  NOT NULL DEFAULT FALSE,
+    show_location     BOOLEAN                  NOT NULL DEFAULT FALSE,
+    show_gender       BOOLEAN                  NOT NULL DEFAULT FALSE,
+    show_birthday     BOOLEAN                  NOT NULL DEFAULT FALSE,
+    updated_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO permissions (name, description)
+VALUES ('users:read:private', 'Read private profile fields of any user');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+         JOIN permissions p ON p.name = 'users:read:private'
+WHERE r.name = 'admin';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS news_author_id_created_at_idx;
+
+DROP TABLE IF EXISTS follows CASCADE;
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS follows CASCADE;
+CREATE TABLE IF NOT EXISTS follows
+(
+    follower_id UUID                     NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    followee_id UUID                     NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK ( follower_id <> followee_id )
+);
+
+CREATE INDEX IF NOT EXISTS follows_followee_id_created_at_idx ON follows (followee_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS follows_follower_id_created_at_idx ON follows (follower_id, created_at DESC);
+
+-- Feed reads newest news of every followed author through this index
+CREATE INDEX IF NOT EXISTS news_author_id_created_at_idx ON news (author_id, created_at DESC, news_id DESC);
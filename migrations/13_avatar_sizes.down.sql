@@ -0,0 +1,9 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS avatar VARCHAR(512);
+
+UPDATE users
+SET avatar = avatars ->> '512'
+WHERE avatars IS NOT NULL;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS avatars;
@@ -0,0 +1,10 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS avatars JSONB;
+
+-- Avatars uploaded before resizing have one image, it is used for every size
+UPDATE users
+SET avatars = jsonb_build_object('64', avatar, '256', avatar, '512', avatar)
+WHERE avatar IS NOT NULL;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS avatar;
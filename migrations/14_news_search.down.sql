@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS news_search_vector_idx;
+
+ALTER TABLE news
+    DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE news
+    DROP COLUMN IF EXISTS search_language;
+
+CREATE INDEX IF NOT EXISTS news_title_id_idx ON news (title);
@@ -0,0 +1,15 @@
+-- Stemming language of every news item is kept, so changing configured language does not mix stems of one row
+ALTER TABLE news
+    ADD COLUMN IF NOT EXISTS search_language REGCONFIG NOT NULL DEFAULT 'english';
+
+-- Title matches rank above content matches
+ALTER TABLE news
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+            setweight(to_tsvector(search_language, coalesce(title, '')), 'A') ||
+            setweight(to_tsvector(search_language, coalesce(content, '')), 'B')
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS news_search_vector_idx ON news USING GIN (search_vector);
+
+-- Only served title search, which now uses search vector
+DROP INDEX IF EXISTS news_title_id_idx;
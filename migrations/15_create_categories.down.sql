@@ -0,0 +1,16 @@
+DELETE FROM permissions WHERE name = 'categories:manage';
+
+ALTER TABLE news
+    ADD COLUMN IF NOT EXISTS category VARCHAR(250);
+
+UPDATE news n
+SET category = c.name
+FROM categories c
+WHERE c.category_id = n.category_id;
+
+DROP INDEX IF EXISTS news_category_id_created_at_idx;
+
+ALTER TABLE news
+    DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS categories CASCADE;
@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS categories
+(
+    category_id UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    slug        VARCHAR(64)              NOT NULL UNIQUE,
+    name        VARCHAR(64)              NOT NULL,
+    description VARCHAR(250),
+    parent_id   UUID REFERENCES categories (category_id) ON DELETE RESTRICT CHECK ( parent_id <> category_id ),
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON categories (parent_id);
+
+ALTER TABLE news
+    ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES categories (category_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS news_category_id_created_at_idx ON news (category_id, created_at);
+
+-- News were created with category bound into image_url as well, such values are not urls
+UPDATE news
+SET image_url = NULL
+WHERE image_url = category
+  AND image_url !~* '^https?://';
+
+-- Free text categories become top level categories, values differing only by case or punctuation are merged
+INSERT INTO categories (slug, name)
+SELECT DISTINCT ON (slug) slug, LEFT(TRIM(category), 64)
+FROM (
+         SELECT category,
+                LEFT(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(TRIM(category)), '[^a-z0-9]+', '-', 'g')), 64) AS slug
+         FROM news
+         WHERE category IS NOT NULL
+     ) c
+WHERE slug <> ''
+ORDER BY slug, category
+ON CONFLICT (slug) DO NOTHING;
+
+UPDATE news n
+SET category_id = c.category_id
+FROM categories c
+WHERE n.category IS NOT NULL
+  AND c.slug = LEFT(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(TRIM(n.category)), '[^a-z0-9]+', '-', 'g')), 64);
+
+ALTER TABLE news
+    DROP COLUMN IF EXISTS category;
+
+INSERT INTO permissions (name, description)
+VALUES ('categories:manage', 'Create, update and delete news categories');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+         JOIN permissions p ON p.name = 'categories:manage'
+WHERE r.name = 'admin';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS news_tags CASCADE;
+DROP TABLE IF EXISTS tags CASCADE;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS news_created_at_news_id_idx;
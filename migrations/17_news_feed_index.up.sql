@@ -0,0 +1,2 @@
+-- Feed walks all news newest first and stops after the page is filled
+CREATE INDEX IF NOT EXISTS news_created_at_news_id_idx ON news (created_at DESC, news_id DESC);
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS news_author_id_idx ON news (author_id);
+CREATE INDEX IF NOT EXISTS news_created_at_news_id_idx ON news (created_at DESC, news_id DESC);
@@ -0,0 +1,3 @@
+-- Feed reads news per followed author again, author lookups are covered by news_author_id_created_at_idx
+DROP INDEX IF EXISTS news_created_at_news_id_idx;
+DROP INDEX IF EXISTS news_author_id_idx;
@@ -0,0 +1,80 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+)
+
+// Storage backends selected by config
+const (
+	BackendMinio      = "minio"
+	BackendFilesystem = "filesystem"
+)
+
+// Missing object or object not served publicly
+var ErrNotFound = errors.New("object not found")
+
+// Object write options
+type PutOptions struct {
+	ContentType string
+	// Public objects are readable by anyone with their url
+	Public bool
+}
+
+// Stored object, content type is not filled by List
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	ContentType  string
+}
+
+// Object storage interface, buckets are created on first write
+type BlobStore interface {
+	Put(ctx context.Context, bucket string, objectName string, reader io.Reader, size int64, opts PutOptions) error
+	Get(ctx context.Context, bucket string, objectName string) (io.ReadCloser, error)
+	Stat(ctx context.Context, bucket string, objectName string) (*ObjectInfo, error)
+	List(ctx context.Context, bucket string) ([]*ObjectInfo, error)
+	Remove(ctx context.Context, bucket string, objectName string) error
+}
+
+// Stored public file opened for serving
+type File struct {
+	io.ReadSeeker
+	io.Closer
+	Size        int64
+	ModTime     time.Time
+	ContentType string
+	ETag        string
+}
+
+// Backends which can not serve objects themselves, their public files are served by API
+type FileServer interface {
+	Open(bucket string, objectName string) (*File, error)
+}
+
+// Configured backend, minio when not set
+func Backend(cfg *config.Config) string {
+	if cfg.Store.Backend == "" {
+		return BackendMinio
+	}
+	return cfg.Store.Backend
+}
+
+// BlobStore constructor, implementation is selected by config backend
+func NewBlobStore(cfg *config.Config, awsClient *minio.Client) (BlobStore, error) {
+	switch Backend(cfg) {
+	case BackendMinio:
+		return NewMinioStore(awsClient), nil
+	case BackendFilesystem:
+		return NewFilesystemStore(cfg.Store.ImagesFolder)
+	default:
+		return nil, errors.Errorf("unknown store backend: %s", cfg.Store.Backend)
+	}
+}
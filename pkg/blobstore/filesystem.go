@@ -0,0 +1,293 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Object metadata is kept in a separate tree, so it never collides with object names
+	metaDir    = ".meta"
+	metaSuffix = ".json"
+	dirPerm    = 0755
+	// Prefix of files being written, they are not objects yet
+	tmpPrefix = ".tmp-"
+)
+
+var bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,62}$`)
+
+// Stored next to every object, written after object data
+type fileMeta struct {
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag"`
+	Public      bool   `json:"public"`
+}
+
+// Filesystem BlobStore, buckets are directories of root and public files are served by API
+type filesystemStore struct {
+	root string
+}
+
+// Filesystem BlobStore constructor, root is created when missing
+func NewFilesystemStore(root string) (BlobStore, error) {
+	if root == "" {
+		return nil, errors.New("store images folder is not set")
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewFilesystemStore.Abs")
+	}
+	if err = os.MkdirAll(absRoot, dirPerm); err != nil {
+		return nil, errors.Wrap(err, "NewFilesystemStore.MkdirAll")
+	}
+
+	return &filesystemStore{root: absRoot}, nil
+}
+
+// Write object and its metadata, existing object is replaced atomically
+func (s *filesystemStore) Put(ctx context.Context, bucket string, objectName string, reader io.Reader, size int64, opts PutOptions) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "filesystemStore.Put")
+	defer span.Finish()
+
+	dataPath, metaPath, err := s.paths(bucket, objectName)
+	if err != nil {
+		return err
+	}
+
+	hash := md5.New()
+	if err = writeFile(dataPath, io.TeeReader(reader, hash), size); err != nil {
+		return errors.Wrap(err, "filesystemStore.Put.writeFile")
+	}
+
+	meta, err := json.Marshal(&fileMeta{
+		ContentType: opts.ContentType,
+		ETag:        `"` + hex.EncodeToString(hash.Sum(nil)) + `"`,
+		Public:      opts.Public,
+	})
+	if err != nil {
+		return errors.Wrap(err, "filesystemStore.Put.json.Marshal")
+	}
+	if err = writeFile(metaPath, strings.NewReader(string(meta)), int64(len(meta))); err != nil {
+		return errors.Wrap(err, "filesystemStore.Put.writeMeta")
+	}
+
+	return nil
+}
+
+// Read object
+func (s *filesystemStore) Get(ctx context.Context, bucket string, objectName string) (io.ReadCloser, error) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "filesystemStore.Get")
+	defer span.Finish()
+
+	dataPath, _, err := s.paths(bucket, objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(dataPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrap(ErrNotFound, "filesystemStore.Get")
+		}
+		return nil, errors.Wrap(err, "filesystemStore.Get.Open")
+	}
+
+	return file, nil
+}
+
+// Object size, modification time and content type, missing object is not found
+func (s *filesystemStore) Stat(ctx context.Context, bucket string, objectName string) (*ObjectInfo, error) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "filesystemStore.Stat")
+	defer span.Finish()
+
+	dataPath, metaPath, err := s.paths(bucket, objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(dataPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrap(ErrNotFound, "filesystemStore.Stat")
+		}
+		return nil, errors.Wrap(err, "filesystemStore.Stat.Stat")
+	}
+	if info.IsDir() {
+		return nil, errors.Wrap(ErrNotFound, "filesystemStore.Stat")
+	}
+
+	object := &ObjectInfo{Key: objectName, Size: info.Size(), LastModified: info.ModTime()}
+
+	// Metadata is written after data, so object being written may have none yet
+	metaBytes, err := ioutil.ReadFile(metaPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return object, nil
+		}
+		return nil, errors.Wrap(err, "filesystemStore.Stat.ReadFile")
+	}
+	meta := &fileMeta{}
+	if err = json.Unmarshal(metaBytes, meta); err != nil {
+		return nil, errors.Wrap(err, "filesystemStore.Stat.json.Unmarshal")
+	}
+	object.ContentType = meta.ContentType
+
+	return object, nil
+}
+
+// List all objects of bucket, missing bucket has no objects
+func (s *filesystemStore) List(ctx context.Context, bucket string) ([]*ObjectInfo, error) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "filesystemStore.List")
+	defer span.Finish()
+
+	if !bucketNameRegexp.MatchString(bucket) {
+		return nil, errors.Errorf("filesystemStore invalid bucket name: %s", bucket)
+	}
+
+	bucketPath := filepath.Join(s.root, bucket)
+	objects := make([]*ObjectInfo, 0)
+	err := filepath.Walk(bucketPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && filePath == bucketPath {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), tmpPrefix) {
+			return nil
+		}
+
+		key, err := filepath.Rel(bucketPath, filePath)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, &ObjectInfo{
+			Key:          filepath.ToSlash(key),
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "filesystemStore.List.Walk")
+	}
+
+	return objects, nil
+}
+
+// Delete object and its metadata, deleting missing object is not an error
+func (s *filesystemStore) Remove(ctx context.Context, bucket string, objectName string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "filesystemStore.Remove")
+	defer span.Finish()
+
+	dataPath, metaPath, err := s.paths(bucket, objectName)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range []string{dataPath, metaPath} {
+		if err = os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "filesystemStore.Remove")
+		}
+	}
+
+	return nil
+}
+
+// Open public object for serving, private and missing objects are not found
+func (s *filesystemStore) Open(bucket string, objectName string) (*File, error) {
+	dataPath, metaPath, err := s.paths(bucket, objectName)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	metaBytes, err := ioutil.ReadFile(metaPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Wrap(err, "filesystemStore.Open.ReadFile")
+	}
+	meta := &fileMeta{}
+	if err = json.Unmarshal(metaBytes, meta); err != nil {
+		return nil, errors.Wrap(err, "filesystemStore.Open.json.Unmarshal")
+	}
+	if !meta.Public {
+		return nil, ErrNotFound
+	}
+
+	file, err := os.Open(dataPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Wrap(err, "filesystemStore.Open.Open")
+	}
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		file.Close()
+		return nil, ErrNotFound
+	}
+
+	return &File{
+		ReadSeeker:  file,
+		Closer:      file,
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+		ContentType: meta.ContentType,
+		ETag:        meta.ETag,
+	}, nil
+}
+
+// Data and metadata paths of object, names escaping bucket directory are rejected
+func (s *filesystemStore) paths(bucket string, objectName string) (string, string, error) {
+	if !bucketNameRegexp.MatchString(bucket) {
+		return "", "", errors.Errorf("filesystemStore invalid bucket name: %s", bucket)
+	}
+	if objectName == "" || strings.Contains(objectName, `\`) || path.Clean("/"+objectName) != "/"+objectName {
+		return "", "", errors.Errorf("filesystemStore invalid object name: %s", objectName)
+	}
+
+	name := filepath.FromSlash(objectName)
+	return filepath.Join(s.root, bucket, name), filepath.Join(s.root, metaDir, bucket, name+metaSuffix), nil
+}
+
+// Write file through temporary file in the same directory, so readers never see partial content
+func writeFile(filePath string, reader io.Reader, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), dirPerm); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(filePath), tmpPrefix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, reader)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && written != size {
+		return errors.Errorf("wrote %d bytes, expected %d", written, size)
+	}
+
+	return os.Rename(tmp.Name(), filePath)
+}
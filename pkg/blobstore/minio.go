@@ -0,0 +1,115 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const noSuchKey = "NoSuchKey"
+
+// Minio BlobStore, public objects are served by minio
+type minioStore struct {
+	client *minio.Client
+}
+
+// Minio BlobStore constructor
+func NewMinioStore(awsClient *minio.Client) BlobStore {
+	return &minioStore{client: awsClient}
+}
+
+// Upload object
+func (s *minioStore) Put(ctx context.Context, bucket string, objectName string, reader io.Reader, size int64, opts PutOptions) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "minioStore.Put")
+	defer span.Finish()
+
+	exists, err := s.client.BucketExists(ctx, bucket)
+	if err != nil {
+		return errors.Wrap(err, "minioStore.Put.BucketExists")
+	}
+	if !exists {
+		if err = s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+			return errors.Wrap(err, "minioStore.Put.MakeBucket")
+		}
+	}
+
+	options := minio.PutObjectOptions{ContentType: opts.ContentType}
+	if opts.Public {
+		options.UserMetadata = map[string]string{"x-amz-acl": "public-read"}
+	}
+
+	if _, err = s.client.PutObject(ctx, bucket, objectName, reader, size, options); err != nil {
+		return errors.Wrap(err, "minioStore.Put.PutObject")
+	}
+
+	return nil
+}
+
+// Download object
+func (s *minioStore) Get(ctx context.Context, bucket string, objectName string) (io.ReadCloser, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "minioStore.Get")
+	defer span.Finish()
+
+	object, err := s.client.GetObject(ctx, bucket, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "minioStore.Get.GetObject")
+	}
+
+	return object, nil
+}
+
+// Object size, modification time and content type, missing object is not found
+func (s *minioStore) Stat(ctx context.Context, bucket string, objectName string) (*ObjectInfo, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "minioStore.Stat")
+	defer span.Finish()
+
+	info, err := s.client.StatObject(ctx, bucket, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == noSuchKey {
+			return nil, errors.Wrap(ErrNotFound, "minioStore.Stat")
+		}
+		return nil, errors.Wrap(err, "minioStore.Stat.StatObject")
+	}
+
+	return &ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		LastModified: info.LastModified,
+		ContentType:  info.ContentType,
+	}, nil
+}
+
+// List all objects of bucket
+func (s *minioStore) List(ctx context.Context, bucket string) ([]*ObjectInfo, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "minioStore.List")
+	defer span.Finish()
+
+	objects := make([]*ObjectInfo, 0)
+	for object := range s.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Recursive: true}) {
+		if object.Err != nil {
+			return nil, errors.Wrap(object.Err, "minioStore.List.ListObjects")
+		}
+		objects = append(objects, &ObjectInfo{
+			Key:          object.Key,
+			Size:         object.Size,
+			LastModified: object.LastModified,
+		})
+	}
+
+	return objects, nil
+}
+
+// Delete object
+func (s *minioStore) Remove(ctx context.Context, bucket string, objectName string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "minioStore.Remove")
+	defer span.Finish()
+
+	if err := s.client.RemoveObject(ctx, bucket, objectName, minio.RemoveObjectOptions{}); err != nil {
+		return errors.Wrap(err, "minioStore.Remove.RemoveObject")
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package blobstore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AleksK1NG/api-mc/config"
+)
+
+// API route serving public files of filesystem backend
+const FilesPath = "/api/v1/files"
+
+// Public url of stored object as <base>/<bucket>/<object>, base is minio endpoint or files API route
+func ObjectURL(cfg *config.Config, bucket string, objectName string) string {
+	return fmt.Sprintf("%s/%s/%s", baseURL(cfg), bucket, objectName)
+}
+
+// Bucket and object name of url built by ObjectURL, false for urls of other hosts and backends
+func ParseObjectURL(cfg *config.Config, url string) (string, string, bool) {
+	prefix := baseURL(cfg) + "/"
+	if !strings.HasPrefix(url, prefix) {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(url, prefix), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+func baseURL(cfg *config.Config) string {
+	if Backend(cfg) == BackendFilesystem {
+		return strings.TrimSuffix(cfg.Store.PublicURL, "/") + FilesPath
+	}
+	return cfg.AWS.MinioEndpoint + "/minio"
+}
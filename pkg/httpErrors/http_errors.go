@@ -38,7 +38,67 @@ var (
 	ExistsEmailError      = errors.New("User with given email already exists")
 	InvalidJWTToken       = errors.New("Invalid JWT token")
 	InvalidJWTClaims      = errors.New("Invalid JWT claims")
+	InvalidRefreshToken   = errors.New("Invalid refresh token")
+	InvalidResetToken     = errors.New("Invalid or expired password reset token")
+	InvalidVerifyToken    = errors.New("Invalid or expired email verification token")
+	EmailNotVerified      = errors.New("Email address is not verified")
+	EmailAlreadyVerified  = errors.New("Email address is already verified")
+	InvalidTwoFactorCode  = errors.New("Invalid two factor code")
+	InvalidChallenge      = errors.New("Invalid or expired two factor challenge")
+	TwoFactorEnabled      = errors.New("Two factor authentication is already enabled")
+	TwoFactorNotEnabled   = errors.New("Two factor authentication is not enabled")
+	TwoFactorNotEnrolled  = errors.New("Two factor authentication enrollment is not started")
+	InvalidOIDCState      = errors.New("Invalid or expired authorization state")
+	UnknownProvider       = errors.New("Unknown identity provider")
+	IdentityLinked        = errors.New("Identity is already linked to another account")
+	ExternalEmailExists   = errors.New("Account with this email already exists, sign in and link the provider")
+	ExternalEmailMissing  = errors.New("Identity provider did not return an email")
+	IdPEmailUnverified    = errors.New("Identity provider did not verify the email")
+	SamePassword          = errors.New("New password must differ from current password")
+	InvalidAPIKey         = errors.New("Invalid or expired API key")
+	InvalidAPIKeyScope    = errors.New("Unknown API key scope")
+	InvalidAPIKeyExpiry   = errors.New("API key expiration must be in the future")
+	MissingAPIKeyScope    = errors.New("API key does not have required scope")
+	RoleExists            = errors.New("Role already exists")
+	PermissionExists      = errors.New("Permission already exists")
+	UnknownRole           = errors.New("Unknown role")
+	ProtectedRole         = errors.New("Built-in role or its management permission can not be removed")
+	ExportNotReady        = errors.New("Data export is not ready")
+	InvalidEraseMode      = errors.New("Unknown erase mode")
+	AccountSuspended      = errors.New("Account is suspended")
+	AccountBanned         = errors.New("Account is banned")
+	SuspendSelf           = errors.New("You can not suspend your own account")
+	SuspendPrivileged     = errors.New("You can not suspend user with permissions you do not have")
+	InvalidSuspension     = errors.New("Suspension expiration must be in the future")
+	InvalidSuspendStatus  = errors.New("Unknown suspension status, use suspended or banned")
+	ImpersonateSelf       = errors.New("You can not impersonate yourself")
+	ImpersonatePrivileged = errors.New("You can not impersonate user with permissions you do not have")
+	ImpersonationDenied   = errors.New("Action is not allowed while impersonating user")
+	InviteRequired        = errors.New("Registration requires an invite code")
+	InvalidInvite         = errors.New("Invalid, expired or used up invite code")
+	InvalidInviteExpiry   = errors.New("Invite expiration must be in the future")
+	InvitePrivilegedRole  = errors.New("You can not invite with role that has permissions you do not have")
+	FollowSelf            = errors.New("You can not follow yourself")
+	NotFollowing          = errors.New("You are not following this user")
+	InvalidUploadTicket   = errors.New("Invalid or expired upload ticket")
+	InvalidUploadTarget   = errors.New("Unknown upload target, use avatar or news_image")
+	UploadTooLarge        = errors.New("Upload exceeds maximum size")
+	UploadNotReceived     = errors.New("Uploaded object not found, upload it before completing")
+	InvalidUpload         = errors.New("Uploaded object does not match declared content type or size")
+	DirectUploadsDisabled = errors.New("Direct uploads require minio store backend")
+	CategoryExists        = errors.New("Category with this slug already exists")
+	InvalidCategorySlug   = errors.New("Category slug must be lowercase letters and digits separated by single hyphens")
+	InvalidCategoryParent = errors.New("Parent category does not exist or is the category itself or its subcategory")
+	CategoryHasChildren   = errors.New("Category has subcategories, move or delete them first")
+	CategoryClearConflict = errors.New("Category can not be set and cleared at once")
+	TooManyTags           = errors.New("News can have at most 10 tags")
+	TagTooLong            = errors.New("Tag can have at most 32 characters")
+	TagsRequired          = errors.New("At least one tag is required")
+	InvalidTagsMatch      = errors.New("Unknown tags match, use all or any")
+	TooManyRequests       = errors.New("Too many requests")
+	TooManyLoginAttempts  = errors.New("Too many failed login attempts, try again later")
 	NotAllowedImageHeader = errors.New("Not allowed image header")
+	InvalidImage          = errors.New("Image can not be decoded or its dimensions are too large")
 	NoCookie              = errors.New("not found cookie header")
 )
 
@@ -71,6 +131,23 @@ func (e RestError) Causes() interface{} {
 	return e.ErrCauses
 }
 
+// Rest error with Retry-After seconds
+type RetryAfterErr interface {
+	RestErr
+	RetryAfter() int
+}
+
+// Too many requests error struct
+type TooManyRequestsError struct {
+	RestError
+	RetryAfterSeconds int `json:"retry_after,omitempty"`
+}
+
+// Seconds until next request is allowed
+func (e TooManyRequestsError) RetryAfter() int {
+	return e.RetryAfterSeconds
+}
+
 // New Rest Error
 func NewRestError(status int, err string, causes interface{}) RestErr {
 	return RestError{
@@ -134,6 +211,18 @@ func NewForbiddenError(causes interface{}) RestErr {
 	}
 }
 
+// New Too Many Requests Error
+func NewTooManyRequestsError(retryAfter int, causes interface{}) RetryAfterErr {
+	return TooManyRequestsError{
+		RestError: RestError{
+			ErrStatus: http.StatusTooManyRequests,
+			ErrError:  TooManyRequests.Error(),
+			ErrCauses: causes,
+		},
+		RetryAfterSeconds: retryAfter,
+	}
+}
+
 // New Internal Server Error
 func NewInternalServerError(causes interface{}) RestErr {
 	result := RestError{
@@ -146,6 +235,10 @@ func NewInternalServerError(causes interface{}) RestErr {
 
 // Parser of error string messages returns RestError
 func ParseErrors(err error) RestErr {
+	if restErr, ok := err.(RestErr); ok {
+		return restErr
+	}
+
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
 		return NewRestError(http.StatusNotFound, NotFound.Error(), err)
@@ -166,9 +259,6 @@ func ParseErrors(err error) RestErr {
 	case strings.Contains(strings.ToLower(err.Error()), "bcrypt"):
 		return NewRestError(http.StatusBadRequest, BadRequest.Error(), err)
 	default:
-		if restErr, ok := err.(RestErr); ok {
-			return restErr
-		}
 		return NewInternalServerError(err)
 	}
 }
@@ -0,0 +1,120 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// Decoded size limit, protects against small files declaring huge dimensions
+	maxPixels   = 50 * 1000 * 1000
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrImageTooLarge     = errors.New("image dimensions are too large")
+)
+
+// Decode jpeg or png image into opaque RGBA with EXIF orientation applied, transparent pixels become white
+func Decode(data []byte) (*image.RGBA, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "jpeg" && format != "png") {
+		return nil, ErrUnsupportedFormat
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(img, img.Bounds(), src, bounds.Min, draw.Over)
+
+	if format == "jpeg" {
+		img = Orient(img, Orientation(data))
+	}
+
+	return img, nil
+}
+
+// Center square of image with side of shorter dimension
+func CropSquare(img *image.RGBA) *image.RGBA {
+	bounds := img.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	return img.SubImage(image.Rect(x, y, x+side, y+side)).(*image.RGBA)
+}
+
+// Resize image to width and height, every destination pixel averages source pixels it covers
+func Resize(img *image.RGBA, width int, height int) *image.RGBA {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		sy0, sy1 := span(y, srcH, height)
+		for x := 0; x < width; x++ {
+			sx0, sx1 := span(x, srcW, width)
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				i := img.PixOffset(bounds.Min.X+sx0, bounds.Min.Y+sy)
+				for sx := sx0; sx < sx1; sx++ {
+					r += uint64(img.Pix[i])
+					g += uint64(img.Pix[i+1])
+					b += uint64(img.Pix[i+2])
+					a += uint64(img.Pix[i+3])
+					n++
+					i += 4
+				}
+			}
+
+			j := dst.PixOffset(x, y)
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(b / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// Encode image as jpeg, output never carries source metadata
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Source pixels range covered by destination pixel i, at least one pixel when upscaling
+func span(i int, srcSize int, dstSize int) (int, int) {
+	start := i * srcSize / dstSize
+	end := ((i+1)*srcSize + dstSize - 1) / dstSize
+	if end <= start {
+		end = start + 1
+	}
+	if end > srcSize {
+		end = srcSize
+	}
+	return start, end
+}
@@ -0,0 +1,232 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+)
+
+// Supported asymmetric signing algorithms
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+)
+
+const (
+	keyFileExt     = ".pem"
+	activeKeyFile  = "active"
+	minRSAKeyBits  = 2048
+	keyFileMode    = 0600
+	activeFileMode = 0644
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("jwtkeys: unknown signing algorithm")
+	ErrUnknownKey       = errors.New("jwtkeys: unknown key id")
+	ErrNoActiveKey      = errors.New("jwtkeys: no active signing key")
+)
+
+// Signing or verification key, verification only keys have no private part
+type Key struct {
+	ID        string
+	Algorithm string
+	private   crypto.Signer
+	public    crypto.PublicKey
+}
+
+// Has private part and can sign tokens
+func (k *Key) CanSign() bool {
+	return k.private != nil
+}
+
+// Set of verification keys with one active signing key, falls back to a shared HS256 secret when no keys are configured
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	secret  []byte
+}
+
+// Shared secret HS256 key set constructor
+func NewSecretKeySet(secret string) *KeySet {
+	return &KeySet{secret: []byte(secret)}
+}
+
+// Load key set from directory: every *.pem file is a verification key named by its kid, the active file holds the signing kid
+func LoadDir(dir string) (*KeySet, error) {
+	keys, err := ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	activeID, err := ActiveKeyID(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &KeySet{keys: make(map[string]*Key, len(keys))}
+	for _, key := range keys {
+		ks.keys[key.ID] = key
+	}
+
+	signing, ok := ks.keys[activeID]
+	if !ok || !signing.CanSign() {
+		return nil, errors.Wrapf(ErrNoActiveKey, "jwtkeys.LoadDir: %q", activeID)
+	}
+	ks.signing = signing
+
+	return ks, nil
+}
+
+// Sign claims with the active key, the kid header tells verifiers which key to use
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if ks.secret != nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.secret)
+	}
+
+	if ks.signing == nil {
+		return "", ErrNoActiveKey
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(ks.signing.Algorithm), claims)
+	token.Header["kid"] = ks.signing.ID
+
+	return token.SignedString(ks.signing.private)
+}
+
+// Keyfunc for jwt.Parse, picks the verification key by kid and rejects algorithms that do not match the key
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if ks.secret != nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signin method %v", token.Header["alg"])
+		}
+		return ks.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownKey, "%q", kid)
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signin method %v", token.Header["alg"])
+	}
+
+	return key.public, nil
+}
+
+// Read all keys from directory sorted by kid
+func ReadDir(dir string) ([]*Key, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "jwtkeys.ReadDir.ReadDir")
+	}
+
+	keys := make([]*Key, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != keyFileExt {
+			continue
+		}
+
+		key, err := readKeyFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+
+	return keys, nil
+}
+
+// Get active signing kid from directory
+func ActiveKeyID(dir string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, activeKeyFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrNoActiveKey
+		}
+		return "", errors.Wrap(err, "jwtkeys.ActiveKeyID.ReadFile")
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+func readKeyFile(path string) (*Key, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "jwtkeys.readKeyFile.ReadFile")
+	}
+
+	key, err := parseKey(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "jwtkeys.readKeyFile: %s", filepath.Base(path))
+	}
+	key.ID = strings.TrimSuffix(filepath.Base(path), keyFileExt)
+
+	return key, nil
+}
+
+func parseKey(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid PEM data")
+	}
+
+	var (
+		parsed interface{}
+		err    error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &Key{}
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.private = signer
+		parsed = signer.Public()
+	}
+
+	switch pub := parsed.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", minRSAKeyBits)
+		}
+		key.Algorithm = AlgorithmRS256
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return nil, errors.New("EC key must use the P-256 curve")
+		}
+		key.Algorithm = AlgorithmES256
+	default:
+		return nil, errors.New("unsupported key type")
+	}
+	key.public = parsed
+
+	return key, nil
+}
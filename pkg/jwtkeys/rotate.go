@@ -0,0 +1,122 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const rsaKeyBits = 3072
+
+// Generate new private key, kid is the key thumbprint
+func Generate(algorithm string) (*Key, []byte, error) {
+	var (
+		private crypto.Signer
+		err     error
+	)
+	switch algorithm {
+	case AlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case AlgorithmES256:
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, nil, ErrUnknownAlgorithm
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "jwtkeys.Generate.GenerateKey")
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "jwtkeys.Generate.MarshalPKCS8PrivateKey")
+	}
+
+	key := &Key{Algorithm: algorithm, private: private, public: private.Public()}
+	key.ID = key.Thumbprint()
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// Write PEM encoded key to directory, existing keys are never overwritten
+func WriteKey(dir string, kid string, data []byte) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrap(err, "jwtkeys.WriteKey.MkdirAll")
+	}
+
+	f, err := os.OpenFile(keyPath(dir, kid), os.O_WRONLY|os.O_CREATE|os.O_EXCL, keyFileMode)
+	if err != nil {
+		return errors.Wrap(err, "jwtkeys.WriteKey.OpenFile")
+	}
+
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return errors.Wrap(err, "jwtkeys.WriteKey.Write")
+	}
+
+	return f.Close()
+}
+
+// Make key the signing key, previous keys stay available for verification
+func Activate(dir string, kid string) error {
+	key, err := readKeyFile(keyPath(dir, kid))
+	if err != nil {
+		return err
+	}
+	if !key.CanSign() {
+		return fmt.Errorf("jwtkeys.Activate: key %q has no private part", kid)
+	}
+
+	tmp, err := ioutil.TempFile(dir, activeKeyFile)
+	if err != nil {
+		return errors.Wrap(err, "jwtkeys.Activate.TempFile")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.WriteString(kid + "\n"); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "jwtkeys.Activate.WriteString")
+	}
+	if err = tmp.Chmod(activeFileMode); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "jwtkeys.Activate.Chmod")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "jwtkeys.Activate.Close")
+	}
+
+	return errors.Wrap(os.Rename(tmp.Name(), filepath.Join(dir, activeKeyFile)), "jwtkeys.Activate.Rename")
+}
+
+// Remove key from directory, tokens signed with it stop verifying, the active key can not be retired
+func Retire(dir string, kid string) error {
+	activeID, err := ActiveKeyID(dir)
+	if err != nil && errors.Cause(err) != ErrNoActiveKey {
+		return err
+	}
+	if kid == activeID {
+		return fmt.Errorf("jwtkeys.Retire: key %q is active", kid)
+	}
+
+	if err = os.Remove(keyPath(dir, kid)); err != nil {
+		if os.IsNotExist(err) {
+			return errors.Wrapf(ErrUnknownKey, "%q", kid)
+		}
+		return errors.Wrap(err, "jwtkeys.Retire.Remove")
+	}
+
+	return nil
+}
+
+func keyPath(dir string, kid string) string {
+	return filepath.Join(dir, filepath.Base(kid)+keyFileExt)
+}
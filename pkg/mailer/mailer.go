@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+)
+
+const (
+	driverSMTP   = "smtp"
+	driverMemory = "memory"
+)
+
+// Email message
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer interface
+type Mailer interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// Mailer constructor, implementation is selected by config driver.
+// Memory driver drops messages, so it must be set explicitly and is meant for tests and local setups only
+func NewMailer(cfg *config.Config) (Mailer, error) {
+	switch cfg.Mailer.Driver {
+	case driverSMTP:
+		return NewSMTPMailer(cfg), nil
+	case driverMemory:
+		return NewMemoryMailer(), nil
+	case "":
+		return nil, errors.New("mailer driver is not configured, use smtp or memory")
+	default:
+		return nil, errors.Errorf("unknown mailer driver: %s", cfg.Mailer.Driver)
+	}
+}
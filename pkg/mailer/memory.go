@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+)
+
+// In memory mailer, captures sent messages instead of delivering them
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// In memory mailer constructor
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Capture message
+func (m *MemoryMailer) Send(ctx context.Context, message *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, *message)
+	return nil
+}
+
+// Get all captured messages
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages := make([]Message, len(m.messages))
+	copy(messages, m.messages)
+	return messages
+}
+
+// Get last captured message, nil if nothing was sent
+func (m *MemoryMailer) Last() *Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.messages) == 0 {
+		return nil
+	}
+	message := m.messages[len(m.messages)-1]
+	return &message
+}
+
+// Remove all captured messages
+func (m *MemoryMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = nil
+}
@@ -0,0 +1,96 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/AleksK1NG/api-mc/config"
+)
+
+// SMTP mailer
+type smtpMailer struct {
+	cfg *config.Config
+}
+
+// SMTP mailer constructor
+func NewSMTPMailer(cfg *config.Config) Mailer {
+	return &smtpMailer{cfg: cfg}
+}
+
+// Send email through smtp server, upgrades connection with STARTTLS when server supports it
+func (m *smtpMailer) Send(ctx context.Context, message *Message) error {
+	addr := net.JoinHostPort(m.cfg.Mailer.Host, m.cfg.Mailer.Port)
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return errors.Wrap(err, "smtpMailer.Send.DialContext")
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return errors.Wrap(err, "smtpMailer.Send.SetDeadline")
+		}
+	}
+
+	// Client owns connection only when it is created
+	client, err := smtp.NewClient(conn, m.cfg.Mailer.Host)
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "smtpMailer.Send.NewClient")
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: m.cfg.Mailer.Host}); err != nil {
+			return errors.Wrap(err, "smtpMailer.Send.StartTLS")
+		}
+	}
+
+	if m.cfg.Mailer.Username != "" {
+		auth := smtp.PlainAuth("", m.cfg.Mailer.Username, m.cfg.Mailer.Password, m.cfg.Mailer.Host)
+		if err = client.Auth(auth); err != nil {
+			return errors.Wrap(err, "smtpMailer.Send.Auth")
+		}
+	}
+
+	if err = client.Mail(m.cfg.Mailer.From); err != nil {
+		return errors.Wrap(err, "smtpMailer.Send.Mail")
+	}
+	for _, to := range message.To {
+		if err = client.Rcpt(to); err != nil {
+			return errors.Wrap(err, "smtpMailer.Send.Rcpt")
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return errors.Wrap(err, "smtpMailer.Send.Data")
+	}
+	if _, err = w.Write(m.buildMessage(message)); err != nil {
+		return errors.Wrap(err, "smtpMailer.Send.Write")
+	}
+	if err = w.Close(); err != nil {
+		return errors.Wrap(err, "smtpMailer.Send.Close")
+	}
+
+	return client.Quit()
+}
+
+func (m *smtpMailer) buildMessage(message *Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.cfg.Mailer.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(message.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", message.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(message.Body)
+	return []byte(b.String())
+}
@@ -0,0 +1,175 @@
+package openid
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+)
+
+// Minimal interval between JWKS refetches on unknown kid
+const keysRefreshInterval = time.Minute
+
+type keySet struct {
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func (p *Provider) verifyIDToken(ctx context.Context, rawIDToken string, nonce string) (map[string]interface{}, error) {
+	ep, err := p.getEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err = jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+			return nil, fmt.Errorf("unexpected signin method %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(ctx, ep.JWKSURL, kid)
+	}); err != nil {
+		return nil, errors.Wrap(err, "openid.verifyIDToken.ParseWithClaims")
+	}
+
+	if strings.TrimSuffix(claimString(claims, "iss"), "/") != strings.TrimSuffix(ep.Issuer, "/") {
+		return nil, errors.New("openid.verifyIDToken: invalid issuer")
+	}
+	if !audienceContains(claims["aud"], p.cfg.ClientID) {
+		return nil, errors.New("openid.verifyIDToken: invalid audience")
+	}
+	if subtle.ConstantTimeCompare([]byte(claimString(claims, "nonce")), []byte(nonce)) != 1 {
+		return nil, errors.New("openid.verifyIDToken: invalid nonce")
+	}
+	if claimString(claims, "sub") == "" {
+		return nil, errors.New("openid.verifyIDToken: missing subject")
+	}
+
+	return claims, nil
+}
+
+func (p *Provider) getKey(ctx context.Context, jwksURL string, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key := p.keys.find(kid); key != nil {
+		return key, nil
+	}
+
+	if p.keys != nil && time.Since(p.keys.fetchedAt) < keysRefreshInterval {
+		return nil, fmt.Errorf("openid.getKey: unknown key id %q", kid)
+	}
+
+	keys, err := p.fetchKeys(ctx, jwksURL)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+
+	if key := p.keys.find(kid); key != nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("openid.getKey: unknown key id %q", kid)
+}
+
+func (p *Provider) fetchKeys(ctx context.Context, jwksURL string) (*keySet, error) {
+	if jwksURL == "" {
+		return nil, errors.New("openid.fetchKeys: provider has no jwks uri")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "openid.fetchKeys.NewRequest")
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err = p.doJSON(req, &jwks); err != nil {
+		return nil, errors.Wrap(err, "openid.fetchKeys")
+	}
+
+	keys := &keySet{keys: make(map[string]*rsa.PublicKey), fetchedAt: time.Now()}
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := jwk.rsaPublicKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "openid.fetchKeys.rsaPublicKey")
+		}
+		keys.keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (s *keySet) find(kid string) *rsa.PublicKey {
+	if s == nil {
+		return nil
+	}
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key
+		}
+	}
+	return s.keys[kid]
+}
+
+func (k jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.N, "="))
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.E, "="))
+	if err != nil {
+		return nil, err
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid rsa exponent")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+func audienceContains(aud interface{}, clientID string) bool {
+	switch value := aud.(type) {
+	case string:
+		return value == clientID
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func randomString(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
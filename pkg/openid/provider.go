@@ -0,0 +1,322 @@
+package openid
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	discoveryPath  = "/.well-known/openid-configuration"
+	maxBodySize    = 1 << 20
+	defaultTimeout = 10 * time.Second
+)
+
+// Provider config, endpoints are discovered from issuer unless set explicitly
+type Config struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	JWKSURL      string
+}
+
+// Token endpoint response
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+}
+
+// Verified end user identity
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	GivenName     string
+	FamilyName    string
+}
+
+// OpenID Connect relying party for single provider
+type Provider struct {
+	cfg        Config
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	endpoints *endpoints
+	keys      *keySet
+}
+
+type endpoints struct {
+	Issuer      string `json:"issuer"`
+	AuthURL     string `json:"authorization_endpoint"`
+	TokenURL    string `json:"token_endpoint"`
+	UserInfoURL string `json:"userinfo_endpoint"`
+	JWKSURL     string `json:"jwks_uri"`
+}
+
+// Provider constructor, nil httpClient uses client with default timeout
+func NewProvider(cfg Config, httpClient *http.Client) *Provider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &Provider{cfg: cfg, httpClient: httpClient}
+}
+
+// Provider name
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// Build authorization code request url with PKCE S256 challenge
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, verifier string) (string, error) {
+	ep, err := p.getEndpoints(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(ep.AuthURL)
+	if err != nil {
+		return "", errors.Wrap(err, "openid.AuthCodeURL.Parse")
+	}
+
+	params := authURL.Query()
+	params.Set("response_type", "code")
+	params.Set("client_id", p.cfg.ClientID)
+	params.Set("redirect_uri", p.cfg.RedirectURL)
+	params.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", CodeChallenge(verifier))
+	params.Set("code_challenge_method", "S256")
+	authURL.RawQuery = params.Encode()
+
+	return authURL.String(), nil
+}
+
+// Exchange authorization code for tokens
+func (p *Provider) Exchange(ctx context.Context, code string, verifier string) (*Token, error) {
+	ep, err := p.getEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("client_secret", p.cfg.ClientSecret)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "openid.Exchange.NewRequest")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	token := &Token{}
+	if err = p.doJSON(req, token); err != nil {
+		return nil, errors.Wrap(err, "openid.Exchange")
+	}
+	if token.AccessToken == "" && token.IDToken == "" {
+		return nil, errors.New("openid.Exchange: empty token response")
+	}
+
+	return token, nil
+}
+
+// Resolve identity from verified id token, falls back to userinfo for plain OAuth2 providers
+func (p *Provider) Identity(ctx context.Context, token *Token, nonce string) (*Identity, error) {
+	if token.IDToken != "" {
+		claims, err := p.verifyIDToken(ctx, token.IDToken, nonce)
+		if err != nil {
+			return nil, err
+		}
+		identity := identityFromClaims(claims)
+		if identity.Email == "" && token.AccessToken != "" {
+			if info, err := p.userInfo(ctx, token.AccessToken); err == nil && info.Subject == identity.Subject {
+				identity.Email, identity.EmailVerified = info.Email, info.EmailVerified
+			}
+		}
+		return identity, nil
+	}
+
+	return p.userInfo(ctx, token.AccessToken)
+}
+
+func (p *Provider) userInfo(ctx context.Context, accessToken string) (*Identity, error) {
+	ep, err := p.getEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ep.UserInfoURL == "" {
+		return nil, errors.New("openid.userInfo: provider has no userinfo endpoint")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.UserInfoURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "openid.userInfo.NewRequest")
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	claims := map[string]interface{}{}
+	if err = p.doJSON(req, &claims); err != nil {
+		return nil, errors.Wrap(err, "openid.userInfo")
+	}
+
+	identity := identityFromClaims(claims)
+	if identity.Subject == "" {
+		return nil, errors.New("openid.userInfo: missing subject")
+	}
+
+	return identity, nil
+}
+
+func (p *Provider) getEndpoints(ctx context.Context) (*endpoints, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.endpoints != nil {
+		return p.endpoints, nil
+	}
+
+	ep := &endpoints{
+		Issuer:      p.cfg.Issuer,
+		AuthURL:     p.cfg.AuthURL,
+		TokenURL:    p.cfg.TokenURL,
+		UserInfoURL: p.cfg.UserInfoURL,
+		JWKSURL:     p.cfg.JWKSURL,
+	}
+
+	// Plain OAuth2 providers such as GitHub have no discovery document
+	if ep.AuthURL == "" || ep.TokenURL == "" {
+		discovered, err := p.discover(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSuffix(discovered.Issuer, "/") != strings.TrimSuffix(p.cfg.Issuer, "/") {
+			return nil, fmt.Errorf("openid.getEndpoints: issuer mismatch %q != %q", discovered.Issuer, p.cfg.Issuer)
+		}
+		ep.Issuer = discovered.Issuer
+		if ep.AuthURL == "" {
+			ep.AuthURL = discovered.AuthURL
+		}
+		if ep.TokenURL == "" {
+			ep.TokenURL = discovered.TokenURL
+		}
+		if ep.UserInfoURL == "" {
+			ep.UserInfoURL = discovered.UserInfoURL
+		}
+		if ep.JWKSURL == "" {
+			ep.JWKSURL = discovered.JWKSURL
+		}
+	}
+
+	p.endpoints = ep
+	return ep, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*endpoints, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.cfg.Issuer, "/")+discoveryPath, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "openid.discover.NewRequest")
+	}
+
+	ep := &endpoints{}
+	if err = p.doJSON(req, ep); err != nil {
+		return nil, errors.Wrap(err, "openid.discover")
+	}
+
+	return ep, nil
+}
+
+func (p *Provider) doJSON(req *http.Request, v interface{}) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(body)))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// Generate PKCE code verifier
+func GenerateVerifier() (string, error) {
+	return randomString(32)
+}
+
+// PKCE S256 code challenge for verifier
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func identityFromClaims(claims map[string]interface{}) *Identity {
+	identity := &Identity{
+		Subject:    claimString(claims, "sub"),
+		Email:      claimString(claims, "email"),
+		Name:       claimString(claims, "name"),
+		GivenName:  claimString(claims, "given_name"),
+		FamilyName: claimString(claims, "family_name"),
+	}
+
+	// GitHub style userinfo uses numeric id instead of sub
+	if identity.Subject == "" {
+		identity.Subject = claimString(claims, "id")
+	}
+
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+
+	return identity
+}
+
+func claimString(claims map[string]interface{}, name string) string {
+	switch value := claims[name].(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case float64:
+		return fmt.Sprintf("%.0f", value)
+	}
+	return ""
+}
@@ -0,0 +1,206 @@
+package passwords
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password hashing algorithms
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+const (
+	defaultMemory      = 64 * 1024
+	defaultIterations  = 3
+	defaultParallelism = 2
+	defaultSaltLength  = 16
+	defaultKeyLength   = 32
+)
+
+var (
+	ErrMismatchedPassword = errors.New("passwords: hashed password is not the hash of the given password")
+	ErrUnknownHash        = errors.New("passwords: unknown password hash format")
+	ErrUnknownAlgorithm   = errors.New("passwords: unknown password hashing algorithm")
+)
+
+// Password hashing params, zero values use defaults, memory is in KiB
+type Params struct {
+	Algorithm   string
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+	BcryptCost  int
+}
+
+// Password hasher, hashes are self describing so older algorithms and params stay verifiable
+type Hasher struct {
+	params Params
+}
+
+// Password hasher constructor
+func NewHasher(params Params) *Hasher {
+	if params.Algorithm == "" {
+		params.Algorithm = AlgorithmArgon2id
+	}
+	if params.Memory == 0 {
+		params.Memory = defaultMemory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = defaultIterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = defaultParallelism
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = defaultSaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = defaultKeyLength
+	}
+	if params.BcryptCost == 0 {
+		params.BcryptCost = bcrypt.DefaultCost
+	}
+	return &Hasher{params: params}
+}
+
+// Hash password with configured algorithm, argon2id hashes use PHC string format
+func (h *Hasher) Hash(password string) (string, error) {
+	switch h.params.Algorithm {
+	case AlgorithmArgon2id:
+		salt := make([]byte, h.params.SaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+		return encodeArgon2id(argon2Hash{
+			memory:      h.params.Memory,
+			iterations:  h.params.Iterations,
+			parallelism: h.params.Parallelism,
+			salt:        salt,
+			key:         key,
+		}), nil
+	case AlgorithmBcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.params.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	default:
+		return "", ErrUnknownAlgorithm
+	}
+}
+
+// Check hash was made with another algorithm or params than configured ones
+func (h *Hasher) NeedsRehash(hash string) bool {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		if h.params.Algorithm != AlgorithmArgon2id {
+			return true
+		}
+		decoded, err := decodeArgon2id(hash)
+		if err != nil {
+			return true
+		}
+		return decoded.memory != h.params.Memory ||
+			decoded.iterations != h.params.Iterations ||
+			decoded.parallelism != h.params.Parallelism ||
+			uint32(len(decoded.salt)) != h.params.SaltLength ||
+			uint32(len(decoded.key)) != h.params.KeyLength
+	case isBcrypt(hash):
+		if h.params.Algorithm != AlgorithmBcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost != h.params.BcryptCost
+	default:
+		return true
+	}
+}
+
+// Compare hash with password, algorithm and params are read from the hash
+func Compare(hash string, password string) error {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		decoded, err := decodeArgon2id(hash)
+		if err != nil {
+			return err
+		}
+		key := argon2.IDKey([]byte(password), decoded.salt, decoded.iterations, decoded.memory, decoded.parallelism, uint32(len(decoded.key)))
+		if subtle.ConstantTimeCompare(key, decoded.key) != 1 {
+			return ErrMismatchedPassword
+		}
+		return nil
+	case isBcrypt(hash):
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return ErrMismatchedPassword
+			}
+			return err
+		}
+		return nil
+	default:
+		return ErrUnknownHash
+	}
+}
+
+type argon2Hash struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func encodeArgon2id(h argon2Hash) string {
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.memory,
+		h.iterations,
+		h.parallelism,
+		base64.RawStdEncoding.EncodeToString(h.salt),
+		base64.RawStdEncoding.EncodeToString(h.key),
+	)
+}
+
+func decodeArgon2id(hash string) (*argon2Hash, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return nil, ErrUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrUnknownHash
+	}
+
+	decoded := &argon2Hash{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &decoded.memory, &decoded.iterations, &decoded.parallelism); err != nil {
+		return nil, ErrUnknownHash
+	}
+
+	var err error
+	if decoded.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrUnknownHash
+	}
+	if decoded.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(decoded.key) == 0 {
+		return nil, ErrUnknownHash
+	}
+
+	return decoded, nil
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
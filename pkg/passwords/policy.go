@@ -0,0 +1,112 @@
+package passwords
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultMinLength = 8
+	defaultMaxLength = 72
+	// Parts of personal data shorter than this are too common to reject
+	minPersonalPartLength = 3
+)
+
+var (
+	ErrMissingUpper     = errors.New("Password must contain an uppercase letter")
+	ErrMissingLower     = errors.New("Password must contain a lowercase letter")
+	ErrMissingDigit     = errors.New("Password must contain a digit")
+	ErrMissingSpecial   = errors.New("Password must contain a special character")
+	ErrContainsPersonal = errors.New("Password must not contain your email or name")
+)
+
+// Password policy, zero lengths use defaults
+type Policy struct {
+	MinLength      int
+	MaxLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+}
+
+// Validate password against policy, personal contains email, first and last name of user
+func (p Policy) Validate(password string, personal ...string) error {
+	minLength, maxLength := p.MinLength, p.MaxLength
+	if minLength <= 0 {
+		minLength = defaultMinLength
+	}
+	// bcrypt ignores bytes after 72
+	if maxLength <= 0 || maxLength > defaultMaxLength {
+		maxLength = defaultMaxLength
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < minLength {
+		return fmt.Errorf("Password must be at least %d characters long", minLength)
+	}
+	if length > maxLength || len(password) > defaultMaxLength {
+		return fmt.Errorf("Password must be at most %d characters long", maxLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+
+	switch {
+	case p.RequireUpper && !hasUpper:
+		return ErrMissingUpper
+	case p.RequireLower && !hasLower:
+		return ErrMissingLower
+	case p.RequireDigit && !hasDigit:
+		return ErrMissingDigit
+	case p.RequireSpecial && !hasSpecial:
+		return ErrMissingSpecial
+	}
+
+	lower := strings.ToLower(password)
+	for _, part := range personalParts(personal) {
+		if strings.Contains(lower, part) {
+			return ErrContainsPersonal
+		}
+	}
+
+	return nil
+}
+
+// Split personal data to lowercase parts, email is checked as whole and by local part
+func personalParts(personal []string) []string {
+	parts := make([]string, 0, len(personal)*2)
+	for _, value := range personal {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+		if at := strings.Index(value, "@"); at > 0 {
+			parts = append(parts, value, value[:at])
+			continue
+		}
+		parts = append(parts, strings.Fields(value)...)
+	}
+
+	result := parts[:0]
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) >= minPersonalPartLength {
+			result = append(result, part)
+		}
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	maxCursorSize = 100
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// Keyset pagination position, last item of previous page
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Encode cursor to opaque url safe string
+func (c *Cursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode cursor from string returned by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), ",", 2)
+	if len(parts) != 2 {
+		return nil, errInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	return &Cursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// Cursor pagination query params
+type CursorQuery struct {
+	Size  int
+	After *Cursor
+}
+
+// Get limit
+func (q *CursorQuery) GetLimit() int {
+	return q.Size
+}
+
+// Get cursor pagination query struct from size and cursor query params
+func GetCursorFromCtx(c echo.Context) (*CursorQuery, error) {
+	q := &CursorQuery{Size: defaultSize}
+
+	if sizeQuery := c.QueryParam("size"); sizeQuery != "" {
+		n, err := strconv.Atoi(sizeQuery)
+		if err != nil || n < 1 {
+			return nil, errors.New("invalid size")
+		}
+		if n > maxCursorSize {
+			n = maxCursorSize
+		}
+		q.Size = n
+	}
+
+	if cursorQuery := c.QueryParam("cursor"); cursorQuery != "" {
+		cursor, err := DecodeCursor(cursorQuery)
+		if err != nil {
+			return nil, err
+		}
+		q.After = cursor
+	}
+
+	return q, nil
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -33,9 +34,19 @@ func GetCtxWithReqID(c echo.Context) (context.Context, context.CancelFunc) {
 	return ctx, cancel
 }
 
-// Get context  with request id
+// IPCtxKey is a key used for the client ip address in context
+type IPCtxKey struct{}
+
+// Get context  with request id and client ip
 func GetRequestCtx(c echo.Context) context.Context {
-	return context.WithValue(c.Request().Context(), ReqIDCtxKey{}, GetRequestID(c))
+	ctx := context.WithValue(c.Request().Context(), ReqIDCtxKey{}, GetRequestID(c))
+	return context.WithValue(ctx, IPCtxKey{}, c.RealIP())
+}
+
+// Get client ip address from context
+func GetIPFromCtx(ctx context.Context) string {
+	ip, _ := ctx.Value(IPCtxKey{}).(string)
+	return ip
 }
 
 // Get config path for local or docker
@@ -99,11 +110,36 @@ func GetUserFromCtx(ctx context.Context) (*models.User, error) {
 	return user, nil
 }
 
+// PermissionsCtxKey is a key used for permissions of the user role in the context
+type PermissionsCtxKey struct{}
+
+// Check permission of ctx user role is granted
+func HasPermission(ctx context.Context, permission string) bool {
+	permissions, ok := ctx.Value(PermissionsCtxKey{}).([]string)
+	if !ok {
+		return false
+	}
+
+	for _, granted := range permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
 // Get user ip address
 func GetIPAddress(c echo.Context) string {
 	return c.Request().RemoteAddr
 }
 
+// Set Retry-After header for rate limited errors
+func SetRetryAfterHeader(c echo.Context, err error) {
+	if retryErr, ok := err.(httpErrors.RetryAfterErr); ok {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(retryErr.RetryAfter()))
+	}
+}
+
 // Error response with logging error for echo context
 func ErrResponseWithLog(ctx echo.Context, logger logger.Logger, err error) error {
 	logger.Errorf(
@@ -166,26 +202,3 @@ func SanitizeRequest(ctx echo.Context, request interface{}) error {
 
 	return validate.StructCtx(ctx.Request().Context(), request)
 }
-
-var allowedImagesContentTypes = map[string]string{
-	"image/bmp":                "bmp",
-	"image/gif":                "gif",
-	"image/png":                "png",
-	"image/jpeg":               "jpeg",
-	"image/jpg":                "jpg",
-	"image/svg+xml":            "svg",
-	"image/webp":               "webp",
-	"image/tiff":               "tiff",
-	"image/vnd.microsoft.icon": "ico",
-}
-
-func CheckImageFileContentType(fileContent []byte) (string, error) {
-	contentType := http.DetectContentType(fileContent)
-
-	extension, ok := allowedImagesContentTypes[contentType]
-	if !ok {
-		return "", errors.New("this content type is not allowed")
-	}
-
-	return extension, nil
-}
@@ -11,6 +11,7 @@ import (
 	"github.com/AleksK1NG/api-mc/pkg/httpErrors"
 )
 
+// Formats imaging.Decode reads, any other image is rejected before upload processing
 var allowedImagesContentType = map[string]string{
 	"image/png":  "png",
 	"image/jpg":  "jpg",
@@ -28,7 +29,7 @@ func determineFileContentType(fileHeader textproto.MIMEHeader) (string, error) {
 func CheckImageContentType(image *multipart.FileHeader) error {
 	// Check content type from header
 	if !IsAllowedImageHeader(image) {
-		return httpErrors.NotAllowedImageHeader
+		return notAllowedImageError()
 	}
 
 	// Check real content type
@@ -44,11 +45,24 @@ func CheckImageContentType(image *multipart.FileHeader) error {
 	}
 
 	if !IsAllowedImageContentType(fileHeader) {
-		return httpErrors.NotAllowedImageHeader
+		return notAllowedImageError()
 	}
 	return nil
 }
 
+// Check real content type of image file and get its extension
+func CheckImageFileContentType(fileContent []byte) (string, error) {
+	extension, allowed := GetImageContentType(fileContent)
+	if !allowed {
+		return "", notAllowedImageError()
+	}
+	return extension, nil
+}
+
+func notAllowedImageError() error {
+	return httpErrors.NewRestError(http.StatusBadRequest, httpErrors.NotAllowedImageHeader.Error(), nil)
+}
+
 func IsAllowedImageHeader(image *multipart.FileHeader) bool {
 	contentType, err := determineFileContentType(image.Header)
 	if err != nil {
@@ -11,8 +11,18 @@ import (
 
 	"github.com/AleksK1NG/api-mc/config"
 	"github.com/AleksK1NG/api-mc/internal/models"
+	"github.com/AleksK1NG/api-mc/pkg/jwtkeys"
 )
 
+const (
+	defaultAccessTokenExpire       = 60 * 60
+	defaultEmailVerificationExpire = 24 * 60 * 60
+	emailVerificationPurpose       = "email_verification"
+)
+
+// Audience of access tokens, verifiers must reject tokens issued for other purposes like email verification
+const AccessTokenAudience = "api-mc:access"
+
 // JWT Claims struct
 type Claims struct {
 	Email string `json:"email"`
@@ -20,22 +30,63 @@ type Claims struct {
 	jwt.StandardClaims
 }
 
+// Load JWT signing keys from config keys dir, shared secret HS256 if none is configured
+func NewJWTKeySet(config *config.Config) (*jwtkeys.KeySet, error) {
+	if config.JWT.KeysDir == "" {
+		return jwtkeys.NewSecretKeySet(config.Server.JwtSecretKey), nil
+	}
+	return jwtkeys.LoadDir(config.JWT.KeysDir)
+}
+
 // Generate new JWT Token
-func GenerateJWTToken(user *models.User, config *config.Config) (string, error) {
+func GenerateJWTToken(user *models.User, config *config.Config, keys *jwtkeys.KeySet) (string, error) {
 	// Register the JWT claims, which includes the username and expiry time
 	claims := &Claims{
 		Email: user.Email,
 		ID:    user.UserID.String(),
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Minute * 60).Unix(),
+			Audience:  AccessTokenAudience,
+			ExpiresAt: time.Now().Add(time.Second * time.Duration(GetAccessTokenExpire(config))).Unix(),
 		},
 	}
 
-	// Declare the token with the algorithm used for signing, and the claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	// Sign with the active key, kid header tells verifiers which key was used
+	tokenString, err := keys.Sign(claims)
+	if err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// Get access token lifetime in seconds
+func GetAccessTokenExpire(config *config.Config) int {
+	if config.Server.AccessTokenExpire <= 0 {
+		return defaultAccessTokenExpire
+	}
+	return config.Server.AccessTokenExpire
+}
+
+// Email verification JWT Claims struct
+type EmailVerificationClaims struct {
+	Email   string `json:"email"`
+	ID      string `json:"id"`
+	Purpose string `json:"purpose"`
+	jwt.StandardClaims
+}
 
-	// Register the JWT string
-	tokenString, err := token.SignedString([]byte(config.Server.JwtSecretKey))
+// Generate signed email verification token
+func GenerateEmailVerificationToken(user *models.User, config *config.Config, keys *jwtkeys.KeySet) (string, error) {
+	claims := &EmailVerificationClaims{
+		Email:   user.Email,
+		ID:      user.UserID.String(),
+		Purpose: emailVerificationPurpose,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Second * time.Duration(GetEmailVerificationExpire(config))).Unix(),
+		},
+	}
+
+	tokenString, err := keys.Sign(claims)
 	if err != nil {
 		return "", err
 	}
@@ -43,6 +94,29 @@ func GenerateJWTToken(user *models.User, config *config.Config) (string, error)
 	return tokenString, nil
 }
 
+// Parse and validate email verification token
+func ParseEmailVerificationToken(tokenString string, keys *jwtkeys.KeySet) (*EmailVerificationClaims, error) {
+	claims := &EmailVerificationClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, keys.Keyfunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid || claims.Purpose != emailVerificationPurpose {
+		return nil, errors.New("invalid token ")
+	}
+
+	return claims, nil
+}
+
+// Get email verification token lifetime in seconds
+func GetEmailVerificationExpire(config *config.Config) int {
+	if config.Auth.EmailVerificationExpire <= 0 {
+		return defaultEmailVerificationExpire
+	}
+	return config.Auth.EmailVerificationExpire
+}
+
 // Extract JWT From Request
 func ExtractJWTFromRequest(r *http.Request) (map[string]interface{}, error) {
 	// Get the JWT string
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+const searchOr = "OR"
+
+type searchToken struct {
+	text   string
+	phrase bool
+	negate bool
+}
+
+// Postgres to_tsquery expression of web search style input.
+// Words are matched all together, "quoted phrase" matches adjacent words, word* matches prefix,
+// -word excludes and OR between terms matches either of them. Empty when input has no words.
+// Only letters and digits get into expression, so user input never breaks to_tsquery syntax.
+func ToTSQuery(input string) string {
+	var (
+		builder strings.Builder
+		or      bool
+	)
+
+	for _, token := range tokenizeSearch(input) {
+		if !token.phrase && !token.negate && token.text == searchOr {
+			or = builder.Len() > 0
+			continue
+		}
+
+		prefix := !token.phrase && strings.HasSuffix(token.text, "*")
+		words := strings.FieldsFunc(strings.ToLower(token.text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(words) == 0 {
+			continue
+		}
+
+		// Words of phrase or hyphenated word must follow each other
+		term := strings.Join(words, " <-> ")
+		if prefix {
+			term += ":*"
+		}
+		if len(words) > 1 {
+			term = "(" + term + ")"
+		}
+		if token.negate {
+			term = "!" + term
+		}
+
+		if builder.Len() > 0 {
+			if or {
+				builder.WriteString(" | ")
+			} else {
+				builder.WriteString(" & ")
+			}
+		}
+		builder.WriteString(term)
+		or = false
+	}
+
+	return builder.String()
+}
+
+// Split input by spaces, quoted text is one phrase token, leading minus negates token
+func tokenizeSearch(input string) []searchToken {
+	runes := []rune(input)
+	tokens := make([]searchToken, 0)
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		negate := false
+		if runes[i] == '-' {
+			negate = true
+			i++
+		}
+
+		if i < len(runes) && runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			tokens = append(tokens, searchToken{text: string(runes[i+1 : end]), phrase: true, negate: negate})
+			i = end + 1
+			continue
+		}
+
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) {
+			i++
+		}
+		tokens = append(tokens, searchToken{text: string(runes[start:i]), negate: negate})
+	}
+
+	return tokens
+}
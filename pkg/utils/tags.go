@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tag name of free form input: lower case, separators become single hyphen and
+// only letters, digits and + # . are kept, so "GoLang", "golang" and "#golang" are one tag
+func NormalizeTag(input string) string {
+	var builder strings.Builder
+	separator := false
+
+	for _, r := range strings.ToLower(strings.TrimSpace(input)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' || r == '.':
+			if separator && builder.Len() > 0 {
+				builder.WriteRune('-')
+			}
+			separator = false
+			builder.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			separator = true
+		}
+	}
+
+	// Hashtag mark and sentence dot are not part of the name, while c# and .net keep theirs
+	return strings.Trim(strings.TrimRight(strings.TrimLeft(builder.String(), "#"), "."), "-")
+}
+
+// Normalized tags without empty names and duplicates, input order is kept
+func NormalizeTags(input []string) []string {
+	tags := make([]string, 0, len(input))
+	seen := make(map[string]struct{}, len(input))
+
+	for _, name := range input {
+		tag := NormalizeTag(name)
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
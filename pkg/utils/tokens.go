@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const (
+	refreshTokenBytes = 32
+	recoveryCodeBytes = 5
+)
+
+// Generate cryptographically secure random url safe token of given bytes length
+func GenerateRandomToken(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Generate new opaque refresh token
+func GenerateRefreshToken() (string, error) {
+	return GenerateRandomToken(refreshTokenBytes)
+}
+
+// Generate human friendly single use recovery code like "a1b2c-3d4e5"
+func GenerateRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := hex.EncodeToString(b)
+	return code[:5] + "-" + code[5:], nil
+}
+
+// Hash token with sha256, secrets are stored only as hashes
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}